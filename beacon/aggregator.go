@@ -0,0 +1,25 @@
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// TargetAggregatorsPerCommittee is the target number of aggregators in each committee
+const TargetAggregatorsPerCommittee = 16
+
+// IsAggregator returns true if the given selection proof (slot signature) selects the validator as an aggregator
+//
+// Spec pseudocode definition:
+//   def is_aggregator(state: BeaconState, slot: Slot, index: CommitteeIndex, slot_signature: BLSSignature) -> bool:
+//    committee = get_beacon_committee(state, slot, index)
+//    modulo = max(1, len(committee) // TARGET_AGGREGATORS_PER_COMMITTEE)
+//    return bytes_to_uint64(hash(slot_signature)[0:8]) % modulo == 0
+func IsAggregator(committeeLength uint64, slotSig []byte) bool {
+	modulo := committeeLength / TargetAggregatorsPerCommittee
+	if modulo < 1 {
+		modulo = 1
+	}
+	h := sha256.Sum256(slotSig)
+	return binary.LittleEndian.Uint64(h[:8])%modulo == 0
+}
@@ -0,0 +1,29 @@
+package beacon
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIsAggregator(t *testing.T) {
+	sig := make([]byte, 96)
+
+	tests := []struct {
+		name            string
+		committeeLength uint64
+		sig             []byte
+		expected        bool
+	}{
+		{"small committee", 10, sig, true},
+		{"target committee", TargetAggregatorsPerCommittee, sig, true},
+		// sha256 of 96 zero bytes starts with 0x2e 0xa9..., modulo 8 (128 / 16) is 6
+		{"large committee, not selected", 128, sig, false},
+		{"large committee, selected", 2 * TargetAggregatorsPerCommittee, sig, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, IsAggregator(test.committeeLength, test.sig))
+		})
+	}
+}
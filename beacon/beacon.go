@@ -8,6 +8,8 @@ import (
 	"go.uber.org/zap"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
@@ -16,11 +18,23 @@ type Options struct {
 	Context        context.Context
 	Logger         *zap.Logger
 	Network        string `yaml:"Network" env:"NETWORK" env-default:"prater"`
-	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true"`
+	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true" env-description:"Comma separated list of beacon nodes, requests fail over to the next healthy node by the given order"`
 	Graffiti       []byte
 	DB             basedb.IDb
+
+	KeyManager       string `yaml:"KeyManager" env:"KEY_MANAGER" env-default:"local" env-description:"Where share keys are held, 'local' (default) for the node db or 'remote' for a Web3Signer that signs the beacon duties. with 'remote', iBFT messages are signed locally with the share keys, as they are not beacon objects"`
+	RemoteSignerAddr string `yaml:"RemoteSignerAddr" env:"REMOTE_SIGNER_ADDR" env-description:"Address of the remote signer, required when KeyManager is 'remote'"`
+
+	SubmitToAllBeaconNodes bool `yaml:"SubmitToAllBeaconNodes" env:"BEACON_SUBMIT_TO_ALL" env-default:"false" env-description:"Submit attestations to all healthy beacon nodes rather than to a single node"`
 }
 
+const (
+	// LocalKeyManager keeps share keys in the node db
+	LocalKeyManager = "local"
+	// RemoteKeyManager signs with share keys held by a remote signing service
+	RemoteKeyManager = "remote"
+)
+
 // Beacon represents the behavior of the beacon node connector
 type Beacon interface {
 	KeyManager
@@ -32,6 +46,16 @@ type Beacon interface {
 	// GetDuties returns duties for the passed validators indices
 	GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*Duty, error)
 
+	// GetLookaheadDuties returns the duties of the passed validators indices that can be fetched ahead of the epoch,
+	// i.e. attester, aggregator and sync committee duties
+	GetLookaheadDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*Duty, error)
+
+	// GetProposerDuties returns the proposer duties of the passed validators indices
+	GetProposerDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*Duty, error)
+
+	// SubscribeToHeadEvents registers a handler for the head events of the beacon node
+	SubscribeToHeadEvents(handler func(event *api.HeadEvent))
+
 	// GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 	GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error)
 
@@ -43,6 +67,37 @@ type Beacon interface {
 
 	// SubscribeToCommitteeSubnet subscribe committee to subnet (p2p topic)
 	SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error
+
+	// GetBeaconBlock returns an unsigned beacon block for the given slot, built with the given randao reveal
+	GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*eth2spec.VersionedBeaconBlock, error)
+
+	// SubmitBeaconBlock submit the signed beacon block to the node
+	SubmitBeaconBlock(block *eth2spec.VersionedSignedBeaconBlock) error
+
+	// GetAggregateAttestation returns the aggregate attestation of the given committee at the given slot by the root of the attestation data,
+	// the attestation data of the beacon node is used if the root is nil
+	GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex, attestationDataRoot *spec.Root) (*spec.Attestation, error)
+
+	// SubmitSignedAggregateSelectionProof submit the signed aggregate and proof to the node
+	SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error
+
+	// SubscribeToSyncCommitteeSubnet subscribe sync committee members to their subnets (p2p topics)
+	SubscribeToSyncCommitteeSubnet(subscriptions []*api.SyncCommitteeSubscription) error
+
+	// GetSyncCommitteeBlockRoot returns the head block root to be signed by sync committee members at the given slot
+	GetSyncCommitteeBlockRoot(slot spec.Slot) (*spec.Root, error)
+
+	// SubmitSyncCommitteeMessage submit the sync committee message to the node
+	SubmitSyncCommitteeMessage(msg *altair.SyncCommitteeMessage) error
+
+	// GetSyncCommitteeContribution returns the sync committee contribution of the given subcommittee for the given block root
+	GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error)
+
+	// SubmitSignedContributionAndProof submit the signed sync committee contribution and proof to the node
+	SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error
+
+	// SubmitVoluntaryExit submit the signed voluntary exit to the node
+	SubmitVoluntaryExit(exit *spec.SignedVoluntaryExit) error
 }
 
 // KeyManager is an interface responsible for all key manager functions
@@ -50,6 +105,10 @@ type KeyManager interface {
 	Signer
 	// AddShare saves a share key
 	AddShare(shareKey *bls.SecretKey) error
+	// RemoveShare removes a share key, slashing protection records of the share are kept
+	RemoveShare(pubKey string) error
+	// RetrieveHighestAttestation returns the highest attestation signed by the given share (slashing protection record)
+	RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error)
 }
 
 // Signer is an interface responsible for all signing operations
@@ -58,10 +117,27 @@ type Signer interface {
 	SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error)
 	// SignAttestation signs the given attestation
 	SignAttestation(data *spec.AttestationData, duty *Duty, pk []byte) (*spec.Attestation, []byte, error)
+	// SignRandaoReveal signs the epoch of the given duty for the block randao reveal, returns the signature and the signing root
+	SignRandaoReveal(duty *Duty, pk []byte) ([]byte, []byte, error)
+	// SignBeaconBlock signs the given beacon block (slashing protected)
+	SignBeaconBlock(block *eth2spec.VersionedBeaconBlock, duty *Duty, pk []byte) (*eth2spec.VersionedSignedBeaconBlock, []byte, error)
+	// SignSelectionProof signs the slot of the given duty for the aggregator selection proof, returns the signature and the signing root
+	SignSelectionProof(duty *Duty, pk []byte) ([]byte, []byte, error)
+	// SignAggregateAndProof signs the given aggregate and proof
+	SignAggregateAndProof(msg *spec.AggregateAndProof, duty *Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error)
+	// SignSyncCommitteeMessage signs the given block root as a sync committee message
+	SignSyncCommitteeMessage(blockRoot spec.Root, duty *Duty, pk []byte) (*altair.SyncCommitteeMessage, []byte, error)
+	// SignSyncCommitteeSelectionProof signs the slot and subcommittee of the given duty for the sync committee aggregator selection proof, returns the signature and the signing root
+	SignSyncCommitteeSelectionProof(subcommitteeIndex uint64, duty *Duty, pk []byte) ([]byte, []byte, error)
+	// SignContributionAndProof signs the given sync committee contribution and proof
+	SignContributionAndProof(msg *altair.ContributionAndProof, duty *Duty, pk []byte) (*altair.SignedContributionAndProof, []byte, error)
+	// SignVoluntaryExit signs a voluntary exit of the duty's validator at the epoch of the given duty, returns the signature and the signing root
+	SignVoluntaryExit(duty *Duty, pk []byte) ([]byte, []byte, error)
 }
 
 // SigningUtil is an interface for beacon node signing specific methods
 type SigningUtil interface {
 	GetDomain(data *spec.AttestationData) ([]byte, error)
+	GetEpochDomain(domainType DomainType, epoch spec.Epoch) ([]byte, error)
 	ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error)
 }
@@ -20,4 +20,6 @@ type Duty struct {
 	CommitteesAtSlot uint64
 	// ValidatorCommitteeIndex is the index of the validator in the list of validators in the committee.
 	ValidatorCommitteeIndex uint64
+	// ValidatorSyncCommitteeIndices are the indices of the validator in the sync committee (sync committee duties only).
+	ValidatorSyncCommitteeIndices []spec.CommitteeIndex
 }
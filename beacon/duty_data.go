@@ -1,6 +1,8 @@
 package beacon
 
 import (
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 )
 
@@ -8,13 +10,17 @@ import (
 type DutyData struct {
 	// Types that are valid to be assigned to Data:
 	//	*InputValueAttestationData
-	//	*InputValue_AggregationData
-	//	*InputValue_BeaconBlock
+	//	*InputValueAggregateAndProof
+	//	*InputValueBeaconBlock
+	//	*InputValueSyncCommitteeBlockRoot
+	//	*InputValueContributionAndProof
 	Data IsInputValueData `protobuf_oneof:"data"`
 	// Types that are valid to be assigned to SignedData:
 	//	*InputValueAttestation
-	//	*InputValue_Aggregation
-	//	*InputValue_Block
+	//	*InputValueSignedAggregateAndProof
+	//	*InputValueSignedBeaconBlock
+	//	*InputValueSyncCommitteeMessage
+	//	*InputValueSignedContributionAndProof
 	SignedData IsInputValueSignedData `protobuf_oneof:"signed_data"`
 }
 
@@ -31,6 +37,38 @@ type InputValueAttestationData struct {
 // isInputValueData implementation
 func (*InputValueAttestationData) isInputValueData() {}
 
+// InputValueBeaconBlock implementing IsInputValueData
+type InputValueBeaconBlock struct {
+	BeaconBlock *spec.VersionedBeaconBlock
+}
+
+// isInputValueData implementation
+func (*InputValueBeaconBlock) isInputValueData() {}
+
+// InputValueAggregateAndProof implementing IsInputValueData
+type InputValueAggregateAndProof struct {
+	AggregateAndProof *phase0.AggregateAndProof
+}
+
+// isInputValueData implementation
+func (*InputValueAggregateAndProof) isInputValueData() {}
+
+// InputValueSyncCommitteeBlockRoot implementing IsInputValueData
+type InputValueSyncCommitteeBlockRoot struct {
+	BlockRoot phase0.Root
+}
+
+// isInputValueData implementation
+func (*InputValueSyncCommitteeBlockRoot) isInputValueData() {}
+
+// InputValueContributionAndProof implementing IsInputValueData
+type InputValueContributionAndProof struct {
+	ContributionAndProof *altair.ContributionAndProof
+}
+
+// isInputValueData implementation
+func (*InputValueContributionAndProof) isInputValueData() {}
+
 // GetData returns input data
 func (m *DutyData) GetData() IsInputValueData {
 	if m != nil {
@@ -47,6 +85,38 @@ func (m *DutyData) GetAttestationData() *phase0.AttestationData {
 	return nil
 }
 
+// GetBeaconBlock return cast beacon block input data
+func (m *DutyData) GetBeaconBlock() *spec.VersionedBeaconBlock {
+	if x, ok := m.GetData().(*InputValueBeaconBlock); ok {
+		return x.BeaconBlock
+	}
+	return nil
+}
+
+// GetAggregateAndProof return cast aggregate and proof input data
+func (m *DutyData) GetAggregateAndProof() *phase0.AggregateAndProof {
+	if x, ok := m.GetData().(*InputValueAggregateAndProof); ok {
+		return x.AggregateAndProof
+	}
+	return nil
+}
+
+// GetSyncCommitteeBlockRoot return cast sync committee block root input data
+func (m *DutyData) GetSyncCommitteeBlockRoot() *phase0.Root {
+	if x, ok := m.GetData().(*InputValueSyncCommitteeBlockRoot); ok {
+		return &x.BlockRoot
+	}
+	return nil
+}
+
+// GetContributionAndProof return cast sync committee contribution and proof input data
+func (m *DutyData) GetContributionAndProof() *altair.ContributionAndProof {
+	if x, ok := m.GetData().(*InputValueContributionAndProof); ok {
+		return x.ContributionAndProof
+	}
+	return nil
+}
+
 // IsInputValueSignedData interface representing input signed data
 type IsInputValueSignedData interface {
 	isInputValueSignedData()
@@ -60,6 +130,38 @@ type InputValueAttestation struct {
 // isInputValueSignedData implementation
 func (*InputValueAttestation) isInputValueSignedData() {}
 
+// InputValueSignedBeaconBlock implementing IsInputValueSignedData
+type InputValueSignedBeaconBlock struct {
+	SignedBeaconBlock *spec.VersionedSignedBeaconBlock
+}
+
+// isInputValueSignedData implementation
+func (*InputValueSignedBeaconBlock) isInputValueSignedData() {}
+
+// InputValueSignedAggregateAndProof implementing IsInputValueSignedData
+type InputValueSignedAggregateAndProof struct {
+	SignedAggregateAndProof *phase0.SignedAggregateAndProof
+}
+
+// isInputValueSignedData implementation
+func (*InputValueSignedAggregateAndProof) isInputValueSignedData() {}
+
+// InputValueSyncCommitteeMessage implementing IsInputValueSignedData
+type InputValueSyncCommitteeMessage struct {
+	SyncCommitteeMessage *altair.SyncCommitteeMessage
+}
+
+// isInputValueSignedData implementation
+func (*InputValueSyncCommitteeMessage) isInputValueSignedData() {}
+
+// InputValueSignedContributionAndProof implementing IsInputValueSignedData
+type InputValueSignedContributionAndProof struct {
+	SignedContributionAndProof *altair.SignedContributionAndProof
+}
+
+// isInputValueSignedData implementation
+func (*InputValueSignedContributionAndProof) isInputValueSignedData() {}
+
 // GetSignedData returns input data
 func (m *DutyData) GetSignedData() IsInputValueSignedData {
 	if m != nil {
@@ -75,3 +177,35 @@ func (m *DutyData) GetAttestation() *phase0.Attestation {
 	}
 	return nil
 }
+
+// GetSignedBeaconBlock return cast signed beacon block input data
+func (m *DutyData) GetSignedBeaconBlock() *spec.VersionedSignedBeaconBlock {
+	if x, ok := m.GetSignedData().(*InputValueSignedBeaconBlock); ok {
+		return x.SignedBeaconBlock
+	}
+	return nil
+}
+
+// GetSignedAggregateAndProof return cast signed aggregate and proof input data
+func (m *DutyData) GetSignedAggregateAndProof() *phase0.SignedAggregateAndProof {
+	if x, ok := m.GetSignedData().(*InputValueSignedAggregateAndProof); ok {
+		return x.SignedAggregateAndProof
+	}
+	return nil
+}
+
+// GetSyncCommitteeMessage return cast sync committee message input data
+func (m *DutyData) GetSyncCommitteeMessage() *altair.SyncCommitteeMessage {
+	if x, ok := m.GetSignedData().(*InputValueSyncCommitteeMessage); ok {
+		return x.SyncCommitteeMessage
+	}
+	return nil
+}
+
+// GetSignedContributionAndProof return cast signed sync committee contribution and proof input data
+func (m *DutyData) GetSignedContributionAndProof() *altair.SignedContributionAndProof {
+	if x, ok := m.GetSignedData().(*InputValueSignedContributionAndProof); ok {
+		return x.SignedContributionAndProof
+	}
+	return nil
+}
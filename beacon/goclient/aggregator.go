@@ -0,0 +1,74 @@
+package goclient
+
+import (
+	client "github.com/attestantio/go-eth2-client"
+	eth2client "github.com/attestantio/go-eth2-client"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/pkg/errors"
+)
+
+// aggregatorDuties returns a potential aggregator duty for each of the given attester duties,
+// the actual selection is determined by the selection proof once the duty is executed
+func aggregatorDuties(attesterDuties []*beacon.Duty) []*beacon.Duty {
+	var duties []*beacon.Duty
+	for _, attesterDuty := range attesterDuties {
+		duty := *attesterDuty
+		duty.Type = beacon.RoleTypeAggregator
+		duties = append(duties, &duty)
+	}
+	return duties
+}
+
+// GetAggregateAttestation implements Beacon interface, waits until two-third of the slot has transpired,
+// the attestation data and the aggregate are fetched from the same beacon node and it fails over to the next node in case of an error
+func (gc *goClient) GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex, attestationDataRoot *spec.Root) (*spec.Attestation, error) {
+	gc.waitToSlotTwoThirds(uint64(slot))
+
+	var aggregate *spec.Attestation
+	err := gc.nodes.withFailover("GetAggregateAttestation", func(c client.Service) error {
+		root := attestationDataRoot
+		if root == nil {
+			dataProvider, isProvider := c.(eth2client.AttestationDataProvider)
+			if !isProvider {
+				return errors.New("client does not support AttestationDataProvider")
+			}
+			data, err := dataProvider.AttestationData(gc.ctx, slot, committeeIndex)
+			if err != nil {
+				return errors.Wrap(err, "failed to get attestation data")
+			}
+			dataRoot, err := data.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "failed to get attestation data root")
+			}
+			dataRootValue := spec.Root(dataRoot)
+			root = &dataRootValue
+		}
+
+		provider, isProvider := c.(eth2client.AggregateAttestationProvider)
+		if !isProvider {
+			return errors.New("client does not support AggregateAttestationProvider")
+		}
+		res, err := provider.AggregateAttestation(gc.ctx, slot, *root)
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			return errors.New("received an empty aggregate attestation")
+		}
+		aggregate = res
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// SubmitSignedAggregateSelectionProof implements Beacon interface
+func (gc *goClient) SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error {
+	if provider, isProvider := gc.client().(eth2client.AggregateAttestationsSubmitter); isProvider {
+		return provider.SubmitAggregateAttestations(gc.ctx, []*spec.SignedAggregateAndProof{msg})
+	}
+	return errors.New("client does not support AggregateAttestationsSubmitter")
+}
@@ -1,41 +1,60 @@
 package goclient
 
 import (
+	client "github.com/attestantio/go-eth2-client"
 	eth2client "github.com/attestantio/go-eth2-client"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/pkg/errors"
 )
 
+// GetAttestationData implements Beacon interface, fails over to the next beacon node in case of an error
 func (gc *goClient) GetAttestationData(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.AttestationData, error) {
-	if provider, isProvider := gc.client.(eth2client.AttestationDataProvider); isProvider {
-		gc.waitOneThirdOrValidBlock(uint64(slot))
-		attestationData, err := provider.AttestationData(gc.ctx, slot, committeeIndex)
+	gc.waitOneThirdOrValidBlock(uint64(slot))
+	var attestationData *spec.AttestationData
+	err := gc.nodes.withFailover("GetAttestationData", func(c client.Service) error {
+		provider, isProvider := c.(eth2client.AttestationDataProvider)
+		if !isProvider {
+			return errors.New("client does not support AttestationDataProvider")
+		}
+		data, err := provider.AttestationData(gc.ctx, slot, committeeIndex)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return attestationData, nil
+		attestationData = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, errors.New("client does not support AttestationDataProvider")
+	return attestationData, nil
 }
 
 func (gc *goClient) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
 	return gc.keyManager.SignAttestation(data, duty, pk)
 }
 
-// SubmitAttestation implements Beacon interface
+// SubmitAttestation implements Beacon interface, the attestation is submitted to all healthy beacon nodes
+// if configured, otherwise fails over to the next beacon node in case of an error
 func (gc *goClient) SubmitAttestation(attestation *spec.Attestation) error {
-	if provider, isProvider := gc.client.(eth2client.AttestationsSubmitter); isProvider {
-		signingRoot, err := gc.getSigningRoot(attestation.Data)
-		if err != nil {
-			return errors.Wrap(err, "failed to get signing root")
-		}
+	signingRoot, err := gc.getSigningRoot(attestation.Data)
+	if err != nil {
+		return errors.Wrap(err, "failed to get signing root")
+	}
 
-		if err := gc.slashableAttestationCheck(gc.ctx, signingRoot); err != nil {
-			return errors.Wrap(err, "failed attestation slashing protection check")
-		}
+	if err := gc.slashableAttestationCheck(gc.ctx, signingRoot); err != nil {
+		return errors.Wrap(err, "failed attestation slashing protection check")
+	}
 
+	submit := func(c client.Service) error {
+		provider, isProvider := c.(eth2client.AttestationsSubmitter)
+		if !isProvider {
+			return errors.New("client does not support AttestationsSubmitter")
+		}
 		return provider.SubmitAttestations(gc.ctx, []*spec.Attestation{attestation})
 	}
-	return nil
+	if gc.submitToAll {
+		return gc.nodes.onAll("SubmitAttestation", submit)
+	}
+	return gc.nodes.withFailover("SubmitAttestation", submit)
 }
@@ -0,0 +1,310 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	client "github.com/attestantio/go-eth2-client"
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/http"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/async"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// nodesHealthCheckInterval is the interval of checking the health of the beacon nodes
+	nodesHealthCheckInterval = 12 * time.Second
+	// nodeRequestTimeout is the timeout of requests to a beacon node
+	nodeRequestTimeout = 5 * time.Second
+)
+
+var (
+	metricsBeaconNodesStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:beacon:nodes_status",
+		Help: "Status of the configured beacon nodes",
+	}, []string{"addr"})
+	metricsBeaconNodesFailovers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:beacon:nodes_failovers",
+		Help: "Count requests that failed on a beacon node and were retried on another node",
+	}, []string{"addr"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsBeaconNodesStatus); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsBeaconNodesFailovers); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
+
+// beaconNode is a connection to a single beacon node and its health status
+type beaconNode struct {
+	addr   string
+	lock   sync.RWMutex
+	client client.Service
+	status int32
+}
+
+func (bn *beaconNode) getClient() client.Service {
+	bn.lock.RLock()
+	defer bn.lock.RUnlock()
+
+	return bn.client
+}
+
+func (bn *beaconNode) getStatus() beaconNodeStatus {
+	return beaconNodeStatus(atomic.LoadInt32(&bn.status))
+}
+
+func (bn *beaconNode) setStatus(status beaconNodeStatus) {
+	atomic.StoreInt32(&bn.status, int32(status))
+	metricsBeaconNodesStatus.WithLabelValues(bn.addr).Set(float64(status))
+}
+
+// beaconNodes manages the connections to the configured beacon nodes and tracks their health,
+// nodes are preferred by the configured order
+type beaconNodes struct {
+	ctx    context.Context
+	logger *zap.Logger
+	nodes  []*beaconNode
+	// onConnect is called once a node was connected
+	onConnect func(addr string, c client.Service)
+
+	indicesLock sync.Mutex
+	indices     map[spec.ValidatorIndex]spec.BLSPubKey
+}
+
+// parseBeaconNodeAddrs parses a comma separated list of beacon nodes addresses
+func parseBeaconNodeAddrs(addrs string) []string {
+	var res []string
+	for _, addr := range strings.Split(addrs, ",") {
+		if addr = strings.TrimSpace(addr); len(addr) > 0 {
+			res = append(res, addr)
+		}
+	}
+	return res
+}
+
+// newBeaconNodes connects to the given beacon nodes, nodes that are not reachable are retried by the health checks
+func newBeaconNodes(ctx context.Context, logger *zap.Logger, addrs []string, onConnect func(addr string, c client.Service)) (*beaconNodes, error) {
+	if len(addrs) == 0 {
+		return nil, errors.New("no beacon node address was provided")
+	}
+	bns := &beaconNodes{
+		ctx:       ctx,
+		logger:    logger,
+		onConnect: onConnect,
+		indices:   make(map[spec.ValidatorIndex]spec.BLSPubKey),
+	}
+	for _, addr := range addrs {
+		bns.nodes = append(bns.nodes, &beaconNode{addr: addr})
+	}
+	bns.checkHealth()
+	for _, node := range bns.nodes {
+		if node.getClient() != nil {
+			return bns, nil
+		}
+	}
+	return nil, errors.New("could not connect to any beacon node")
+}
+
+// start checks the health of the nodes periodically
+func (bns *beaconNodes) start() {
+	async.RunEvery(bns.ctx, nodesHealthCheckInterval, bns.checkHealth)
+}
+
+// checkHealth connects nodes that are not connected yet, and updates the status of all nodes.
+// clients are created one by one as the http client sets a package level logger upon creation
+func (bns *beaconNodes) checkHealth() {
+	for _, node := range bns.nodes {
+		if node.getClient() != nil {
+			continue
+		}
+		if _, err := bns.connect(node); err != nil {
+			bns.logger.Warn("could not connect to beacon node", zap.String("addr", node.addr), zap.Error(err))
+			node.setStatus(statusUnknown)
+		}
+	}
+	var wg sync.WaitGroup
+	for _, node := range bns.nodes {
+		c := node.getClient()
+		if c == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(node *beaconNode, c client.Service) {
+			defer wg.Done()
+			node.setStatus(bns.nodeStatus(node, c))
+		}(node, c)
+	}
+	wg.Wait()
+}
+
+// nodeStatus returns the current status of the given node
+func (bns *beaconNodes) nodeStatus(node *beaconNode, c client.Service) beaconNodeStatus {
+	provider, isProvider := c.(eth2client.NodeSyncingProvider)
+	if !isProvider {
+		return statusOK
+	}
+	logger := bns.logger.With(zap.String("addr", node.addr))
+	ctx, cancel := context.WithTimeout(bns.ctx, healthCheckTimeout)
+	defer cancel()
+	syncState, err := provider.NodeSyncing(ctx)
+	if err != nil {
+		logger.Warn("could not get beacon node sync state", zap.Error(err))
+		return statusUnknown
+	}
+	if syncState != nil && syncState.IsSyncing {
+		logger.Debug("beacon node is syncing", zap.Uint64("head", uint64(syncState.HeadSlot)),
+			zap.Uint64("distance", uint64(syncState.SyncDistance)))
+		return statusSyncing
+	}
+	return statusOK
+}
+
+// connect creates the client of the given node
+func (bns *beaconNodes) connect(node *beaconNode) (client.Service, error) {
+	httpClient, err := http.New(bns.ctx,
+		// WithAddress supplies the address of the beacon node, in host:port format.
+		http.WithAddress(node.addr),
+		// LogLevel supplies the level of logging to carry out.
+		http.WithLogLevel(zerolog.DebugLevel),
+		http.WithTimeout(nodeRequestTimeout),
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create http client")
+	}
+	bns.logger.Info("successfully connected to beacon client", zap.String("name", httpClient.Name()),
+		zap.String("address", httpClient.Address()))
+
+	if bns.onConnect != nil {
+		bns.onConnect(node.addr, httpClient)
+	}
+
+	bns.indicesLock.Lock()
+	defer bns.indicesLock.Unlock()
+	if len(bns.indices) > 0 {
+		httpClient.ExtendIndexMap(bns.indices)
+	}
+
+	node.lock.Lock()
+	defer node.lock.Unlock()
+	node.client = httpClient
+	return httpClient, nil
+}
+
+// extendIndexMap extends the index map of all the connected nodes,
+// the indices are kept so nodes that are connected later will get them as well
+func (bns *beaconNodes) extendIndexMap(indices map[spec.ValidatorIndex]spec.BLSPubKey) {
+	bns.indicesLock.Lock()
+	defer bns.indicesLock.Unlock()
+
+	for index, pubKey := range indices {
+		bns.indices[index] = pubKey
+	}
+	for _, node := range bns.nodes {
+		if c := node.getClient(); c != nil {
+			c.ExtendIndexMap(indices)
+		}
+	}
+}
+
+// ordered returns the connected nodes, healthy nodes first then syncing nodes and nodes with unknown status
+func (bns *beaconNodes) ordered() []*beaconNode {
+	var healthy, others []*beaconNode
+	for _, node := range bns.nodes {
+		if node.getClient() == nil {
+			continue
+		}
+		if node.getStatus() == statusOK {
+			healthy = append(healthy, node)
+		} else {
+			others = append(others, node)
+		}
+	}
+	return append(healthy, others...)
+}
+
+// healthy returns the nodes that are connected and synced
+func (bns *beaconNodes) healthy() []*beaconNode {
+	var res []*beaconNode
+	for _, node := range bns.nodes {
+		if node.getClient() != nil && node.getStatus() == statusOK {
+			res = append(res, node)
+		}
+	}
+	return res
+}
+
+// active returns the client of the preferred node
+func (bns *beaconNodes) active() client.Service {
+	if nodes := bns.ordered(); len(nodes) > 0 {
+		return nodes[0].getClient()
+	}
+	return nil
+}
+
+// withFailover runs the given function on the preferred node, and fails over to the next nodes in case of an error.
+// nodes that failed are marked with unknown status until the next health check
+func (bns *beaconNodes) withFailover(name string, fn func(c client.Service) error) error {
+	nodes := bns.ordered()
+	if len(nodes) == 0 {
+		return errors.New("not connected to any beacon node")
+	}
+	var errs []string
+	for i, node := range nodes {
+		err := fn(node.getClient())
+		if err == nil {
+			return nil
+		}
+		node.setStatus(statusUnknown)
+		errs = append(errs, fmt.Sprintf("%s: %s", node.addr, err.Error()))
+		if i < len(nodes)-1 {
+			metricsBeaconNodesFailovers.WithLabelValues(node.addr).Inc()
+			bns.logger.Warn("beacon node request failed, failing over to next node", zap.String("request", name),
+				zap.String("addr", node.addr), zap.Error(err))
+		}
+	}
+	return errors.Errorf("%s failed on all beacon nodes: %s", name, strings.Join(errs, "; "))
+}
+
+// onAll runs the given function on all healthy nodes concurrently, succeeds if any of the nodes succeeded.
+// falls back to failover if no node is healthy
+func (bns *beaconNodes) onAll(name string, fn func(c client.Service) error) error {
+	nodes := bns.healthy()
+	if len(nodes) == 0 {
+		return bns.withFailover(name, fn)
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, len(nodes))
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node *beaconNode) {
+			defer wg.Done()
+			if errs[i] = fn(node.getClient()); errs[i] != nil {
+				node.setStatus(statusUnknown)
+				bns.logger.Warn("beacon node request failed", zap.String("request", name),
+					zap.String("addr", node.addr), zap.Error(errs[i]))
+			}
+		}(i, node)
+	}
+	wg.Wait()
+	var msgs []string
+	for i, err := range errs {
+		if err == nil {
+			return nil
+		}
+		msgs = append(msgs, fmt.Sprintf("%s: %s", nodes[i].addr, err.Error()))
+	}
+	return errors.Errorf("%s failed on all beacon nodes: %s", name, strings.Join(msgs, "; "))
+}
@@ -0,0 +1,178 @@
+package goclient
+
+import (
+	"context"
+	"fmt"
+	client "github.com/attestantio/go-eth2-client"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	zeroRoot   = "0x0000000000000000000000000000000000000000000000000000000000000000"
+	stubBlock  = "0x0101010101010101010101010101010101010101010101010101010101010101"
+	stubDomain = "0x01000000"
+)
+
+var stubResponses = map[string]string{
+	"/eth/v1/beacon/genesis":          fmt.Sprintf(`{"data":{"genesis_time":"1616508000","genesis_validators_root":"%s","genesis_fork_version":"0x00001020"}}`, zeroRoot),
+	"/eth/v1/config/spec":             fmt.Sprintf(`{"data":{"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32","DOMAIN_BEACON_ATTESTER":"%s"}}`, stubDomain),
+	"/eth/v1/config/deposit_contract": `{"data":{"chain_id":"5","address":"0xff50ed3d0ec03ac01d4c79aad74928bff48a7b2b"}}`,
+	"/eth/v1/config/fork_schedule":    `{"data":[{"previous_version":"0x00001020","current_version":"0x00001020","epoch":"0"}]}`,
+	"/eth/v1/node/version":            `{"data":{"version":"stub/v0.0.1"}}`,
+	"/eth/v1/validator/attestation_data": fmt.Sprintf(`{"data":{"slot":"1","index":"2","beacon_block_root":"%s","source":{"epoch":"0","root":"%s"},"target":{"epoch":"0","root":"%s"}}}`,
+		stubBlock, zeroRoot, stubBlock),
+}
+
+// stubBeaconNode is a beacon node http server that serves static responses
+type stubBeaconNode struct {
+	server  *httptest.Server
+	syncing int32
+	failing int32
+	calls   int32
+}
+
+func newStubBeaconNode(t *testing.T) *stubBeaconNode {
+	stub := &stubBeaconNode{}
+	stub.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/eth/v1/node/syncing":
+			_, _ = fmt.Fprintf(w, `{"data":{"head_slot":"1","sync_distance":"0","is_syncing":%v}}`,
+				atomic.LoadInt32(&stub.syncing) == 1)
+			return
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/validator/"):
+			atomic.AddInt32(&stub.calls, 1)
+			if atomic.LoadInt32(&stub.failing) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		res, ok := stubResponses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(res))
+	}))
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+func newTestGoClient(t *testing.T, ctx context.Context, addrs ...string) *goClient {
+	nodes, err := newBeaconNodes(ctx, zap.L(), addrs, nil)
+	require.NoError(t, err)
+	return &goClient{
+		ctx:     ctx,
+		logger:  zap.L(),
+		network: core.PraterNetwork,
+		nodes:   nodes,
+		heads:   newHeadTracker(zap.L()),
+		domains: cache.New(time.Minute, time.Minute),
+	}
+}
+
+func TestParseBeaconNodeAddrs(t *testing.T) {
+	require.Equal(t, []string{"http://localhost:5052"}, parseBeaconNodeAddrs("http://localhost:5052"))
+	require.Equal(t, []string{"http://a:5052", "http://b:5052"}, parseBeaconNodeAddrs(" http://a:5052, http://b:5052,"))
+	require.Len(t, parseBeaconNodeAddrs(""), 0)
+}
+
+func TestBeaconNodes_Connect(t *testing.T) {
+	// the context is not canceled, as closing clients races with the package level logger of the http client
+	ctx := context.Background()
+
+	_, err := newBeaconNodes(ctx, zap.L(), nil, nil)
+	require.Error(t, err)
+
+	unreachable := newStubBeaconNode(t)
+	unreachable.server.Close()
+	_, err = newBeaconNodes(ctx, zap.L(), []string{unreachable.server.URL}, nil)
+	require.Error(t, err)
+
+	healthy := newStubBeaconNode(t)
+	gc := newTestGoClient(t, ctx, unreachable.server.URL, healthy.server.URL)
+	require.Len(t, gc.nodes.healthy(), 1)
+	require.Equal(t, statusUnknown, gc.nodes.nodes[0].getStatus())
+	require.Equal(t, statusOK, gc.nodes.nodes[1].getStatus())
+	require.Empty(t, gc.HealthCheck())
+}
+
+func TestBeaconNodes_Failover(t *testing.T) {
+	// the context is not canceled, as closing clients races with the package level logger of the http client
+	ctx := context.Background()
+
+	primary, secondary := newStubBeaconNode(t), newStubBeaconNode(t)
+	gc := newTestGoClient(t, ctx, primary.server.URL, secondary.server.URL)
+
+	data, err := gc.GetAttestationData(1, 2)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, data.Index)
+	require.EqualValues(t, 1, atomic.LoadInt32(&primary.calls))
+	require.EqualValues(t, 0, atomic.LoadInt32(&secondary.calls))
+
+	// failed requests are retried on the next node, and the failed node is not preferred until it is healthy
+	atomic.StoreInt32(&primary.failing, 1)
+	data, err = gc.GetAttestationData(1, 2)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, data.Slot)
+	require.EqualValues(t, 2, atomic.LoadInt32(&primary.calls))
+	require.EqualValues(t, 1, atomic.LoadInt32(&secondary.calls))
+	require.Equal(t, statusUnknown, gc.nodes.nodes[0].getStatus())
+	require.Equal(t, secondary.server.URL, gc.nodes.ordered()[0].addr)
+	require.Empty(t, gc.HealthCheck())
+
+	// all nodes are failing
+	atomic.StoreInt32(&secondary.failing, 1)
+	_, err = gc.GetAttestationData(1, 2)
+	require.Error(t, err)
+
+	// the health check restores the status of nodes, syncing nodes are not preferred
+	atomic.StoreInt32(&primary.failing, 0)
+	atomic.StoreInt32(&secondary.failing, 0)
+	atomic.StoreInt32(&primary.syncing, 1)
+	gc.nodes.checkHealth()
+	require.Equal(t, statusSyncing, gc.nodes.nodes[0].getStatus())
+	require.Equal(t, statusOK, gc.nodes.nodes[1].getStatus())
+	require.Equal(t, secondary.server.URL, gc.nodes.ordered()[0].addr)
+
+	atomic.StoreInt32(&secondary.syncing, 1)
+	gc.nodes.checkHealth()
+	require.Len(t, gc.HealthCheck(), 2)
+	// syncing nodes are still used if no node is healthy
+	_, err = gc.GetAttestationData(1, 2)
+	require.NoError(t, err)
+}
+
+func TestBeaconNodes_OnAll(t *testing.T) {
+	// the context is not canceled, as closing clients races with the package level logger of the http client
+	ctx := context.Background()
+
+	nodeA, nodeB, nodeC := newStubBeaconNode(t), newStubBeaconNode(t), newStubBeaconNode(t)
+	atomic.StoreInt32(&nodeC.syncing, 1)
+	gc := newTestGoClient(t, ctx, nodeA.server.URL, nodeB.server.URL, nodeC.server.URL)
+
+	var calls int32
+	submit := func(c client.Service) error {
+		atomic.AddInt32(&calls, 1)
+		if c.Address() == nodeA.server.URL {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	}
+	// submitted to healthy nodes only, succeeds if any of the nodes succeeded
+	require.NoError(t, gc.nodes.onAll("test", submit))
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	require.Equal(t, statusUnknown, gc.nodes.nodes[0].getStatus())
+
+	require.Error(t, gc.nodes.onAll("test", func(c client.Service) error {
+		return fmt.Errorf("failed")
+	}))
+}
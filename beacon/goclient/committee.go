@@ -8,7 +8,7 @@ import (
 
 // SubscribeToCommitteeSubnet is implementation for subscribing committee to subnet (p2p topic)
 func (gc *goClient) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommitteeSubscription) error {
-	if provider, isProvider := gc.client.(eth2client.BeaconCommitteeSubscriptionsSubmitter); isProvider {
+	if provider, isProvider := gc.client().(eth2client.BeaconCommitteeSubscriptionsSubmitter); isProvider {
 		return provider.SubmitBeaconCommitteeSubscriptions(gc.ctx, subscription)
 	}
 	return errors.New("client does not support BeaconCommitteeSubscriptionsSubmitter")
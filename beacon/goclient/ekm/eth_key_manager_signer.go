@@ -2,6 +2,8 @@ package ekm
 
 import (
 	"encoding/hex"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	eth2keymanager "github.com/bloxapp/eth2-key-manager"
 	"github.com/bloxapp/eth2-key-manager/core"
@@ -16,6 +18,8 @@ import (
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/go-bitfield"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
 	"sync"
 )
 
@@ -25,11 +29,15 @@ type ethKeyManagerSigner struct {
 	signer       signer.ValidatorSigner
 	storage      *signerStorage
 	signingUtils beacon.SigningUtil
+	network      core.Network
 }
 
 // NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
 func NewETHKeyManagerSigner(db basedb.IDb, signingUtils beacon.SigningUtil, network core.Network) (beacon.KeyManager, error) {
-	signerStore := newSignerStorage(db, network)
+	signerStore, err := openSignerStorage(db, network)
+	if err != nil {
+		return nil, err
+	}
 	options := &eth2keymanager.KeyVaultOptions{}
 	options.SetStorage(signerStore)
 	options.SetWalletType(core.NDWallet)
@@ -60,6 +68,7 @@ func NewETHKeyManagerSigner(db basedb.IDb, signingUtils beacon.SigningUtil, netw
 		signer:       beaconSigner,
 		storage:      signerStore,
 		signingUtils: signingUtils,
+		network:      network,
 	}, nil
 }
 
@@ -84,9 +93,43 @@ func (km *ethKeyManagerSigner) AddShare(shareKey *bls.SecretKey) error {
 			return errors.Wrap(err, "could not save share")
 		}
 	}
+	// shares added before proposals were supported have no highest proposal
+	if km.storage.RetrieveHighestProposal(shareKey.GetPublicKey().Serialize()) == nil {
+		if err := km.storage.SaveHighestProposal(shareKey.GetPublicKey().Serialize(), zeroSlotProposal); err != nil {
+			return errors.Wrap(err, "could not save zero highest proposal")
+		}
+	}
 	return nil
 }
 
+func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
+	km.walletLock.Lock()
+	defer km.walletLock.Unlock()
+
+	acc, err := km.wallet.AccountByPublicKey(pubKey)
+	if err != nil && err.Error() != "account not found" {
+		return errors.Wrap(err, "could not check share existence")
+	}
+	if acc != nil {
+		if err := km.wallet.DeleteAccountByPublicKey(pubKey); err != nil {
+			return errors.Wrap(err, "could not delete share")
+		}
+	}
+	return nil
+}
+
+func (km *ethKeyManagerSigner) RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error) {
+	highest := km.storage.RetrieveHighestAttestation(pubKey)
+	if highest == nil {
+		return nil, nil
+	}
+	ret := &spec.AttestationData{}
+	if err := convertSSZ(highest, ret); err != nil {
+		return nil, errors.Wrap(err, "could not convert highest attestation")
+	}
+	return ret, nil
+}
+
 func (km *ethKeyManagerSigner) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	km.walletLock.RLock()
 	defer km.walletLock.RUnlock()
@@ -137,6 +180,231 @@ func (km *ethKeyManagerSigner) SignAttestation(data *spec.AttestationData, duty
 	}, root[:], nil
 }
 
+func (km *ethKeyManagerSigner) SignRandaoReveal(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	domain, err := km.signingUtils.GetEpochDomain(beacon.DomainRandao, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(uint64(epoch), domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	sig, err := km.signer.SignEpoch(epoch, domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign randao reveal")
+	}
+	return sig, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignBeaconBlock(b *eth2spec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*eth2spec.VersionedSignedBeaconBlock, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	domain, err := km.signingUtils.GetEpochDomain(beacon.DomainBeaconProposer, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+
+	var blk block.BeaconBlock
+	var root [32]byte
+	switch b.Version {
+	case eth2spec.DataVersionPhase0:
+		if b.Phase0 == nil {
+			return nil, nil, errors.New("missing phase0 block")
+		}
+		if root, err = km.signingUtils.ComputeSigningRoot(b.Phase0, domain); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to get root for signing")
+		}
+		prysmBlk := &eth.BeaconBlock{}
+		if err := convertSSZ(b.Phase0, prysmBlk); err != nil {
+			return nil, nil, errors.Wrap(err, "could not convert phase0 block")
+		}
+		blk = wrapper.WrappedPhase0BeaconBlock(prysmBlk)
+	case eth2spec.DataVersionAltair:
+		if b.Altair == nil {
+			return nil, nil, errors.New("missing altair block")
+		}
+		if root, err = km.signingUtils.ComputeSigningRoot(b.Altair, domain); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to get root for signing")
+		}
+		prysmBlk := &eth.BeaconBlockAltair{}
+		if err := convertSSZ(b.Altair, prysmBlk); err != nil {
+			return nil, nil, errors.Wrap(err, "could not convert altair block")
+		}
+		if blk, err = wrapper.WrappedAltairBeaconBlock(prysmBlk); err != nil {
+			return nil, nil, errors.Wrap(err, "could not wrap altair block")
+		}
+	default:
+		return nil, nil, errors.Errorf("unsupported block version %d", b.Version)
+	}
+
+	sig, err := km.signer.SignBeaconBlock(blk, domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign beacon block")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	signed := &eth2spec.VersionedSignedBeaconBlock{Version: b.Version}
+	switch b.Version {
+	case eth2spec.DataVersionPhase0:
+		signed.Phase0 = &spec.SignedBeaconBlock{Message: b.Phase0, Signature: blsSig}
+	case eth2spec.DataVersionAltair:
+		signed.Altair = &altair.SignedBeaconBlock{Message: b.Altair, Signature: blsSig}
+	}
+	return signed, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignSelectionProof(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	domain, err := km.signingUtils.GetEpochDomain(beacon.DomainSelectionProof, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(uint64(duty.Slot), domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	sig, err := km.signer.SignSlot(types.Slot(duty.Slot), domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign selection proof")
+	}
+	return sig, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	domain, err := km.signingUtils.GetEpochDomain(beacon.DomainAggregateAndProof, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(msg, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	prysmMsg := &eth.AggregateAttestationAndProof{}
+	if err := convertSSZ(msg, prysmMsg); err != nil {
+		return nil, nil, errors.Wrap(err, "could not convert aggregate and proof")
+	}
+	sig, err := km.signer.SignAggregateAndProof(prysmMsg, domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign aggregate and proof")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &spec.SignedAggregateAndProof{
+		Message:   msg,
+		Signature: blsSig,
+	}, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignSyncCommitteeMessage(blockRoot spec.Root, duty *beacon.Duty, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	domain, err := km.signingUtils.GetEpochDomain(beacon.DomainSyncCommittee, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	sszRoot := types.SSZBytes(blockRoot[:])
+	root, err := km.signingUtils.ComputeSigningRoot(&sszRoot, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	sig, err := km.signer.SignSyncCommittee(blockRoot[:], domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign sync committee message")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &altair.SyncCommitteeMessage{
+		Slot:            duty.Slot,
+		BeaconBlockRoot: blockRoot,
+		ValidatorIndex:  duty.ValidatorIndex,
+		Signature:       blsSig,
+	}, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignSyncCommitteeSelectionProof(subcommitteeIndex uint64, duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	domain, err := km.signingUtils.GetEpochDomain(beacon.DomainSyncCommitteeSelectionProof, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	data := &altair.SyncAggregatorSelectionData{
+		Slot:              duty.Slot,
+		SubcommitteeIndex: subcommitteeIndex,
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(data, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	prysmData := &eth.SyncAggregatorSelectionData{}
+	if err := convertSSZ(data, prysmData); err != nil {
+		return nil, nil, errors.Wrap(err, "could not convert sync aggregator selection data")
+	}
+	sig, err := km.signer.SignSyncCommitteeSelectionData(prysmData, domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign sync committee selection proof")
+	}
+	return sig, root[:], nil
+}
+
+func (km *ethKeyManagerSigner) SignContributionAndProof(msg *altair.ContributionAndProof, duty *beacon.Duty, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	domain, err := km.signingUtils.GetEpochDomain(beacon.DomainContributionAndProof, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(msg, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	prysmMsg := &eth.ContributionAndProof{}
+	if err := convertSSZ(msg, prysmMsg); err != nil {
+		return nil, nil, errors.Wrap(err, "could not convert contribution and proof")
+	}
+	sig, err := km.signer.SignSyncCommitteeContributionAndProof(prysmMsg, domain, pk)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign contribution and proof")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &altair.SignedContributionAndProof{
+		Message:   msg,
+		Signature: blsSig,
+	}, root[:], nil
+}
+
+// SignVoluntaryExit signs the voluntary exit with the share key directly, exits are not slashable
+func (km *ethKeyManagerSigner) SignVoluntaryExit(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	epoch := km.network.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	domain, err := km.signingUtils.GetEpochDomain(beacon.DomainVoluntaryExit, spec.Epoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := km.signingUtils.ComputeSigningRoot(&spec.VoluntaryExit{
+		Epoch:          spec.Epoch(epoch),
+		ValidatorIndex: duty.ValidatorIndex,
+	}, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+
+	km.walletLock.RLock()
+	defer km.walletLock.RUnlock()
+
+	account, err := km.wallet.AccountByPublicKey(hex.EncodeToString(pk))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get signing account")
+	}
+	sig, err := account.ValidationKeySign(root[:])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign voluntary exit")
+	}
+	return sig, root[:], nil
+}
+
 func (km *ethKeyManagerSigner) saveShare(shareKey *bls.SecretKey) error {
 	key, err := core.NewHDKeyFromPrivateKey(shareKey.Serialize(), "")
 	if err != nil {
@@ -164,6 +432,38 @@ var zeroSlotAttestation = &eth.AttestationData{
 	},
 }
 
+// zeroSlotProposal is a place holder beacon block representing all zero values
+var zeroSlotProposal = &eth.BeaconBlock{
+	Slot:          0,
+	ProposerIndex: 0,
+	ParentRoot:    make([]byte, 32),
+	StateRoot:     make([]byte, 32),
+	Body: &eth.BeaconBlockBody{
+		RandaoReveal: make([]byte, 96),
+		Eth1Data: &eth.Eth1Data{
+			DepositRoot: make([]byte, 32),
+			BlockHash:   make([]byte, 32),
+		},
+		Graffiti: make([]byte, 32),
+	},
+}
+
+// sszObject is an object that can be marshaled and unmarshaled as ssz
+type sszObject interface {
+	MarshalSSZ() ([]byte, error)
+	UnmarshalSSZ(buf []byte) error
+}
+
+// convertSSZ converts between equivalent types (e.g. go-eth2-client and prysm) by ssz encoding
+func convertSSZ(from, to sszObject) error {
+	// TODO - adopt github.com/attestantio/go-eth2-client in eth2-key-manager
+	byts, err := from.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal object")
+	}
+	return to.UnmarshalSSZ(byts)
+}
+
 // specAttDataToPrysmAttData a simple func converting between data types
 func specAttDataToPrysmAttData(data *spec.AttestationData) *eth.AttestationData {
 	// TODO - adopt github.com/attestantio/go-eth2-client in eth2-key-manager
@@ -0,0 +1,260 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/storage/basedb"
+	validatorstorage "github.com/bloxapp/ssv/validator/storage"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// InterchangeFormatVersion is the supported version of the EIP-3076 slashing protection interchange format
+const InterchangeFormatVersion = "5"
+
+// genesisValidatorsRoots maps the supported networks to their genesis validators root
+var genesisValidatorsRoots = map[core.Network]string{
+	core.MainNetwork:   "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+	core.PraterNetwork: "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb",
+}
+
+// Interchange is the EIP-3076 slashing protection interchange format
+type Interchange struct {
+	Metadata *InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData   `json:"data"`
+}
+
+// InterchangeMetadata holds the format version and the chain of the interchange data
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeData holds the signing history of a single validator
+type InterchangeData struct {
+	Pubkey             string                          `json:"pubkey"`
+	SignedBlocks       []*InterchangeSignedBlock       `json:"signed_blocks"`
+	SignedAttestations []*InterchangeSignedAttestation `json:"signed_attestations"`
+}
+
+// InterchangeSignedBlock is a signed block record
+type InterchangeSignedBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// InterchangeSignedAttestation is a signed attestation record
+type InterchangeSignedAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// GenesisValidatorsRoot returns the genesis validators root of the given network
+func GenesisValidatorsRoot(network core.Network) (string, error) {
+	root, found := genesisValidatorsRoots[network]
+	if !found {
+		return "", errors.Errorf("unknown genesis validators root for network %q", network)
+	}
+	return root, nil
+}
+
+// ExportSlashingProtection exports the highest attestation and proposal records of the given shares.
+// only the highest records are kept by the signer, therefore each validator has at most one record of each kind.
+func ExportSlashingProtection(db basedb.IDb, network core.Network, shares []*validatorstorage.Share) (*Interchange, error) {
+	root, err := GenesisValidatorsRoot(network)
+	if err != nil {
+		return nil, err
+	}
+	signerStore, err := openSignerStorage(db, network)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    root,
+		},
+		Data: make([]*InterchangeData, 0),
+	}
+	for _, share := range shares {
+		sharePk, err := share.OperatorPubKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not find operator pk of validator %s", share.PublicKey.SerializeToHexStr())
+		}
+
+		data := &InterchangeData{
+			Pubkey:             "0x" + share.PublicKey.SerializeToHexStr(),
+			SignedBlocks:       make([]*InterchangeSignedBlock, 0),
+			SignedAttestations: make([]*InterchangeSignedAttestation, 0),
+		}
+		// zero records are place holders saved when the share was added, nothing was signed yet
+		if highest := signerStore.RetrieveHighestProposal(sharePk.Serialize()); highest != nil && highest.Slot > 0 {
+			data.SignedBlocks = append(data.SignedBlocks, &InterchangeSignedBlock{
+				Slot: strconv.FormatUint(uint64(highest.Slot), 10),
+			})
+		}
+		if highest := signerStore.RetrieveHighestAttestation(sharePk.Serialize()); highest != nil && highest.Target.Epoch > 0 {
+			data.SignedAttestations = append(data.SignedAttestations, &InterchangeSignedAttestation{
+				SourceEpoch: strconv.FormatUint(uint64(highest.Source.Epoch), 10),
+				TargetEpoch: strconv.FormatUint(uint64(highest.Target.Epoch), 10),
+			})
+		}
+		ret.Data = append(ret.Data, data)
+	}
+	return ret, nil
+}
+
+// ImportSlashingProtection merges the given interchange into the highest records of the given shares.
+// the merge is conservative, records are only raised to the highest source, target and slot of both sides.
+// it returns the amount of validators that were found in the interchange data.
+func ImportSlashingProtection(db basedb.IDb, network core.Network, shares []*validatorstorage.Share, interchange *Interchange) (int, error) {
+	if err := validateInterchangeMetadata(network, interchange); err != nil {
+		return 0, err
+	}
+	signerStore, err := openSignerStorage(db, network)
+	if err != nil {
+		return 0, err
+	}
+
+	sharesByPk := make(map[string]*validatorstorage.Share)
+	for _, share := range shares {
+		sharesByPk[share.PublicKey.SerializeToHexStr()] = share
+	}
+
+	imported := 0
+	for _, data := range interchange.Data {
+		pk, err := decodeInterchangeHex(data.Pubkey)
+		if err != nil {
+			return imported, errors.Wrap(err, "could not decode validator public key")
+		}
+		share, found := sharesByPk[hex.EncodeToString(pk)]
+		if !found {
+			continue
+		}
+		sharePk, err := share.OperatorPubKey()
+		if err != nil {
+			return imported, errors.Wrapf(err, "could not find operator pk of validator %s", data.Pubkey)
+		}
+		if err := importAttestations(signerStore, sharePk.Serialize(), data.SignedAttestations); err != nil {
+			return imported, errors.Wrapf(err, "could not import attestations of validator %s", data.Pubkey)
+		}
+		if err := importBlocks(signerStore, sharePk.Serialize(), data.SignedBlocks); err != nil {
+			return imported, errors.Wrapf(err, "could not import blocks of validator %s", data.Pubkey)
+		}
+		imported++
+	}
+	return imported, nil
+}
+
+func validateInterchangeMetadata(network core.Network, interchange *Interchange) error {
+	if interchange == nil || interchange.Metadata == nil {
+		return errors.New("missing interchange metadata")
+	}
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return errors.Errorf("unsupported interchange format version %s", interchange.Metadata.InterchangeFormatVersion)
+	}
+	root, err := GenesisValidatorsRoot(network)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(interchange.Metadata.GenesisValidatorsRoot, root) {
+		return errors.Errorf("genesis validators root %s does not match network %s (%s)", interchange.Metadata.GenesisValidatorsRoot, network, root)
+	}
+	return nil
+}
+
+func importAttestations(signerStore *signerStorage, pk []byte, attestations []*InterchangeSignedAttestation) error {
+	if len(attestations) == 0 {
+		return nil
+	}
+	var maxSource, maxTarget uint64
+	for _, att := range attestations {
+		source, err := strconv.ParseUint(att.SourceEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "could not parse source epoch")
+		}
+		target, err := strconv.ParseUint(att.TargetEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "could not parse target epoch")
+		}
+		if source > target {
+			return errors.Errorf("source epoch %d is higher than target epoch %d", source, target)
+		}
+		if source > maxSource {
+			maxSource = source
+		}
+		if target > maxTarget {
+			maxTarget = target
+		}
+	}
+
+	highest := signerStore.RetrieveHighestAttestation(pk)
+	if highest == nil {
+		highest = newZeroSlotAttestation()
+	}
+	shouldUpdate := false
+	if uint64(highest.Source.Epoch) < maxSource {
+		highest.Source.Epoch = types.Epoch(maxSource)
+		shouldUpdate = true
+	}
+	if uint64(highest.Target.Epoch) < maxTarget {
+		highest.Target.Epoch = types.Epoch(maxTarget)
+		shouldUpdate = true
+	}
+	if !shouldUpdate {
+		return nil
+	}
+	return signerStore.SaveHighestAttestation(pk, highest)
+}
+
+func importBlocks(signerStore *signerStorage, pk []byte, blocks []*InterchangeSignedBlock) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	var maxSlot uint64
+	for _, block := range blocks {
+		slot, err := strconv.ParseUint(block.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "could not parse slot")
+		}
+		if slot > maxSlot {
+			maxSlot = slot
+		}
+	}
+
+	highest := signerStore.RetrieveHighestProposal(pk)
+	if highest == nil {
+		highest = newZeroSlotProposal()
+	}
+	if uint64(highest.Slot) >= maxSlot {
+		return nil
+	}
+	highest.Slot = types.Slot(maxSlot)
+	return signerStore.SaveHighestProposal(pk, highest)
+}
+
+func decodeInterchangeHex(value string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(value, "0x"))
+}
+
+// newZeroSlotAttestation returns a copy of zeroSlotAttestation which can be safely modified
+func newZeroSlotAttestation() *eth.AttestationData {
+	ret := &eth.AttestationData{}
+	byts, _ := zeroSlotAttestation.MarshalSSZ()
+	_ = ret.UnmarshalSSZ(byts)
+	return ret
+}
+
+// newZeroSlotProposal returns a copy of zeroSlotProposal which can be safely modified
+func newZeroSlotProposal() *eth.BeaconBlock {
+	ret := &eth.BeaconBlock{}
+	byts, _ := zeroSlotProposal.MarshalSSZ()
+	_ = ret.UnmarshalSSZ(byts)
+	return ret
+}
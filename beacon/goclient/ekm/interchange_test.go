@@ -0,0 +1,204 @@
+package ekm
+
+import (
+	"encoding/json"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/threshold"
+	validatorstorage "github.com/bloxapp/ssv/validator/storage"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func testInterchangeShare(t *testing.T, skStr string) *validatorstorage.Share {
+	threshold.Init()
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(skStr))
+	validatorSk := &bls.SecretKey{}
+	validatorSk.SetByCSPRNG()
+
+	return &validatorstorage.Share{
+		NodeID:    1,
+		PublicKey: validatorSk.GetPublicKey(),
+		Committee: map[uint64]*proto.Node{
+			1: {IbftId: 1, Pk: sk.GetPublicKey().Serialize()},
+		},
+	}
+}
+
+func testInterchangeKeyManager(t *testing.T, db basedb.IDb, skStrs ...string) beacon.KeyManager {
+	km, err := NewETHKeyManagerSigner(db, nil, core.PraterNetwork)
+	require.NoError(t, err)
+	km.(*ethKeyManagerSigner).signingUtils = &signingUtils{}
+	for _, skStr := range skStrs {
+		sk := &bls.SecretKey{}
+		require.NoError(t, sk.SetHexString(skStr))
+		require.NoError(t, km.AddShare(sk))
+	}
+	return km
+}
+
+func testInterchange(data ...*InterchangeData) *Interchange {
+	return &Interchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    genesisValidatorsRoots[core.PraterNetwork],
+		},
+		Data: data,
+	}
+}
+
+func TestExportSlashingProtection(t *testing.T) {
+	db := getStorage(t)
+	km := testInterchangeKeyManager(t, db, sk1Str, sk2Str)
+	share1 := testInterchangeShare(t, sk1Str)
+	share2 := testInterchangeShare(t, sk2Str)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+	_, _, err := km.SignAttestation(&spec.AttestationData{
+		Slot:            30,
+		BeaconBlockRoot: [32]byte{1, 2, 3, 4},
+		Source:          &spec.Checkpoint{Epoch: 1},
+		Target:          &spec.Checkpoint{Epoch: 3},
+	}, &beacon.Duty{Type: beacon.RoleTypeAttester, Slot: 30}, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+
+	t.Run("export", func(t *testing.T) {
+		interchange, err := ExportSlashingProtection(db, core.PraterNetwork, []*validatorstorage.Share{share1, share2})
+		require.NoError(t, err)
+		require.EqualValues(t, InterchangeFormatVersion, interchange.Metadata.InterchangeFormatVersion)
+		require.EqualValues(t, genesisValidatorsRoots[core.PraterNetwork], interchange.Metadata.GenesisValidatorsRoot)
+		require.Len(t, interchange.Data, 2)
+
+		require.EqualValues(t, "0x"+share1.PublicKey.SerializeToHexStr(), interchange.Data[0].Pubkey)
+		require.Len(t, interchange.Data[0].SignedAttestations, 1)
+		require.EqualValues(t, "1", interchange.Data[0].SignedAttestations[0].SourceEpoch)
+		require.EqualValues(t, "3", interchange.Data[0].SignedAttestations[0].TargetEpoch)
+		require.Len(t, interchange.Data[0].SignedBlocks, 0)
+
+		// nothing was signed by the second share
+		require.EqualValues(t, "0x"+share2.PublicKey.SerializeToHexStr(), interchange.Data[1].Pubkey)
+		require.Len(t, interchange.Data[1].SignedAttestations, 0)
+		require.Len(t, interchange.Data[1].SignedBlocks, 0)
+	})
+
+	t.Run("json format", func(t *testing.T) {
+		interchange, err := ExportSlashingProtection(db, core.PraterNetwork, []*validatorstorage.Share{share1})
+		require.NoError(t, err)
+		byts, err := json.Marshal(interchange)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"metadata": {
+				"interchange_format_version": "5",
+				"genesis_validators_root": "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"
+			},
+			"data": [{
+				"pubkey": "0x`+share1.PublicKey.SerializeToHexStr()+`",
+				"signed_blocks": [],
+				"signed_attestations": [{"source_epoch": "1", "target_epoch": "3"}]
+			}]
+		}`, string(byts))
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		_, err := ExportSlashingProtection(db, core.PyrmontNetwork, []*validatorstorage.Share{share1})
+		require.EqualError(t, err, `unknown genesis validators root for network "pyrmont"`)
+	})
+}
+
+func TestImportSlashingProtection(t *testing.T) {
+	db := getStorage(t)
+	km := testInterchangeKeyManager(t, db, sk1Str)
+	share := testInterchangeShare(t, sk1Str)
+	sharePk, err := share.OperatorPubKey()
+	require.NoError(t, err)
+	signerStore := newSignerStorage(db, core.PraterNetwork)
+
+	t.Run("invalid version", func(t *testing.T) {
+		interchange := testInterchange()
+		interchange.Metadata.InterchangeFormatVersion = "4"
+		_, err := ImportSlashingProtection(db, core.PraterNetwork, []*validatorstorage.Share{share}, interchange)
+		require.EqualError(t, err, "unsupported interchange format version 4")
+	})
+
+	t.Run("genesis validators root mismatch", func(t *testing.T) {
+		interchange := testInterchange()
+		interchange.Metadata.GenesisValidatorsRoot = genesisValidatorsRoots[core.MainNetwork]
+		_, err := ImportSlashingProtection(db, core.PraterNetwork, []*validatorstorage.Share{share}, interchange)
+		require.EqualError(t, err, "genesis validators root 0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95 "+
+			"does not match network prater (0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb)")
+	})
+
+	t.Run("invalid attestation", func(t *testing.T) {
+		_, err := ImportSlashingProtection(db, core.PraterNetwork, []*validatorstorage.Share{share}, testInterchange(&InterchangeData{
+			Pubkey:             "0x" + share.PublicKey.SerializeToHexStr(),
+			SignedAttestations: []*InterchangeSignedAttestation{{SourceEpoch: "5", TargetEpoch: "4"}},
+		}))
+		require.EqualError(t, err, "could not import attestations of validator 0x"+share.PublicKey.SerializeToHexStr()+
+			": source epoch 5 is higher than target epoch 4")
+	})
+
+	t.Run("import", func(t *testing.T) {
+		imported, err := ImportSlashingProtection(db, core.PraterNetwork, []*validatorstorage.Share{share}, testInterchange(
+			&InterchangeData{
+				Pubkey: "0x" + share.PublicKey.SerializeToHexStr(),
+				SignedAttestations: []*InterchangeSignedAttestation{
+					{SourceEpoch: "2", TargetEpoch: "5"},
+					{SourceEpoch: "4", TargetEpoch: "4"},
+				},
+				SignedBlocks: []*InterchangeSignedBlock{{Slot: "40"}, {Slot: "12"}},
+			},
+			// unknown validators are ignored
+			&InterchangeData{
+				Pubkey:             "0x" + testInterchangeShare(t, sk2Str).PublicKey.SerializeToHexStr(),
+				SignedAttestations: []*InterchangeSignedAttestation{{SourceEpoch: "10", TargetEpoch: "11"}},
+			},
+		))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, imported)
+
+		highestAtt := signerStore.RetrieveHighestAttestation(sharePk.Serialize())
+		require.NotNil(t, highestAtt)
+		require.EqualValues(t, 4, highestAtt.Source.Epoch)
+		require.EqualValues(t, 5, highestAtt.Target.Epoch)
+		highestProposal := signerStore.RetrieveHighestProposal(sharePk.Serialize())
+		require.NotNil(t, highestProposal)
+		require.EqualValues(t, 40, highestProposal.Slot)
+	})
+
+	t.Run("conservative merge", func(t *testing.T) {
+		imported, err := ImportSlashingProtection(db, core.PraterNetwork, []*validatorstorage.Share{share}, testInterchange(
+			&InterchangeData{
+				Pubkey:             "0x" + share.PublicKey.SerializeToHexStr(),
+				SignedAttestations: []*InterchangeSignedAttestation{{SourceEpoch: "3", TargetEpoch: "7"}},
+				SignedBlocks:       []*InterchangeSignedBlock{{Slot: "20"}},
+			},
+		))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, imported)
+
+		// lower values are never imported over higher ones
+		highestAtt := signerStore.RetrieveHighestAttestation(sharePk.Serialize())
+		require.EqualValues(t, 4, highestAtt.Source.Epoch)
+		require.EqualValues(t, 7, highestAtt.Target.Epoch)
+		highestProposal := signerStore.RetrieveHighestProposal(sharePk.Serialize())
+		require.EqualValues(t, 40, highestProposal.Slot)
+	})
+
+	t.Run("signer refuses imported history", func(t *testing.T) {
+		_, _, err := km.SignAttestation(&spec.AttestationData{
+			Slot:            200,
+			BeaconBlockRoot: [32]byte{1, 2, 3, 4},
+			Source:          &spec.Checkpoint{Epoch: 4},
+			Target:          &spec.Checkpoint{Epoch: 6},
+		}, &beacon.Duty{Type: beacon.RoleTypeAttester, Slot: 200}, sharePk.Serialize())
+		require.EqualError(t, err, "failed to sign attestation: slashable attestation (HighestAttestationVote), not signing")
+	})
+}
@@ -1,6 +1,8 @@
 package ekm
 
 import (
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
@@ -9,6 +11,7 @@ import (
 	fssz "github.com/ferranbt/fastssz"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -28,6 +31,10 @@ func (s *signingUtils) GetDomain(data *spec.AttestationData) ([]byte, error) {
 	return make([]byte, 32), nil
 }
 
+func (s *signingUtils) GetEpochDomain(domainType beacon.DomainType, epoch spec.Epoch) ([]byte, error) {
+	return make([]byte, 32), nil
+}
+
 func (s *signingUtils) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
 	if object == nil {
 		return [32]byte{}, errors.New("cannot compute signing root of nil")
@@ -119,6 +126,306 @@ func TestSignAttestation(t *testing.T) {
 	})
 }
 
+func TestRetrieveHighestAttestation(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+	pk := sk1.GetPublicKey().Serialize()
+
+	t.Run("initial record", func(t *testing.T) {
+		highest, err := km.RetrieveHighestAttestation(pk)
+		require.NoError(t, err)
+		require.NotNil(t, highest)
+		require.EqualValues(t, 0, highest.Source.Epoch)
+		require.EqualValues(t, 0, highest.Target.Epoch)
+	})
+
+	t.Run("updated after signing", func(t *testing.T) {
+		attestationData := &spec.AttestationData{
+			Slot:            30,
+			Index:           1,
+			BeaconBlockRoot: [32]byte{1, 2, 3, 4},
+			Source:          &spec.Checkpoint{Epoch: 1},
+			Target:          &spec.Checkpoint{Epoch: 3},
+		}
+		_, _, err := km.SignAttestation(attestationData, &beacon.Duty{Type: beacon.RoleTypeAttester, Slot: 30}, pk)
+		require.NoError(t, err)
+
+		highest, err := km.RetrieveHighestAttestation(pk)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, highest.Source.Epoch)
+		require.EqualValues(t, 3, highest.Target.Epoch)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		highest, err := km.RetrieveHighestAttestation(make([]byte, 48))
+		require.NoError(t, err)
+		require.Nil(t, highest)
+	})
+}
+
+func TestRemoveShare(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+	pk := sk1.GetPublicKey()
+
+	require.NoError(t, km.RemoveShare(pk.SerializeToHexStr()))
+
+	t.Run("can't sign with removed share", func(t *testing.T) {
+		_, err := km.SignIBFTMessage(&proto.Message{
+			Type:      proto.RoundState_Commit,
+			Lambda:    []byte("lambda1"),
+			SeqNumber: 3,
+			Value:     []byte("value1"),
+		}, pk.Serialize())
+		require.EqualError(t, err, "could not get signing account: account not found")
+	})
+
+	t.Run("slashing protection is kept", func(t *testing.T) {
+		highest, err := km.RetrieveHighestAttestation(pk.Serialize())
+		require.NoError(t, err)
+		require.NotNil(t, highest)
+	})
+
+	t.Run("remove unknown share", func(t *testing.T) {
+		require.NoError(t, km.RemoveShare(pk.SerializeToHexStr()))
+	})
+
+	t.Run("add share again", func(t *testing.T) {
+		require.NoError(t, km.AddShare(sk1))
+		_, err := km.SignIBFTMessage(&proto.Message{
+			Type:      proto.RoundState_Commit,
+			Lambda:    []byte("lambda1"),
+			SeqNumber: 3,
+			Value:     []byte("value1"),
+		}, pk.Serialize())
+		require.NoError(t, err)
+	})
+}
+
+func testBeaconBlockBody() *spec.BeaconBlockBody {
+	return &spec.BeaconBlockBody{
+		ETH1Data: &spec.ETH1Data{
+			BlockHash: make([]byte, 32),
+		},
+		Graffiti:          make([]byte, 32),
+		ProposerSlashings: []*spec.ProposerSlashing{},
+		AttesterSlashings: []*spec.AttesterSlashing{},
+		Attestations:      []*spec.Attestation{},
+		Deposits:          []*spec.Deposit{},
+		VoluntaryExits:    []*spec.SignedVoluntaryExit{},
+	}
+}
+
+func TestSignBeaconBlock(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	phase0Block := &eth2spec.VersionedBeaconBlock{
+		Version: eth2spec.DataVersionPhase0,
+		Phase0: &spec.BeaconBlock{
+			Slot:          30,
+			ProposerIndex: 1,
+			ParentRoot:    [32]byte{1, 2, 3},
+			StateRoot:     [32]byte{4, 5, 6},
+			Body:          testBeaconBlockBody(),
+		},
+	}
+	phase0Body := testBeaconBlockBody()
+	altairBlock := &eth2spec.VersionedBeaconBlock{
+		Version: eth2spec.DataVersionAltair,
+		Altair: &altair.BeaconBlock{
+			Slot:          31,
+			ProposerIndex: 1,
+			ParentRoot:    [32]byte{1, 2, 3},
+			StateRoot:     [32]byte{4, 5, 6},
+			Body: &altair.BeaconBlockBody{
+				ETH1Data:          phase0Body.ETH1Data,
+				Graffiti:          phase0Body.Graffiti,
+				ProposerSlashings: phase0Body.ProposerSlashings,
+				AttesterSlashings: phase0Body.AttesterSlashings,
+				Attestations:      phase0Body.Attestations,
+				Deposits:          phase0Body.Deposits,
+				VoluntaryExits:    phase0Body.VoluntaryExits,
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: make([]byte, 64),
+				},
+			},
+		},
+	}
+
+	t.Run("sign phase0 block", func(t *testing.T) {
+		signed, root, err := km.SignBeaconBlock(phase0Block, &beacon.Duty{Slot: 30}, sk1.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.NotNil(t, root)
+		require.EqualValues(t, eth2spec.DataVersionPhase0, signed.Version)
+
+		sig := &bls.Sign{}
+		require.NoError(t, sig.Deserialize(append([]byte{}, signed.Phase0.Signature[:]...)))
+		require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+	})
+	t.Run("slashable sign, fail", func(t *testing.T) {
+		_, _, err := km.SignBeaconBlock(phase0Block, &beacon.Duty{Slot: 30}, sk1.GetPublicKey().Serialize())
+		require.EqualError(t, err, "failed to sign beacon block: slashable proposal (HighestProposalVote), not signing")
+	})
+	t.Run("sign altair block", func(t *testing.T) {
+		signed, root, err := km.SignBeaconBlock(altairBlock, &beacon.Duty{Slot: 31}, sk1.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.EqualValues(t, eth2spec.DataVersionAltair, signed.Version)
+
+		sig := &bls.Sign{}
+		require.NoError(t, sig.Deserialize(append([]byte{}, signed.Altair.Signature[:]...)))
+		require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+	})
+}
+
+func TestSignRandaoReveal(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	sigByts, root, err := km.SignRandaoReveal(&beacon.Duty{Slot: 64}, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(sigByts))
+	require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+}
+
+func TestSignSelectionProof(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	sigByts, root, err := km.SignSelectionProof(&beacon.Duty{Slot: 64}, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(sigByts))
+	require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+}
+
+func TestSignAggregateAndProof(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	msg := &spec.AggregateAndProof{
+		AggregatorIndex: 1,
+		Aggregate: &spec.Attestation{
+			AggregationBits: []byte{0x03},
+			Data: &spec.AttestationData{
+				Slot:   30,
+				Index:  1,
+				Source: &spec.Checkpoint{Epoch: 1},
+				Target: &spec.Checkpoint{Epoch: 3},
+			},
+		},
+		SelectionProof: spec.BLSSignature{1, 2, 3},
+	}
+
+	signed, root, err := km.SignAggregateAndProof(msg, &beacon.Duty{Slot: 30}, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+	require.Equal(t, msg, signed.Message)
+
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(append([]byte{}, signed.Signature[:]...)))
+	require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+}
+
+func TestSignSyncCommitteeMessage(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	blockRoot := spec.Root{1, 2, 3}
+	msg, root, err := km.SignSyncCommitteeMessage(blockRoot, &beacon.Duty{Slot: 64, ValidatorIndex: 5}, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+	require.EqualValues(t, 64, msg.Slot)
+	require.EqualValues(t, 5, msg.ValidatorIndex)
+	require.Equal(t, blockRoot, msg.BeaconBlockRoot)
+
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(append([]byte{}, msg.Signature[:]...)))
+	require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+}
+
+func TestSignSyncCommitteeSelectionProof(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	sigByts, root, err := km.SignSyncCommitteeSelectionProof(2, &beacon.Duty{Slot: 64}, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(sigByts))
+	require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+
+	// a different subcommittee results in a different selection proof
+	otherSigByts, _, err := km.SignSyncCommitteeSelectionProof(3, &beacon.Duty{Slot: 64}, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+	require.NotEqual(t, sigByts, otherSigByts)
+}
+
+func TestSignContributionAndProof(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	msg := &altair.ContributionAndProof{
+		AggregatorIndex: 1,
+		Contribution: &altair.SyncCommitteeContribution{
+			Slot:              30,
+			BeaconBlockRoot:   spec.Root{1, 2, 3},
+			SubcommitteeIndex: 1,
+			AggregationBits:   bitfield.NewBitvector128(),
+		},
+		SelectionProof: spec.BLSSignature{1, 2, 3},
+	}
+
+	signed, root, err := km.SignContributionAndProof(msg, &beacon.Duty{Slot: 30}, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+	require.Equal(t, msg, signed.Message)
+
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(append([]byte{}, signed.Signature[:]...)))
+	require.True(t, sig.VerifyByte(sk1.GetPublicKey(), root))
+}
+
+func TestSignVoluntaryExit(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+
+	duty := &beacon.Duty{Type: beacon.RoleTypeVoluntaryExit, Slot: 64, ValidatorIndex: 12}
+	sig, root, err := km.SignVoluntaryExit(duty, sk1.GetPublicKey().Serialize())
+	require.NoError(t, err)
+
+	expectedRoot, err := (&signingUtils{}).ComputeSigningRoot(&spec.VoluntaryExit{Epoch: 2, ValidatorIndex: 12}, make([]byte, 32))
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot[:], root)
+
+	blsSig := &bls.Sign{}
+	require.NoError(t, blsSig.Deserialize(sig))
+	require.True(t, blsSig.VerifyByte(sk1.GetPublicKey(), root))
+
+	_, _, err = km.SignVoluntaryExit(duty, _byteArray("a8cb269bd7741740cfe90de2f8db6ea35a9da443385155da0fa2f621ba80e5ac14b5c8f65d23fd9ccc170cc85f29e27e"))
+	require.Error(t, err)
+}
+
 func TestSignIBFTMessage(t *testing.T) {
 	km := testKeyManager(t)
 
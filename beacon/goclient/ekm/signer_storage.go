@@ -22,6 +22,9 @@ const (
 	accountsPath          = "accounts_%s"
 	highestAttPrefix      = prefix + "highest_att-"
 	highestProposalPrefix = prefix + "highest_prop-"
+
+	// legacyNetwork is the network that prefixed the signer storage regardless of the configured network
+	legacyNetwork = core.PraterNetwork
 )
 
 type signerStorage struct {
@@ -38,6 +41,45 @@ func newSignerStorage(db basedb.IDb, network core.Network) *signerStorage {
 	}
 }
 
+// openSignerStorage migrates the records of the legacy network prefix to the given network, and returns the storage of the network
+func openSignerStorage(db basedb.IDb, network core.Network) (*signerStorage, error) {
+	if err := migrateLegacyPrefix(db, network); err != nil {
+		return nil, errors.Wrap(err, "could not migrate signer storage")
+	}
+	return newSignerStorage(db, network), nil
+}
+
+// migrateLegacyPrefix moves the records that were saved with the legacy network prefix to the prefix of the given network.
+// it fails if the given network has records as well, as it is not clear which of them should be kept
+func migrateLegacyPrefix(db basedb.IDb, network core.Network) error {
+	if network == legacyNetwork {
+		return nil
+	}
+	legacyPrefix := []byte(string(legacyNetwork) + prefix)
+	objs, err := db.GetAllByCollection(legacyPrefix)
+	if err != nil {
+		return errors.Wrap(err, "could not get legacy records")
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+	networkPrefix := []byte(string(network) + prefix)
+	count, err := db.CountByCollection(networkPrefix)
+	if err != nil {
+		return errors.Wrap(err, "could not count records")
+	}
+	if count > 0 {
+		return errors.Errorf("found signer records of both %q and %q networks, "+
+			"the %q records must be removed or exported before starting", legacyNetwork, network, legacyNetwork)
+	}
+	for _, obj := range objs {
+		if err := db.Set(networkPrefix, obj.Key, obj.Value); err != nil {
+			return errors.Wrap(err, "could not save migrated record")
+		}
+	}
+	return db.RemoveAllByCollection(legacyPrefix)
+}
+
 func (s *signerStorage) objPrefix(obj string) []byte {
 	return []byte(string(s.network) + obj)
 }
@@ -394,3 +394,45 @@ func TestSavingHighestAttestation(t *testing.T) {
 		})
 	}
 }
+
+func TestMigrateLegacyPrefix(t *testing.T) {
+	att := &eth.AttestationData{
+		Slot:            30,
+		CommitteeIndex:  1,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &eth.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		Target:          &eth.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+	}
+	pk := _byteArray("a8cb269bd7741740cfe90de2f8db6ea35a9da443385155da0fa2f621ba80e5ac14b5c8f65d23fd9ccc170cc85f29e27d")
+
+	t.Run("legacy records are migrated", func(t *testing.T) {
+		db := getStorage(t)
+		require.NoError(t, newSignerStorage(db, core.PraterNetwork).SaveHighestAttestation(pk, att))
+
+		storage, err := openSignerStorage(db, core.MainNetwork)
+		require.NoError(t, err)
+		highest := storage.RetrieveHighestAttestation(pk)
+		require.NotNil(t, highest)
+		require.EqualValues(t, 2, highest.Target.Epoch)
+		require.Nil(t, newSignerStorage(db, core.PraterNetwork).RetrieveHighestAttestation(pk))
+	})
+
+	t.Run("records of the legacy network are kept", func(t *testing.T) {
+		db := getStorage(t)
+		require.NoError(t, newSignerStorage(db, core.PraterNetwork).SaveHighestAttestation(pk, att))
+
+		storage, err := openSignerStorage(db, core.PraterNetwork)
+		require.NoError(t, err)
+		require.NotNil(t, storage.RetrieveHighestAttestation(pk))
+	})
+
+	t.Run("records of both networks", func(t *testing.T) {
+		db := getStorage(t)
+		require.NoError(t, newSignerStorage(db, core.PraterNetwork).SaveHighestAttestation(pk, att))
+		require.NoError(t, newSignerStorage(db, core.MainNetwork).SaveHighestAttestation(pk, att))
+
+		_, err := openSignerStorage(db, core.MainNetwork)
+		require.Error(t, err)
+		require.NotNil(t, newSignerStorage(db, core.PraterNetwork).RetrieveHighestAttestation(pk))
+	})
+}
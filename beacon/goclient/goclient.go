@@ -6,18 +6,18 @@ import (
 	client "github.com/attestantio/go-eth2-client"
 	eth2client "github.com/attestantio/go-eth2-client"
 	api "github.com/attestantio/go-eth2-client/api/v1"
-	"github.com/attestantio/go-eth2-client/http"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	"github.com/bloxapp/ssv/beacon/goclient/remotesigner"
 	"github.com/bloxapp/ssv/monitoring/metrics"
+	"github.com/patrickmn/go-cache"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
-	"github.com/rs/zerolog"
 	"go.uber.org/zap"
 	"log"
 	"sync"
@@ -51,10 +51,17 @@ type goClient struct {
 	ctx            context.Context
 	logger         *zap.Logger
 	network        core.Network
-	client         client.Service
+	nodes          *beaconNodes
+	heads          *headTracker
+	submitToAll    bool
 	indicesMapLock sync.Mutex
 	graffiti       []byte
 	keyManager     beacon.KeyManager
+	// domains caches the signing domains by type and epoch
+	domains *cache.Cache
+
+	syncCommitteeDutiesLock sync.Mutex
+	syncCommitteeDuties     *syncCommitteePeriodDuties
 }
 
 // verifies that the client implements HealthCheckAgent
@@ -63,71 +70,154 @@ var _ metrics.HealthCheckAgent = &goClient{}
 // New init new client and go-client instance
 func New(opt beacon.Options) (beacon.Beacon, error) {
 	logger := opt.Logger.With(zap.String("component", "goClient"), zap.String("network", opt.Network))
+	network := core.NetworkFromString(opt.Network)
+	if network == "" {
+		return nil, errors.Errorf("unknown eth2 network %q", opt.Network)
+	}
 	logger.Info("connecting to beacon client...")
 
-	httpClient, err := http.New(opt.Context,
-		// WithAddress supplies the address of the beacon node, in host:port format.
-		http.WithAddress(opt.BeaconNodeAddr),
-		// LogLevel supplies the level of logging to carry out.
-		http.WithLogLevel(zerolog.DebugLevel),
-		http.WithTimeout(time.Second*5),
-	)
+	heads := newHeadTracker(logger)
+	addrs := parseBeaconNodeAddrs(opt.BeaconNodeAddr)
+	nodes, err := newBeaconNodes(opt.Context, logger, addrs, func(addr string, c client.Service) {
+		if err := heads.subscribe(opt.Context, c); err != nil {
+			logger.Warn("could not subscribe to head events", zap.String("addr", addr), zap.Error(err))
+		}
+	})
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to create http client")
+		return nil, errors.WithMessage(err, "failed to connect to beacon nodes")
 	}
-
-	logger = logger.With(zap.String("name", httpClient.Name()), zap.String("address", httpClient.Address()))
-	logger.Info("successfully connected to beacon client")
+	nodes.start()
+	logger.Info("successfully connected to beacon nodes", zap.Strings("addrs", addrs))
 
 	_client := &goClient{
 		ctx:            opt.Context,
 		logger:         logger,
-		network:        core.NetworkFromString(opt.Network),
-		client:         httpClient,
+		network:        network,
+		nodes:          nodes,
+		heads:          heads,
+		submitToAll:    opt.SubmitToAllBeaconNodes,
 		indicesMapLock: sync.Mutex{},
 		graffiti:       opt.Graffiti,
+		domains:        cache.New(time.Minute*15, time.Minute*16),
 	}
 
-	_client.keyManager, err = ekm.NewETHKeyManagerSigner(opt.DB, _client, core.PraterNetwork) // TODO need to set dynemic network
-	if err != nil {
-		return nil, errors.Wrap(err, "could not create new eth-key-manager signer")
+	switch opt.KeyManager {
+	case beacon.LocalKeyManager, "":
+		// the network must match the one used by the slashing protection commands, as it prefixes the signer storage
+		_client.keyManager, err = ekm.NewETHKeyManagerSigner(opt.DB, _client, _client.network)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create new eth-key-manager signer")
+		}
+	case beacon.RemoteKeyManager:
+		logger.Info("using remote signer, iBFT messages are signed locally with the share keys", zap.String("addr", opt.RemoteSignerAddr))
+		_client.keyManager, err = remotesigner.NewRemoteSigner(opt.Context, opt.RemoteSignerAddr, opt.DB, _client.network, _client, _client)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create new remote signer")
+		}
+	default:
+		return nil, errors.Errorf("unknown key manager %q", opt.KeyManager)
 	}
 
 	return _client, nil
 }
 
-// HealthCheck provides health status of beacon node
+// HealthCheck provides health status of the beacon nodes, the client is considered healthy if any node is healthy
 func (gc *goClient) HealthCheck() []string {
-	if gc.client == nil {
-		return []string{"not connected to beacon node"}
-	}
-	if provider, isProvider := gc.client.(eth2client.NodeSyncingProvider); isProvider {
-		ctx, cancel := context.WithTimeout(gc.ctx, healthCheckTimeout)
-		defer cancel()
-		syncState, err := provider.NodeSyncing(ctx)
-		if err != nil {
-			metricsBeaconNodeStatus.Set(float64(statusUnknown))
-			return []string{"could not get beacon node sync state"}
-		}
-		if syncState != nil && syncState.IsSyncing {
-			metricsBeaconNodeStatus.Set(float64(statusSyncing))
-			return []string{fmt.Sprintf("beacon node is currently syncing: head=%d, distance=%d",
-				syncState.HeadSlot, syncState.SyncDistance)}
+	var errs []string
+	for _, node := range gc.nodes.nodes {
+		switch node.getStatus() {
+		case statusOK:
+			metricsBeaconNodeStatus.Set(float64(statusOK))
+			return []string{}
+		case statusSyncing:
+			errs = append(errs, fmt.Sprintf("beacon node %s is currently syncing", node.addr))
+		default:
+			errs = append(errs, fmt.Sprintf("beacon node %s is not available", node.addr))
 		}
 	}
-	metricsBeaconNodeStatus.Set(float64(statusOK))
-	return []string{}
+	if len(errs) == 0 {
+		errs = append(errs, "not connected to beacon node")
+	}
+	metricsBeaconNodeStatus.Set(float64(statusUnknown))
+	return errs
+}
+
+// client returns the client of the preferred beacon node
+func (gc *goClient) client() client.Service {
+	return gc.nodes.active()
 }
 
 func (gc *goClient) ExtendIndexMap(index spec.ValidatorIndex, pubKey spec.BLSPubKey) {
 	gc.indicesMapLock.Lock()
 	defer gc.indicesMapLock.Unlock()
 
-	gc.client.ExtendIndexMap(map[spec.ValidatorIndex]spec.BLSPubKey{index: pubKey})
+	gc.nodes.extendIndexMap(map[spec.ValidatorIndex]spec.BLSPubKey{index: pubKey})
 }
 
+// GetDuties returns the duties of the given validators, fails over to the next beacon node in case of an error
 func (gc *goClient) GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
-	if provider, isProvider := gc.client.(eth2client.AttesterDutiesProvider); isProvider {
+	var duties []*beacon.Duty
+	err := gc.nodes.withFailover("GetDuties", func(c client.Service) error {
+		var err error
+		duties, err = gc.getDuties(c, epoch, validatorIndices)
+		return err
+	})
+	return duties, err
+}
+
+// GetLookaheadDuties returns the attester, aggregator and sync committee duties of the given validators,
+// fails over to the next beacon node in case of an error
+func (gc *goClient) GetLookaheadDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	var duties []*beacon.Duty
+	err := gc.nodes.withFailover("GetLookaheadDuties", func(c client.Service) error {
+		var err error
+		duties, err = gc.getLookaheadDuties(c, epoch, validatorIndices)
+		return err
+	})
+	return duties, err
+}
+
+// GetProposerDuties returns the proposer duties of the given validators, fails over to the next beacon node in case of an error
+func (gc *goClient) GetProposerDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	var duties []*beacon.Duty
+	err := gc.nodes.withFailover("GetProposerDuties", func(c client.Service) error {
+		var err error
+		duties, err = gc.getProposerDuties(c, epoch, validatorIndices)
+		return err
+	})
+	return duties, err
+}
+
+// getDuties returns the duties of the given validators from the given beacon node
+func (gc *goClient) getDuties(c client.Service, epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	duties, err := gc.getLookaheadDuties(c, epoch, validatorIndices)
+	if err != nil {
+		return nil, err
+	}
+	proposerDuties, err := gc.getProposerDuties(c, epoch, validatorIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get proposer duties")
+	}
+	return append(duties, proposerDuties...), nil
+}
+
+// getLookaheadDuties returns the attester, aggregator and sync committee duties of the given validators from the given beacon node
+func (gc *goClient) getLookaheadDuties(c client.Service, epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	attesterDuties, err := gc.getAttesterDuties(c, epoch, validatorIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attester duties")
+	}
+	syncCommitteeDuties, err := gc.getSyncCommitteeDuties(c, epoch, validatorIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get sync committee duties")
+	}
+	duties := append(attesterDuties, aggregatorDuties(attesterDuties)...)
+	return append(duties, syncCommitteeDuties...), nil
+}
+
+// getAttesterDuties returns the attester duties of the given validators
+func (gc *goClient) getAttesterDuties(c client.Service, epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	if provider, isProvider := c.(eth2client.AttesterDutiesProvider); isProvider {
 		attesterDuties, err := provider.AttesterDuties(gc.ctx, epoch, validatorIndices)
 		if err != nil {
 			return nil, err
@@ -152,7 +242,7 @@ func (gc *goClient) GetDuties(epoch spec.Epoch, validatorIndices []spec.Validato
 
 // GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 func (gc *goClient) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
-	if provider, isProvider := gc.client.(eth2client.ValidatorsProvider); isProvider {
+	if provider, isProvider := gc.client().(eth2client.ValidatorsProvider); isProvider {
 		validatorsMap, err := provider.ValidatorsByPubKey(gc.ctx, "head", validatorPubKeys) // TODO maybe need to get the chainId (head) as var
 		if err != nil {
 			return nil, err
@@ -162,11 +252,25 @@ func (gc *goClient) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spe
 	return nil, errors.New("client does not support ValidatorsProvider")
 }
 
-// waitOneThirdOrValidBlock waits until one-third of the slot has transpired (SECONDS_PER_SLOT / 3 seconds after the start of slot)
+// waitOneThirdOrValidBlock waits until the block of the slot was seen in the head events of the beacon nodes,
+// or until one-third of the slot has transpired (SECONDS_PER_SLOT / 3 seconds after the start of slot)
 func (gc *goClient) waitOneThirdOrValidBlock(slot uint64) {
 	delay := slots.DivideSlotBy(3 /* a third of the slot duration */)
-	startTime := gc.slotStartTime(slot)
-	finalTime := startTime.Add(delay)
+	if gc.heads.waitForBlock(gc.ctx, spec.Slot(slot), gc.slotStartTime(slot).Add(delay)) {
+		metricsWaitForBlock.WithLabelValues(waitResultBlock).Inc()
+		return
+	}
+	metricsWaitForBlock.WithLabelValues(waitResultDeadline).Inc()
+}
+
+// waitToSlotTwoThirds waits until two-third of the slot has transpired (SECONDS_PER_SLOT * 2 / 3 seconds after the start of slot)
+func (gc *goClient) waitToSlotTwoThirds(slot uint64) {
+	oneThird := slots.DivideSlotBy(3 /* one third of slot duration */)
+	gc.waitUntil(gc.slotStartTime(slot).Add(2 * oneThird))
+}
+
+// waitUntil blocks until the given time
+func (gc *goClient) waitUntil(finalTime time.Time) {
 	wait := prysmTime.Until(finalTime)
 	if wait <= 0 {
 		return
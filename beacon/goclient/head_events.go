@@ -0,0 +1,159 @@
+package goclient
+
+import (
+	"context"
+	client "github.com/attestantio/go-eth2-client"
+	eth2client "github.com/attestantio/go-eth2-client"
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// headEventTopic is the topic of head events in the beacon node events stream
+	headEventTopic = "head"
+	// waitResultBlock is the result of waiting that ended when the block was seen
+	waitResultBlock = "block"
+	// waitResultDeadline is the result of waiting that ended on the deadline
+	waitResultDeadline = "deadline"
+)
+
+var (
+	metricsWaitForBlock = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:beacon:wait_for_block",
+		Help: "Count waits for the block of a slot by their result (block / deadline)",
+	}, []string{"result"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsWaitForBlock); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
+
+// headTracker tracks the head slot of the beacon nodes by their events stream,
+// and enables to wait for the block of a specific slot
+type headTracker struct {
+	logger *zap.Logger
+
+	lock     sync.Mutex
+	headSlot spec.Slot
+	// updated is closed and replaced once the head was updated
+	updated chan struct{}
+
+	handlersLock sync.Mutex
+	handlers     []func(event *api.HeadEvent)
+	// lastEvent is the last event that was passed to handlers, used to skip the same event from other beacon nodes
+	lastEvent *api.HeadEvent
+
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+}
+
+// newHeadTracker creates a new instance of headTracker
+func newHeadTracker(logger *zap.Logger) *headTracker {
+	return &headTracker{
+		logger:  logger,
+		updated: make(chan struct{}),
+		now:     time.Now,
+		after:   time.After,
+	}
+}
+
+// subscribe subscribes to the head events of the given beacon node
+func (ht *headTracker) subscribe(ctx context.Context, c client.Service) error {
+	provider, isProvider := c.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("client does not support EventsProvider")
+	}
+	return provider.Events(ctx, []string{headEventTopic}, ht.handleEvent)
+}
+
+// handleEvent handles events from the beacon nodes
+func (ht *headTracker) handleEvent(event *api.Event) {
+	if event == nil || event.Topic != headEventTopic {
+		return
+	}
+	headEvent, ok := event.Data.(*api.HeadEvent)
+	if !ok || headEvent == nil {
+		return
+	}
+	ht.onHead(headEvent.Slot)
+	ht.notifyHandlers(headEvent)
+}
+
+// addHandler registers a handler for head events
+func (ht *headTracker) addHandler(handler func(event *api.HeadEvent)) {
+	ht.handlersLock.Lock()
+	defer ht.handlersLock.Unlock()
+
+	ht.handlers = append(ht.handlers, handler)
+}
+
+// notifyHandlers passes the given event to the registered handlers
+func (ht *headTracker) notifyHandlers(event *api.HeadEvent) {
+	ht.handlersLock.Lock()
+	if last := ht.lastEvent; last != nil && last.Slot == event.Slot && last.Block == event.Block &&
+		last.CurrentDutyDependentRoot == event.CurrentDutyDependentRoot &&
+		last.PreviousDutyDependentRoot == event.PreviousDutyDependentRoot {
+		ht.handlersLock.Unlock()
+		return
+	}
+	ht.lastEvent = event
+	handlers := make([]func(event *api.HeadEvent), len(ht.handlers))
+	copy(handlers, ht.handlers)
+	ht.handlersLock.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// onHead updates the head slot and notifies waiters
+func (ht *headTracker) onHead(slot spec.Slot) {
+	ht.lock.Lock()
+	defer ht.lock.Unlock()
+
+	if slot <= ht.headSlot {
+		return
+	}
+	ht.headSlot = slot
+	close(ht.updated)
+	ht.updated = make(chan struct{})
+}
+
+// waitForBlock blocks until the head reached the given slot or until the deadline,
+// returns whether the block of the slot was seen
+func (ht *headTracker) waitForBlock(ctx context.Context, slot spec.Slot, deadline time.Time) bool {
+	for {
+		ht.lock.Lock()
+		headSlot, updated := ht.headSlot, ht.updated
+		ht.lock.Unlock()
+
+		if headSlot >= slot {
+			return true
+		}
+		wait := deadline.Sub(ht.now())
+		if wait <= 0 {
+			return false
+		}
+		select {
+		case <-updated:
+		case <-ht.after(wait):
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// SubscribeToHeadEvents implements Beacon interface
+func (gc *goClient) SubscribeToHeadEvents(handler func(event *api.HeadEvent)) {
+	gc.heads.addHandler(handler)
+}
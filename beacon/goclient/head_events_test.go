@@ -0,0 +1,149 @@
+package goclient
+
+import (
+	"context"
+	client "github.com/attestantio/go-eth2-client"
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// fakeEventSource is an events provider that enables to push events manually
+type fakeEventSource struct {
+	client.Service
+	topics  []string
+	handler client.EventHandlerFunc
+}
+
+func (f *fakeEventSource) Events(ctx context.Context, topics []string, handler client.EventHandlerFunc) error {
+	f.topics = topics
+	f.handler = handler
+	return nil
+}
+
+func (f *fakeEventSource) pushHead(slot spec.Slot) {
+	f.handler(&api.Event{Topic: headEventTopic, Data: &api.HeadEvent{Slot: slot}})
+}
+
+// fakeClock is a manual clock for head tracker
+type fakeClock struct {
+	now    time.Time
+	timers chan chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, timers: make(chan chan time.Time, 10)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(time.Duration) <-chan time.Time {
+	timer := make(chan time.Time, 1)
+	c.timers <- timer
+	return timer
+}
+
+func newTestHeadTracker(t *testing.T, clock *fakeClock) (*headTracker, *fakeEventSource) {
+	ht := newHeadTracker(zap.L())
+	ht.now = clock.Now
+	ht.after = clock.After
+	source := &fakeEventSource{}
+	require.NoError(t, ht.subscribe(context.Background(), source))
+	require.Equal(t, []string{headEventTopic}, source.topics)
+	return ht, source
+}
+
+func waitForBlockAsync(ht *headTracker, ctx context.Context, slot spec.Slot, deadline time.Time) <-chan bool {
+	res := make(chan bool, 1)
+	go func() {
+		res <- ht.waitForBlock(ctx, slot, deadline)
+	}()
+	return res
+}
+
+func TestHeadTracker_Subscribe(t *testing.T) {
+	ht := newHeadTracker(zap.L())
+	// the service doesn't implement EventsProvider
+	require.Error(t, ht.subscribe(context.Background(), struct{ client.Service }{}))
+}
+
+func TestHeadTracker_WaitForBlock(t *testing.T) {
+	ctx := context.Background()
+	slotStart := time.Unix(1616508000, 0)
+	deadline := slotStart.Add(4 * time.Second)
+
+	t.Run("block seen before waiting", func(t *testing.T) {
+		ht, source := newTestHeadTracker(t, newFakeClock(slotStart))
+		source.pushHead(10)
+		require.True(t, ht.waitForBlock(ctx, 10, deadline))
+		require.True(t, ht.waitForBlock(ctx, 9, deadline))
+	})
+
+	t.Run("block seen while waiting", func(t *testing.T) {
+		clock := newFakeClock(slotStart)
+		ht, source := newTestHeadTracker(t, clock)
+		res := waitForBlockAsync(ht, ctx, 10, deadline)
+		<-clock.timers
+		// head of a previous slot doesn't release the waiting
+		source.pushHead(9)
+		<-clock.timers
+		// irrelevant events are ignored
+		source.handler(&api.Event{Topic: "block", Data: &api.BlockEvent{Slot: 10}})
+		source.handler(&api.Event{Topic: headEventTopic})
+		select {
+		case <-res:
+			t.Fatal("waiting was released before the block was seen")
+		case <-time.After(50 * time.Millisecond):
+		}
+		source.pushHead(10)
+		require.True(t, <-res)
+	})
+
+	t.Run("deadline reached", func(t *testing.T) {
+		clock := newFakeClock(slotStart)
+		ht, source := newTestHeadTracker(t, clock)
+		source.pushHead(9)
+		res := waitForBlockAsync(ht, ctx, 10, deadline)
+		timer := <-clock.timers
+		timer <- deadline
+		require.False(t, <-res)
+	})
+
+	t.Run("deadline passed", func(t *testing.T) {
+		ht, _ := newTestHeadTracker(t, newFakeClock(deadline.Add(time.Millisecond)))
+		require.False(t, ht.waitForBlock(ctx, 10, deadline))
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		clock := newFakeClock(slotStart)
+		ht, _ := newTestHeadTracker(t, clock)
+		ctx, cancel := context.WithCancel(ctx)
+		res := waitForBlockAsync(ht, ctx, 10, deadline)
+		<-clock.timers
+		cancel()
+		require.False(t, <-res)
+	})
+}
+
+func TestHeadTracker_Handlers(t *testing.T) {
+	ht, source := newTestHeadTracker(t, newFakeClock(time.Now()))
+	var events []*api.HeadEvent
+	ht.addHandler(func(event *api.HeadEvent) {
+		events = append(events, event)
+	})
+
+	source.pushHead(10)
+	// the same event from another beacon node is passed once
+	source.pushHead(10)
+	require.Len(t, events, 1)
+	source.handler(&api.Event{Topic: headEventTopic, Data: &api.HeadEvent{Slot: 10, CurrentDutyDependentRoot: spec.Root{1}}})
+	source.pushHead(11)
+	require.Len(t, events, 3)
+	require.Equal(t, spec.Root{1}, events[1].CurrentDutyDependentRoot)
+	require.EqualValues(t, 11, events[2].Slot)
+}
@@ -1,6 +1,10 @@
 package goclient
 
 import (
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/ibft/proto"
 	"github.com/herumi/bls-eth-go-binary/bls"
 )
@@ -9,6 +13,46 @@ func (gc *goClient) AddShare(shareKey *bls.SecretKey) error {
 	return gc.keyManager.AddShare(shareKey)
 }
 
+func (gc *goClient) RemoveShare(pubKey string) error {
+	return gc.keyManager.RemoveShare(pubKey)
+}
+
+func (gc *goClient) RetrieveHighestAttestation(pubKey []byte) (*phase0.AttestationData, error) {
+	return gc.keyManager.RetrieveHighestAttestation(pubKey)
+}
+
 func (gc *goClient) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	return gc.keyManager.SignIBFTMessage(message, pk)
 }
+
+func (gc *goClient) SignRandaoReveal(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return gc.keyManager.SignRandaoReveal(duty, pk)
+}
+
+func (gc *goClient) SignBeaconBlock(block *spec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*spec.VersionedSignedBeaconBlock, []byte, error) {
+	return gc.keyManager.SignBeaconBlock(block, duty, pk)
+}
+
+func (gc *goClient) SignSelectionProof(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return gc.keyManager.SignSelectionProof(duty, pk)
+}
+
+func (gc *goClient) SignAggregateAndProof(msg *phase0.AggregateAndProof, duty *beacon.Duty, pk []byte) (*phase0.SignedAggregateAndProof, []byte, error) {
+	return gc.keyManager.SignAggregateAndProof(msg, duty, pk)
+}
+
+func (gc *goClient) SignSyncCommitteeMessage(blockRoot phase0.Root, duty *beacon.Duty, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	return gc.keyManager.SignSyncCommitteeMessage(blockRoot, duty, pk)
+}
+
+func (gc *goClient) SignSyncCommitteeSelectionProof(subcommitteeIndex uint64, duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return gc.keyManager.SignSyncCommitteeSelectionProof(subcommitteeIndex, duty, pk)
+}
+
+func (gc *goClient) SignContributionAndProof(msg *altair.ContributionAndProof, duty *beacon.Duty, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	return gc.keyManager.SignContributionAndProof(msg, duty, pk)
+}
+
+func (gc *goClient) SignVoluntaryExit(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return gc.keyManager.SignVoluntaryExit(duty, pk)
+}
@@ -0,0 +1,54 @@
+package goclient
+
+import (
+	client "github.com/attestantio/go-eth2-client"
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/pkg/errors"
+)
+
+// getProposerDuties returns the proposer duties of the given validators
+func (gc *goClient) getProposerDuties(c client.Service, epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	if provider, isProvider := c.(eth2client.ProposerDutiesProvider); isProvider {
+		proposerDuties, err := provider.ProposerDuties(gc.ctx, epoch, validatorIndices)
+		if err != nil {
+			return nil, err
+		}
+		var duties []*beacon.Duty
+		for _, proposerDuty := range proposerDuties {
+			duties = append(duties, &beacon.Duty{
+				Type:           beacon.RoleTypeProposer,
+				PubKey:         proposerDuty.PubKey,
+				Slot:           proposerDuty.Slot,
+				ValidatorIndex: proposerDuty.ValidatorIndex,
+			})
+		}
+		return duties, nil
+	}
+	return nil, errors.New("client does not support ProposerDutiesProvider")
+}
+
+// GetBeaconBlock implements Beacon interface
+func (gc *goClient) GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*eth2spec.VersionedBeaconBlock, error) {
+	if provider, isProvider := gc.client().(eth2client.BeaconBlockProposalProvider); isProvider {
+		block, err := provider.BeaconBlockProposal(gc.ctx, slot, randaoReveal, gc.graffiti)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil || block.IsEmpty() {
+			return nil, errors.New("received an empty beacon block proposal")
+		}
+		return block, nil
+	}
+	return nil, errors.New("client does not support BeaconBlockProposalProvider")
+}
+
+// SubmitBeaconBlock implements Beacon interface
+func (gc *goClient) SubmitBeaconBlock(block *eth2spec.VersionedSignedBeaconBlock) error {
+	if provider, isProvider := gc.client().(eth2client.BeaconBlockSubmitter); isProvider {
+		return provider.SubmitBeaconBlock(gc.ctx, block)
+	}
+	return errors.New("client does not support BeaconBlockSubmitter")
+}
@@ -0,0 +1,492 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+const (
+	publicKeysPath = "/api/v1/eth2/publicKeys"
+	signPath       = "/api/v1/eth2/sign/0x%s"
+
+	attestationSignType                   = "ATTESTATION"
+	randaoRevealSignType                  = "RANDAO_REVEAL"
+	blockSignType                         = "BLOCK_V2"
+	aggregationSlotSignType               = "AGGREGATION_SLOT"
+	aggregateAndProofSignType             = "AGGREGATE_AND_PROOF"
+	syncCommitteeMessageSignType          = "SYNC_COMMITTEE_MESSAGE"
+	syncCommitteeSelectionProofSignType   = "SYNC_COMMITTEE_SELECTION_PROOF"
+	syncCommitteeContributionAndProofType = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+	voluntaryExitSignType                 = "VOLUNTARY_EXIT"
+
+	requestTimeout = 5 * time.Second
+)
+
+// ForkInfoProvider provides the fork information required by the remote signer to compute signing domains
+type ForkInfoProvider interface {
+	// GetForkInfo returns the current fork and the genesis validators root of the chain
+	GetForkInfo() (*spec.Fork, spec.Root, error)
+}
+
+// forkInfo is the fork information sent with beacon signing requests
+type forkInfo struct {
+	Fork                  *spec.Fork `json:"fork"`
+	GenesisValidatorsRoot string     `json:"genesis_validators_root"`
+}
+
+// signRequest is the body of a Web3Signer signing request, only the object of the request type is set
+type signRequest struct {
+	Type                        string                       `json:"type"`
+	ForkInfo                    *forkInfo                    `json:"fork_info,omitempty"`
+	SigningRoot                 string                       `json:"signingRoot"`
+	Attestation                 *spec.AttestationData        `json:"attestation,omitempty"`
+	RandaoReveal                *randaoReveal                `json:"randao_reveal,omitempty"`
+	BeaconBlock                 *beaconBlock                 `json:"beacon_block,omitempty"`
+	AggregationSlot             *aggregationSlot             `json:"aggregation_slot,omitempty"`
+	AggregateAndProof           *spec.AggregateAndProof      `json:"aggregate_and_proof,omitempty"`
+	SyncCommitteeMessage        *syncCommitteeMessage        `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *syncAggregatorSelectionData `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *altair.ContributionAndProof `json:"contribution_and_proof,omitempty"`
+	VoluntaryExit               *spec.VoluntaryExit          `json:"voluntary_exit,omitempty"`
+}
+
+type randaoReveal struct {
+	Epoch string `json:"epoch"`
+}
+
+type beaconBlock struct {
+	Version string      `json:"version"`
+	Block   interface{} `json:"block"`
+}
+
+type aggregationSlot struct {
+	Slot string `json:"slot"`
+}
+
+type syncCommitteeMessage struct {
+	BeaconBlockRoot string `json:"beacon_block_root"`
+	Slot            string `json:"slot"`
+}
+
+type syncAggregatorSelectionData struct {
+	Slot              string `json:"slot"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+}
+
+// signResponse is the json body of a Web3Signer signing response
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type remoteSigner struct {
+	ctx          context.Context
+	addr         string
+	client       *http.Client
+	storage      *signerStorage
+	network      core.Network
+	signingUtils beacon.SigningUtil
+	forkInfo     ForkInfoProvider
+}
+
+// NewRemoteSigner returns a new instance of remoteSigner, which signs beacon objects with share keys held by a Web3Signer.
+// iBFT messages are not beacon objects, therefore they are signed locally with the share keys that were added to the signer
+func NewRemoteSigner(ctx context.Context, addr string, db basedb.IDb, network core.Network, signingUtils beacon.SigningUtil, forkInfo ForkInfoProvider) (beacon.KeyManager, error) {
+	if len(addr) == 0 {
+		return nil, errors.New("remote signer address is missing")
+	}
+	return &remoteSigner{
+		ctx:          ctx,
+		addr:         strings.TrimSuffix(addr, "/"),
+		client:       &http.Client{Timeout: requestTimeout},
+		storage:      newSignerStorage(db),
+		network:      network,
+		signingUtils: signingUtils,
+		forkInfo:     forkInfo,
+	}, nil
+}
+
+// AddShare makes sure the share is held by the remote signer, the secret key is kept locally for signing iBFT messages
+func (rs *remoteSigner) AddShare(shareKey *bls.SecretKey) error {
+	pk := shareKey.GetPublicKey().Serialize()
+	pks, err := rs.publicKeys()
+	if err != nil {
+		return errors.Wrap(err, "could not fetch remote signer public keys")
+	}
+	if _, found := pks[hex.EncodeToString(pk)]; !found {
+		return errors.Errorf("share %s is not held by remote signer", hex.EncodeToString(pk))
+	}
+	if err := rs.storage.saveShareKey(pk, shareKey); err != nil {
+		return errors.Wrap(err, "could not save share key")
+	}
+
+	highest, err := rs.storage.retrieveHighestAttestation(pk)
+	if err != nil {
+		return errors.Wrap(err, "could not check highest attestation")
+	}
+	if highest == nil {
+		if err := rs.storage.saveHighestAttestation(pk, &spec.AttestationData{
+			Source: &spec.Checkpoint{},
+			Target: &spec.Checkpoint{},
+		}); err != nil {
+			return errors.Wrap(err, "could not save zero highest attestation")
+		}
+	}
+	return nil
+}
+
+// RemoveShare removes the local share key, the share is held by the remote signer and the local slashing protection record is kept
+func (rs *remoteSigner) RemoveShare(pubKey string) error {
+	pk, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not decode public key")
+	}
+	if err := rs.storage.deleteShareKey(pk); err != nil {
+		return errors.Wrap(err, "could not delete share key")
+	}
+	return nil
+}
+
+func (rs *remoteSigner) RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error) {
+	return rs.storage.retrieveHighestAttestation(pubKey)
+}
+
+// SignIBFTMessage signs the message locally with the share key, as a Web3Signer signs beacon objects only
+func (rs *remoteSigner) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
+	sk, err := rs.storage.retrieveShareKey(pk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get share key")
+	}
+	if sk == nil {
+		return nil, errors.New("share key not found")
+	}
+	sig, err := message.Sign(sk)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign message")
+	}
+	return sig.Serialize(), nil
+}
+
+func (rs *remoteSigner) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
+	domain, err := rs.signingUtils.GetDomain(data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := rs.signingUtils.ComputeSigningRoot(data, domain[:])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	fork, err := rs.getForkInfo()
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err := rs.sign(pk, &signRequest{
+		Type:        attestationSignType,
+		ForkInfo:    fork,
+		SigningRoot: "0x" + hex.EncodeToString(root[:]),
+		Attestation: data,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign attestation")
+	}
+	if err := rs.storage.updateHighestAttestation(pk, data); err != nil {
+		return nil, nil, errors.Wrap(err, "could not update highest attestation")
+	}
+
+	aggregationBitfield := bitfield.NewBitlist(duty.CommitteeLength)
+	aggregationBitfield.SetBitAt(duty.ValidatorCommitteeIndex, true)
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &spec.Attestation{
+		AggregationBits: aggregationBitfield,
+		Data:            data,
+		Signature:       blsSig,
+	}, root[:], nil
+}
+
+func (rs *remoteSigner) SignRandaoReveal(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	epoch := rs.dutyEpoch(duty)
+	sig, root, err := rs.signBeaconObject(uint64(epoch), beacon.DomainRandao, epoch, pk, &signRequest{
+		Type:         randaoRevealSignType,
+		RandaoReveal: &randaoReveal{Epoch: strconv.FormatUint(uint64(epoch), 10)},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign randao reveal")
+	}
+	return sig, root, nil
+}
+
+func (rs *remoteSigner) SignBeaconBlock(b *eth2spec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*eth2spec.VersionedSignedBeaconBlock, []byte, error) {
+	var object interface{}
+	req := &signRequest{Type: blockSignType}
+	switch b.Version {
+	case eth2spec.DataVersionPhase0:
+		if b.Phase0 == nil {
+			return nil, nil, errors.New("missing phase0 block")
+		}
+		object = b.Phase0
+		req.BeaconBlock = &beaconBlock{Version: "PHASE0", Block: b.Phase0}
+	case eth2spec.DataVersionAltair:
+		if b.Altair == nil {
+			return nil, nil, errors.New("missing altair block")
+		}
+		object = b.Altair
+		req.BeaconBlock = &beaconBlock{Version: "ALTAIR", Block: b.Altair}
+	default:
+		return nil, nil, errors.Errorf("unsupported block version %d", b.Version)
+	}
+	sig, root, err := rs.signBeaconObject(object, beacon.DomainBeaconProposer, rs.dutyEpoch(duty), pk, req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign beacon block")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	signed := &eth2spec.VersionedSignedBeaconBlock{Version: b.Version}
+	switch b.Version {
+	case eth2spec.DataVersionPhase0:
+		signed.Phase0 = &spec.SignedBeaconBlock{Message: b.Phase0, Signature: blsSig}
+	case eth2spec.DataVersionAltair:
+		signed.Altair = &altair.SignedBeaconBlock{Message: b.Altair, Signature: blsSig}
+	}
+	return signed, root, nil
+}
+
+func (rs *remoteSigner) SignSelectionProof(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	sig, root, err := rs.signBeaconObject(uint64(duty.Slot), beacon.DomainSelectionProof, rs.dutyEpoch(duty), pk, &signRequest{
+		Type:            aggregationSlotSignType,
+		AggregationSlot: &aggregationSlot{Slot: strconv.FormatUint(uint64(duty.Slot), 10)},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign selection proof")
+	}
+	return sig, root, nil
+}
+
+func (rs *remoteSigner) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	sig, root, err := rs.signBeaconObject(msg, beacon.DomainAggregateAndProof, rs.dutyEpoch(duty), pk, &signRequest{
+		Type:              aggregateAndProofSignType,
+		AggregateAndProof: msg,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign aggregate and proof")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &spec.SignedAggregateAndProof{
+		Message:   msg,
+		Signature: blsSig,
+	}, root, nil
+}
+
+func (rs *remoteSigner) SignSyncCommitteeMessage(blockRoot spec.Root, duty *beacon.Duty, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	sszRoot := types.SSZBytes(blockRoot[:])
+	sig, root, err := rs.signBeaconObject(&sszRoot, beacon.DomainSyncCommittee, rs.dutyEpoch(duty), pk, &signRequest{
+		Type: syncCommitteeMessageSignType,
+		SyncCommitteeMessage: &syncCommitteeMessage{
+			BeaconBlockRoot: "0x" + hex.EncodeToString(blockRoot[:]),
+			Slot:            strconv.FormatUint(uint64(duty.Slot), 10),
+		},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign sync committee message")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &altair.SyncCommitteeMessage{
+		Slot:            duty.Slot,
+		BeaconBlockRoot: blockRoot,
+		ValidatorIndex:  duty.ValidatorIndex,
+		Signature:       blsSig,
+	}, root, nil
+}
+
+func (rs *remoteSigner) SignSyncCommitteeSelectionProof(subcommitteeIndex uint64, duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	data := &altair.SyncAggregatorSelectionData{
+		Slot:              duty.Slot,
+		SubcommitteeIndex: subcommitteeIndex,
+	}
+	sig, root, err := rs.signBeaconObject(data, beacon.DomainSyncCommitteeSelectionProof, rs.dutyEpoch(duty), pk, &signRequest{
+		Type: syncCommitteeSelectionProofSignType,
+		SyncAggregatorSelectionData: &syncAggregatorSelectionData{
+			Slot:              strconv.FormatUint(uint64(duty.Slot), 10),
+			SubcommitteeIndex: strconv.FormatUint(subcommitteeIndex, 10),
+		},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign sync committee selection proof")
+	}
+	return sig, root, nil
+}
+
+func (rs *remoteSigner) SignContributionAndProof(msg *altair.ContributionAndProof, duty *beacon.Duty, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	sig, root, err := rs.signBeaconObject(msg, beacon.DomainContributionAndProof, rs.dutyEpoch(duty), pk, &signRequest{
+		Type:                 syncCommitteeContributionAndProofType,
+		ContributionAndProof: msg,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign contribution and proof")
+	}
+
+	blsSig := spec.BLSSignature{}
+	copy(blsSig[:], sig)
+	return &altair.SignedContributionAndProof{
+		Message:   msg,
+		Signature: blsSig,
+	}, root, nil
+}
+
+func (rs *remoteSigner) SignVoluntaryExit(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	epoch := rs.dutyEpoch(duty)
+	exit := &spec.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: duty.ValidatorIndex,
+	}
+	sig, root, err := rs.signBeaconObject(exit, beacon.DomainVoluntaryExit, epoch, pk, &signRequest{
+		Type:          voluntaryExitSignType,
+		VoluntaryExit: exit,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to sign voluntary exit")
+	}
+	return sig, root, nil
+}
+
+// dutyEpoch returns the epoch of the given duty's slot
+func (rs *remoteSigner) dutyEpoch(duty *beacon.Duty) spec.Epoch {
+	return spec.Epoch(rs.network.EstimatedEpochAtSlot(types.Slot(duty.Slot)))
+}
+
+// signBeaconObject computes the signing root of the given object with the domain of the given type and epoch,
+// and sends the given request completed with the fork info and the signing root. returns the signature and the signing root
+func (rs *remoteSigner) signBeaconObject(object interface{}, domainType beacon.DomainType, epoch spec.Epoch, pk []byte, req *signRequest) ([]byte, []byte, error) {
+	domain, err := rs.signingUtils.GetEpochDomain(domainType, epoch)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get domain for signing")
+	}
+	root, err := rs.signingUtils.ComputeSigningRoot(object, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get root for signing")
+	}
+	fork, err := rs.getForkInfo()
+	if err != nil {
+		return nil, nil, err
+	}
+	req.ForkInfo = fork
+	req.SigningRoot = "0x" + hex.EncodeToString(root[:])
+	sig, err := rs.sign(pk, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, root[:], nil
+}
+
+func (rs *remoteSigner) getForkInfo() (*forkInfo, error) {
+	fork, genesisValidatorsRoot, err := rs.forkInfo.GetForkInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get fork info")
+	}
+	return &forkInfo{
+		Fork:                  fork,
+		GenesisValidatorsRoot: "0x" + hex.EncodeToString(genesisValidatorsRoot[:]),
+	}, nil
+}
+
+// publicKeys returns the hex encoded public keys held by the remote signer
+func (rs *remoteSigner) publicKeys() (map[string]bool, error) {
+	body, err := rs.do(http.MethodGet, publicKeysPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var pks []string
+	if err := json.Unmarshal(body, &pks); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal public keys")
+	}
+	ret := make(map[string]bool, len(pks))
+	for _, pk := range pks {
+		ret[strings.ToLower(strings.TrimPrefix(pk, "0x"))] = true
+	}
+	return ret, nil
+}
+
+// sign sends the given signing request and returns the signature of the given share
+func (rs *remoteSigner) sign(pk []byte, req *signRequest) ([]byte, error) {
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal signing request")
+	}
+	body, err := rs.do(http.MethodPost, fmt.Sprintf(signPath, hex.EncodeToString(pk)), reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// the signature is returned either as json or as plain hex text
+	sigHex := strings.TrimSpace(string(body))
+	res := &signResponse{}
+	if err := json.Unmarshal(body, res); err == nil {
+		sigHex = res.Signature
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(sigHex, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode signature")
+	}
+	if len(sig) != len(spec.BLSSignature{}) {
+		return nil, errors.Errorf("invalid signature length %d", len(sig))
+	}
+	return sig, nil
+}
+
+func (rs *remoteSigner) do(method string, path string, reqBody []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(rs.ctx, method, rs.addr+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := rs.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "remote signer request failed")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read remote signer response")
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, errors.New("key not found in remote signer")
+	case http.StatusPreconditionFailed:
+		return nil, errors.New("slashable data, remote signer refused to sign")
+	default:
+		return nil, errors.Errorf("remote signer returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
@@ -0,0 +1,419 @@
+package remotesigner
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/threshold"
+	fssz "github.com/ferranbt/fastssz"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	sk1Str = "3548db63ab5701878daf25fa877638dc7809778815b9d9ecd5369da33ca9e64f"
+	sk2Str = "66dd37ae71b35c81022cdde98370e881cff896b689fa9136917f45afce43fd3b"
+)
+
+type signingUtils struct {
+}
+
+func (s *signingUtils) GetDomain(data *spec.AttestationData) ([]byte, error) {
+	return make([]byte, 32), nil
+}
+
+func (s *signingUtils) GetEpochDomain(domainType beacon.DomainType, epoch spec.Epoch) ([]byte, error) {
+	return make([]byte, 32), nil
+}
+
+func (s *signingUtils) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
+	var root [32]byte
+	var err error
+	if v, ok := object.(fssz.HashRoot); ok {
+		root, err = v.HashTreeRoot()
+	} else {
+		root, err = ssz.HashTreeRoot(object)
+	}
+	if err != nil {
+		return [32]byte{}, err
+	}
+	container := &spec.SigningData{ObjectRoot: root}
+	copy(container.Domain[:], domain)
+	return container.HashTreeRoot()
+}
+
+type forkInfoProvider struct {
+}
+
+func (p *forkInfoProvider) GetForkInfo() (*spec.Fork, spec.Root, error) {
+	return &spec.Fork{
+		PreviousVersion: spec.Version{0x00, 0x00, 0x10, 0x20},
+		CurrentVersion:  spec.Version{0x01, 0x00, 0x10, 0x20},
+		Epoch:           36660,
+	}, spec.Root{1, 2, 3, 4}, nil
+}
+
+// stubSigner is a minimal Web3Signer compatible service signing the received signing roots
+type stubSigner struct {
+	keys     map[string]*bls.SecretKey
+	slashing bool
+
+	lock     sync.Mutex
+	requests []map[string]interface{}
+}
+
+func (s *stubSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == publicKeysPath {
+		pks := make([]string, 0)
+		for pk := range s.keys {
+			pks = append(pks, "0x"+pk)
+		}
+		_ = json.NewEncoder(w).Encode(pks)
+		return
+	}
+
+	pk := strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/0x")
+	sk, found := s.keys[pk]
+	if r.Method != http.MethodPost || !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.lock.Lock()
+	slashing := s.slashing
+	s.lock.Unlock()
+	if slashing {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	req := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.lock.Lock()
+	s.requests = append(s.requests, req)
+	s.lock.Unlock()
+
+	root, err := hex.DecodeString(strings.TrimPrefix(req["signingRoot"].(string), "0x"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(&signResponse{
+		Signature: "0x" + hex.EncodeToString(sk.SignByte(root).Serialize()),
+	})
+}
+
+func (s *stubSigner) setSlashing(slashing bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.slashing = slashing
+}
+
+func (s *stubSigner) requestsCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.requests)
+}
+
+func (s *stubSigner) lastRequest() map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests[len(s.requests)-1]
+}
+
+func getStorage(t *testing.T) basedb.IDb {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func testRemoteSigner(t *testing.T) (beacon.KeyManager, *stubSigner, *bls.SecretKey) {
+	threshold.Init()
+
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.SetHexString(sk1Str))
+	stub := &stubSigner{keys: map[string]*bls.SecretKey{
+		hex.EncodeToString(sk.GetPublicKey().Serialize()): sk,
+	}}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	km, err := NewRemoteSigner(context.Background(), server.URL, getStorage(t), core.PraterNetwork, &signingUtils{}, &forkInfoProvider{})
+	require.NoError(t, err)
+	require.NoError(t, km.AddShare(sk))
+	return km, stub, sk
+}
+
+func TestNewRemoteSigner(t *testing.T) {
+	_, err := NewRemoteSigner(context.Background(), "", getStorage(t), core.PraterNetwork, &signingUtils{}, &forkInfoProvider{})
+	require.EqualError(t, err, "remote signer address is missing")
+}
+
+func TestAddShare(t *testing.T) {
+	km, _, sk := testRemoteSigner(t)
+
+	t.Run("zero highest attestation", func(t *testing.T) {
+		highest, err := km.RetrieveHighestAttestation(sk.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.NotNil(t, highest)
+		require.EqualValues(t, 0, highest.Source.Epoch)
+		require.EqualValues(t, 0, highest.Target.Epoch)
+	})
+
+	t.Run("share not held by remote signer", func(t *testing.T) {
+		sk2 := &bls.SecretKey{}
+		require.NoError(t, sk2.SetHexString(sk2Str))
+		err := km.AddShare(sk2)
+		require.EqualError(t, err, fmt.Sprintf("share %s is not held by remote signer", hex.EncodeToString(sk2.GetPublicKey().Serialize())))
+	})
+}
+
+func TestSignAttestation(t *testing.T) {
+	km, stub, sk := testRemoteSigner(t)
+	pk := sk.GetPublicKey().Serialize()
+
+	duty := &beacon.Duty{
+		Type:                    beacon.RoleTypeAttester,
+		Slot:                    30,
+		ValidatorIndex:          1,
+		CommitteeIndex:          2,
+		CommitteeLength:         128,
+		CommitteesAtSlot:        4,
+		ValidatorCommitteeIndex: 3,
+	}
+	attestationData := &spec.AttestationData{
+		Slot:            30,
+		Index:           2,
+		BeaconBlockRoot: [32]byte{1, 2, 3, 4, 5, 6},
+		Source:          &spec.Checkpoint{Epoch: 1},
+		Target:          &spec.Checkpoint{Epoch: 3},
+	}
+
+	t.Run("sign", func(t *testing.T) {
+		attestation, root, err := km.SignAttestation(attestationData, duty, pk)
+		require.NoError(t, err)
+		require.True(t, attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex))
+		require.EqualValues(t, attestationData, attestation.Data)
+
+		sig := &bls.Sign{}
+		require.NoError(t, sig.Deserialize(append([]byte{}, attestation.Signature[:]...)))
+		require.True(t, sig.VerifyByte(sk.GetPublicKey(), root))
+
+		req := stub.lastRequest()
+		require.EqualValues(t, attestationSignType, req["type"])
+		require.EqualValues(t, "0x"+hex.EncodeToString(root), req["signingRoot"])
+		require.EqualValues(t, "3", req["attestation"].(map[string]interface{})["target"].(map[string]interface{})["epoch"])
+		forkInfo := req["fork_info"].(map[string]interface{})
+		require.EqualValues(t, "0x0102030400000000000000000000000000000000000000000000000000000000", forkInfo["genesis_validators_root"])
+		require.EqualValues(t, "0x01001020", forkInfo["fork"].(map[string]interface{})["current_version"])
+	})
+
+	t.Run("highest attestation updated", func(t *testing.T) {
+		highest, err := km.RetrieveHighestAttestation(pk)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, highest.Source.Epoch)
+		require.EqualValues(t, 3, highest.Target.Epoch)
+	})
+
+	t.Run("slashable, refused by remote signer", func(t *testing.T) {
+		stub.setSlashing(true)
+		defer stub.setSlashing(false)
+		_, _, err := km.SignAttestation(&spec.AttestationData{
+			Slot:            64,
+			BeaconBlockRoot: [32]byte{1, 2, 3, 4, 5, 6},
+			Source:          &spec.Checkpoint{Epoch: 1},
+			Target:          &spec.Checkpoint{Epoch: 2},
+		}, duty, pk)
+		require.EqualError(t, err, "failed to sign attestation: slashable data, remote signer refused to sign")
+
+		highest, err := km.RetrieveHighestAttestation(pk)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, highest.Target.Epoch)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, _, err := km.SignAttestation(attestationData, duty, make([]byte, 48))
+		require.EqualError(t, err, "failed to sign attestation: key not found in remote signer")
+	})
+}
+
+func TestSignIBFTMessage(t *testing.T) {
+	km, stub, sk := testRemoteSigner(t)
+
+	msg := &proto.Message{
+		Type:      proto.RoundState_Commit,
+		Round:     2,
+		Lambda:    []byte("lambda1"),
+		SeqNumber: 3,
+		Value:     []byte("value1"),
+	}
+
+	t.Run("signed locally", func(t *testing.T) {
+		requests := stub.requestsCount()
+		sig, err := km.SignIBFTMessage(msg, sk.GetPublicKey().Serialize())
+		require.NoError(t, err)
+		require.Equal(t, requests, stub.requestsCount())
+
+		signed := &proto.SignedMessage{
+			Message:   msg,
+			Signature: sig,
+			SignerIds: []uint64{1},
+		}
+		res, err := signed.VerifySig(sk.GetPublicKey())
+		require.NoError(t, err)
+		require.True(t, res)
+	})
+
+	t.Run("removed share", func(t *testing.T) {
+		require.NoError(t, km.RemoveShare(sk.GetPublicKey().SerializeToHexStr()))
+		_, err := km.SignIBFTMessage(msg, sk.GetPublicKey().Serialize())
+		require.EqualError(t, err, "share key not found")
+	})
+}
+
+func TestSignBeaconObjects(t *testing.T) {
+	km, stub, sk := testRemoteSigner(t)
+	pk := sk.GetPublicKey().Serialize()
+	// slot 893108 is in epoch 27909
+	duty := &beacon.Duty{Slot: 893108, ValidatorIndex: 205238}
+
+	tests := []struct {
+		name      string
+		sign      func() ([]byte, []byte, error)
+		signType  string
+		objectKey string
+		object    map[string]interface{}
+	}{
+		{"randao reveal", func() ([]byte, []byte, error) {
+			return km.SignRandaoReveal(duty, pk)
+		}, randaoRevealSignType, "randao_reveal", map[string]interface{}{"epoch": "27909"}},
+		{"beacon block", func() ([]byte, []byte, error) {
+			signed, root, err := km.SignBeaconBlock(&eth2spec.VersionedBeaconBlock{
+				Version: eth2spec.DataVersionAltair,
+				Altair:  testAltairBlock(duty.Slot),
+			}, duty, pk)
+			if err != nil {
+				return nil, nil, err
+			}
+			return signed.Altair.Signature[:], root, nil
+		}, blockSignType, "beacon_block", map[string]interface{}{"version": "ALTAIR"}},
+		{"selection proof", func() ([]byte, []byte, error) {
+			return km.SignSelectionProof(duty, pk)
+		}, aggregationSlotSignType, "aggregation_slot", map[string]interface{}{"slot": "893108"}},
+		{"aggregate and proof", func() ([]byte, []byte, error) {
+			signed, root, err := km.SignAggregateAndProof(&spec.AggregateAndProof{
+				AggregatorIndex: duty.ValidatorIndex,
+				Aggregate: &spec.Attestation{
+					AggregationBits: bitfield.NewBitlist(8),
+					Data: &spec.AttestationData{
+						Slot:   duty.Slot,
+						Source: &spec.Checkpoint{Epoch: 27907},
+						Target: &spec.Checkpoint{Epoch: 27908},
+					},
+				},
+			}, duty, pk)
+			if err != nil {
+				return nil, nil, err
+			}
+			return signed.Signature[:], root, nil
+		}, aggregateAndProofSignType, "aggregate_and_proof", map[string]interface{}{"aggregator_index": "205238"}},
+		{"sync committee message", func() ([]byte, []byte, error) {
+			msg, root, err := km.SignSyncCommitteeMessage(spec.Root{1, 2, 3}, duty, pk)
+			if err != nil {
+				return nil, nil, err
+			}
+			return msg.Signature[:], root, nil
+		}, syncCommitteeMessageSignType, "sync_committee_message", map[string]interface{}{
+			"slot":              "893108",
+			"beacon_block_root": "0x0102030000000000000000000000000000000000000000000000000000000000",
+		}},
+		{"sync committee selection proof", func() ([]byte, []byte, error) {
+			return km.SignSyncCommitteeSelectionProof(2, duty, pk)
+		}, syncCommitteeSelectionProofSignType, "sync_aggregator_selection_data", map[string]interface{}{
+			"slot":               "893108",
+			"subcommittee_index": "2",
+		}},
+		{"contribution and proof", func() ([]byte, []byte, error) {
+			signed, root, err := km.SignContributionAndProof(&altair.ContributionAndProof{
+				AggregatorIndex: duty.ValidatorIndex,
+				Contribution: &altair.SyncCommitteeContribution{
+					Slot:              duty.Slot,
+					SubcommitteeIndex: 2,
+					AggregationBits:   bitfield.NewBitvector128(),
+				},
+			}, duty, pk)
+			if err != nil {
+				return nil, nil, err
+			}
+			return signed.Signature[:], root, nil
+		}, syncCommitteeContributionAndProofType, "contribution_and_proof", map[string]interface{}{"aggregator_index": "205238"}},
+		{"voluntary exit", func() ([]byte, []byte, error) {
+			return km.SignVoluntaryExit(duty, pk)
+		}, voluntaryExitSignType, "voluntary_exit", map[string]interface{}{"epoch": "27909", "validator_index": "205238"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sigBytes, root, err := test.sign()
+			require.NoError(t, err)
+			sig := &bls.Sign{}
+			require.NoError(t, sig.Deserialize(append([]byte{}, sigBytes...)))
+			require.True(t, sig.VerifyByte(sk.GetPublicKey(), root))
+
+			req := stub.lastRequest()
+			require.EqualValues(t, test.signType, req["type"])
+			require.EqualValues(t, "0x"+hex.EncodeToString(root), req["signingRoot"])
+			require.NotNil(t, req["fork_info"])
+			object, ok := req[test.objectKey].(map[string]interface{})
+			require.True(t, ok)
+			for k, v := range test.object {
+				require.EqualValues(t, v, object[k])
+			}
+		})
+	}
+}
+
+func testAltairBlock(slot spec.Slot) *altair.BeaconBlock {
+	return &altair.BeaconBlock{
+		Slot:          slot,
+		ProposerIndex: 205238,
+		Body: &altair.BeaconBlockBody{
+			ETH1Data: &spec.ETH1Data{
+				BlockHash: make([]byte, 32),
+			},
+			Graffiti:          make([]byte, 32),
+			ProposerSlashings: []*spec.ProposerSlashing{},
+			AttesterSlashings: []*spec.AttesterSlashing{},
+			Attestations:      []*spec.Attestation{},
+			Deposits:          []*spec.Deposit{},
+			VoluntaryExits:    []*spec.SignedVoluntaryExit{},
+			SyncAggregate: &altair.SyncAggregate{
+				SyncCommitteeBits: bitfield.NewBitvector512(),
+			},
+		},
+	}
+}
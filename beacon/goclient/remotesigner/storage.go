@@ -0,0 +1,122 @@
+package remotesigner
+
+import (
+	"sync"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+const (
+	prefix           = "remote_signer-"
+	highestAttPrefix = prefix + "highest_att-"
+	shareKeyPrefix   = prefix + "share_key-"
+)
+
+// signerStorage keeps the highest attestation signed through the remote signer for each share,
+// the remote service has its own slashing protection but it doesn't expose its records.
+// it keeps the share keys as well, which are used for signing iBFT messages
+type signerStorage struct {
+	db   basedb.IDb
+	lock sync.RWMutex
+}
+
+func newSignerStorage(db basedb.IDb) *signerStorage {
+	return &signerStorage{
+		db:   db,
+		lock: sync.RWMutex{},
+	}
+}
+
+// saveShareKey stores the secret key of the given share
+func (s *signerStorage) saveShareKey(pubKey []byte, sk *bls.SecretKey) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Set([]byte(shareKeyPrefix), pubKey, sk.Serialize())
+}
+
+// retrieveShareKey returns nil,nil if no key was found
+func (s *signerStorage) retrieveShareKey(pubKey []byte) (*bls.SecretKey, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	obj, found, err := s.db.Get([]byte(shareKeyPrefix), pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get share key")
+	}
+	if !found || len(obj.Value) == 0 {
+		return nil, nil
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(obj.Value); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize share key")
+	}
+	return sk, nil
+}
+
+// deleteShareKey removes the secret key of the given share
+func (s *signerStorage) deleteShareKey(pubKey []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete([]byte(shareKeyPrefix), pubKey)
+}
+
+// saveHighestAttestation stores the given attestation as the highest attestation of the given share
+func (s *signerStorage) saveHighestAttestation(pubKey []byte, attestation *spec.AttestationData) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data, err := attestation.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal attestation")
+	}
+	return s.db.Set([]byte(highestAttPrefix), pubKey, data)
+}
+
+// retrieveHighestAttestation returns nil,nil if no record was found
+func (s *signerStorage) retrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	obj, found, err := s.db.Get([]byte(highestAttPrefix), pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get highest attestation")
+	}
+	if !found || len(obj.Value) == 0 {
+		return nil, nil
+	}
+	ret := &spec.AttestationData{}
+	if err := ret.UnmarshalSSZ(obj.Value); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal highest attestation")
+	}
+	return ret, nil
+}
+
+// updateHighestAttestation raises the highest source and target epochs of the given share
+func (s *signerStorage) updateHighestAttestation(pubKey []byte, attestation *spec.AttestationData) error {
+	highest, err := s.retrieveHighestAttestation(pubKey)
+	if err != nil {
+		return err
+	}
+	if highest == nil {
+		return s.saveHighestAttestation(pubKey, attestation)
+	}
+
+	shouldUpdate := false
+	if highest.Source.Epoch < attestation.Source.Epoch {
+		highest.Source.Epoch = attestation.Source.Epoch
+		shouldUpdate = true
+	}
+	if highest.Target.Epoch < attestation.Target.Epoch {
+		highest.Target.Epoch = attestation.Target.Epoch
+		shouldUpdate = true
+	}
+	if !shouldUpdate {
+		return nil
+	}
+	return s.saveHighestAttestation(pubKey, highest)
+}
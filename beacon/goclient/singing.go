@@ -1,6 +1,8 @@
 package goclient
 
 import (
+	"fmt"
+
 	eth2client "github.com/attestantio/go-eth2-client"
 	phase0spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
@@ -23,57 +25,59 @@ func (gc *goClient) getSigningRoot(data *phase0spec.AttestationData) ([32]byte,
 	return root, nil
 }
 
-// getSigningRoot returns signing root
+// GetDomain returns the attester domain for the given attestation data
 func (gc *goClient) GetDomain(data *phase0spec.AttestationData) ([]byte, error) {
 	epoch := gc.network.EstimatedEpochAtSlot(types.Slot(data.Slot))
-	domainType, err := gc.getDomainType(beacon.RoleTypeAttester)
+	return gc.GetEpochDomain(beacon.DomainBeaconAttester, phase0spec.Epoch(epoch))
+}
+
+// GetEpochDomain returns the domain of the given type at the given epoch
+func (gc *goClient) GetEpochDomain(domainType beacon.DomainType, epoch phase0spec.Epoch) ([]byte, error) {
+	specDomainType, err := gc.getDomainType(domainType)
 	if err != nil {
 		return nil, err
 	}
-	domain, err := gc.getDomainData(domainType, phase0spec.Epoch(epoch))
+	domain, err := gc.getDomainData(specDomainType, epoch)
 	if err != nil {
 		return nil, err
 	}
 	return domain[:], nil
 }
 
-// getDomainType returns domain type by role type
-func (gc *goClient) getDomainType(roleType beacon.RoleType) (*phase0spec.DomainType, error) {
-	if provider, isProvider := gc.client.(eth2client.SpecProvider); isProvider {
+// getDomainType returns the spec domain type by its name
+func (gc *goClient) getDomainType(domainType beacon.DomainType) (*phase0spec.DomainType, error) {
+	if provider, isProvider := gc.client().(eth2client.SpecProvider); isProvider {
 		spec, err := provider.Spec(gc.ctx)
 		if err != nil {
 			return nil, err
 		}
-		var val interface{}
-		var exists bool
-		switch roleType {
-		case beacon.RoleTypeAttester:
-			val, exists = spec["DOMAIN_BEACON_ATTESTER"]
-		case beacon.RoleTypeAggregator:
-			val, exists = spec["DOMAIN_AGGREGATE_AND_PROOF"]
-		case beacon.RoleTypeProposer:
-			val, exists = spec["DOMAIN_BEACON_PROPOSER"]
-		default:
-			return nil, errors.New("role type domain is not implemented")
-		}
-
+		val, exists := spec[string(domainType)]
 		if !exists {
-			return nil, errors.New("spec type is missing")
+			return nil, errors.Errorf("spec type %s is missing", domainType)
+		}
+		res, ok := val.(phase0spec.DomainType)
+		if !ok {
+			return nil, errors.Errorf("spec type %s is not a domain type", domainType)
 		}
-		res := val.(phase0spec.DomainType)
 		return &res, nil
 	}
-	return nil, errors.New("client does not support BeaconAttesterDomainProvider")
+	return nil, errors.New("client does not support SpecProvider")
 }
 
-// getDomainData return domain data by domain type
-func (gc *goClient) getDomainData(domainType *phase0spec.DomainType, epoch phase0spec.Epoch) (*phase0spec.Domain, error) { // TODO need to add cache (?)
-	if provider, isProvider := gc.client.(eth2client.DomainProvider); isProvider {
-		attestationData, err := provider.Domain(gc.ctx, *domainType, epoch)
+// getDomainData return domain data by domain type, domains are cached as they are also used for validating network messages
+func (gc *goClient) getDomainData(domainType *phase0spec.DomainType, epoch phase0spec.Epoch) (*phase0spec.Domain, error) {
+	cacheKey := fmt.Sprintf("%x_%d", domainType[:], epoch)
+	if raw, exist := gc.domains.Get(cacheKey); exist {
+		domain := raw.(phase0spec.Domain)
+		return &domain, nil
+	}
+	if provider, isProvider := gc.client().(eth2client.DomainProvider); isProvider {
+		domain, err := provider.Domain(gc.ctx, *domainType, epoch)
 		if err != nil {
 			return nil, err
 		}
-		return &attestationData, nil
+		gc.domains.SetDefault(cacheKey, domain)
+		return &domain, nil
 	}
 	return nil, errors.New("client does not support DomainProvider")
 }
@@ -117,3 +121,23 @@ func (gc *goClient) signingData(rootFunc func() ([32]byte, error), domain []byte
 	}
 	return container.HashTreeRoot()
 }
+
+// GetForkInfo returns the fork of the head state and the genesis validators root of the chain
+func (gc *goClient) GetForkInfo() (*phase0spec.Fork, phase0spec.Root, error) {
+	genesisProvider, isProvider := gc.client().(eth2client.GenesisProvider)
+	if !isProvider {
+		return nil, phase0spec.Root{}, errors.New("client does not support GenesisProvider")
+	}
+	genesis, err := genesisProvider.Genesis(gc.ctx)
+	if err != nil {
+		return nil, phase0spec.Root{}, errors.Wrap(err, "failed to get genesis")
+	}
+	if provider, isProvider := gc.client().(eth2client.ForkProvider); isProvider {
+		fork, err := provider.Fork(gc.ctx, "head")
+		if err != nil {
+			return nil, phase0spec.Root{}, errors.Wrap(err, "failed to get fork")
+		}
+		return fork, genesis.GenesisValidatorsRoot, nil
+	}
+	return nil, phase0spec.Root{}, errors.New("client does not support ForkProvider")
+}
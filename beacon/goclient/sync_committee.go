@@ -0,0 +1,184 @@
+package goclient
+
+import (
+	client "github.com/attestantio/go-eth2-client"
+	eth2client "github.com/attestantio/go-eth2-client"
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/pkg/errors"
+)
+
+// syncCommitteePeriodDuties holds the sync committee membership of a sync period for a set of validators
+type syncCommitteePeriodDuties struct {
+	period  uint64
+	indices map[spec.ValidatorIndex]bool
+	duties  []*api.SyncCommitteeDuty
+}
+
+// covers returns true if the membership of all the given validators in the given period is known
+func (d *syncCommitteePeriodDuties) covers(period uint64, validatorIndices []spec.ValidatorIndex) bool {
+	if d == nil || d.period != period {
+		return false
+	}
+	for _, index := range validatorIndices {
+		if !d.indices[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// getSyncCommitteeDuties returns sync committee and contribution duties for every slot of the given epoch,
+// membership is fetched once per sync committee period
+func (gc *goClient) getSyncCommitteeDuties(c client.Service, epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	altairEpoch, err := gc.altairForkEpoch()
+	if err != nil {
+		return nil, err
+	}
+	if altairEpoch == nil || epoch < *altairEpoch {
+		return nil, nil
+	}
+
+	syncDuties, err := gc.syncCommitteeMembership(c, epoch, validatorIndices)
+	if err != nil {
+		return nil, err
+	}
+
+	var duties []*beacon.Duty
+	firstSlot := uint64(epoch) * gc.network.SlotsPerEpoch()
+	for _, syncDuty := range syncDuties {
+		for slot := firstSlot; slot < firstSlot+gc.network.SlotsPerEpoch(); slot++ {
+			for _, role := range []beacon.RoleType{beacon.RoleTypeSyncCommittee, beacon.RoleTypeSyncCommitteeContribution} {
+				duties = append(duties, &beacon.Duty{
+					Type:                          role,
+					PubKey:                        syncDuty.PubKey,
+					Slot:                          spec.Slot(slot),
+					ValidatorIndex:                syncDuty.ValidatorIndex,
+					ValidatorSyncCommitteeIndices: syncDuty.ValidatorSyncCommitteeIndices,
+				})
+			}
+		}
+	}
+	return duties, nil
+}
+
+// syncCommitteeMembership returns the sync committee duties of the given validators in the period of the given epoch
+func (gc *goClient) syncCommitteeMembership(c client.Service, epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*api.SyncCommitteeDuty, error) {
+	gc.syncCommitteeDutiesLock.Lock()
+	defer gc.syncCommitteeDutiesLock.Unlock()
+
+	period := beacon.SyncCommitteePeriod(epoch)
+	if !gc.syncCommitteeDuties.covers(period, validatorIndices) {
+		provider, isProvider := c.(eth2client.SyncCommitteeDutiesProvider)
+		if !isProvider {
+			return nil, errors.New("client does not support SyncCommitteeDutiesProvider")
+		}
+		syncDuties, err := provider.SyncCommitteeDuties(gc.ctx, epoch, validatorIndices)
+		if err != nil {
+			return nil, err
+		}
+		indices := make(map[spec.ValidatorIndex]bool, len(validatorIndices))
+		for _, index := range validatorIndices {
+			indices[index] = true
+		}
+		gc.syncCommitteeDuties = &syncCommitteePeriodDuties{
+			period:  period,
+			indices: indices,
+			duties:  syncDuties,
+		}
+	}
+
+	var ret []*api.SyncCommitteeDuty
+	for _, index := range validatorIndices {
+		for _, syncDuty := range gc.syncCommitteeDuties.duties {
+			if syncDuty.ValidatorIndex == index {
+				ret = append(ret, syncDuty)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// altairForkEpoch returns the altair fork epoch as configured in the beacon node, nil if unknown
+func (gc *goClient) altairForkEpoch() (*spec.Epoch, error) {
+	if provider, isProvider := gc.client().(eth2client.SpecProvider); isProvider {
+		specValues, err := provider.Spec(gc.ctx)
+		if err != nil {
+			return nil, err
+		}
+		val, exists := specValues["ALTAIR_FORK_EPOCH"]
+		if !exists {
+			return nil, nil
+		}
+		epoch, ok := val.(uint64)
+		if !ok {
+			return nil, errors.New("spec type ALTAIR_FORK_EPOCH is not an epoch")
+		}
+		res := spec.Epoch(epoch)
+		return &res, nil
+	}
+	return nil, errors.New("client does not support SpecProvider")
+}
+
+// SubscribeToSyncCommitteeSubnet implements Beacon interface
+func (gc *goClient) SubscribeToSyncCommitteeSubnet(subscriptions []*api.SyncCommitteeSubscription) error {
+	if provider, isProvider := gc.client().(eth2client.SyncCommitteeSubscriptionsSubmitter); isProvider {
+		if err := provider.SubmitSyncCommitteeSubscriptions(gc.ctx, subscriptions); err != nil {
+			return err
+		}
+		return nil
+	}
+	return errors.New("client does not support SyncCommitteeSubscriptionsSubmitter")
+}
+
+// GetSyncCommitteeBlockRoot implements Beacon interface, waits until one-third of the slot has transpired
+func (gc *goClient) GetSyncCommitteeBlockRoot(slot spec.Slot) (*spec.Root, error) {
+	gc.waitOneThirdOrValidBlock(uint64(slot))
+
+	if provider, isProvider := gc.client().(eth2client.BeaconBlockRootProvider); isProvider {
+		root, err := provider.BeaconBlockRoot(gc.ctx, "head")
+		if err != nil {
+			return nil, err
+		}
+		if root == nil {
+			return nil, errors.New("received an empty beacon block root")
+		}
+		return root, nil
+	}
+	return nil, errors.New("client does not support BeaconBlockRootProvider")
+}
+
+// SubmitSyncCommitteeMessage implements Beacon interface
+func (gc *goClient) SubmitSyncCommitteeMessage(msg *altair.SyncCommitteeMessage) error {
+	if provider, isProvider := gc.client().(eth2client.SyncCommitteeMessagesSubmitter); isProvider {
+		return provider.SubmitSyncCommitteeMessages(gc.ctx, []*altair.SyncCommitteeMessage{msg})
+	}
+	return errors.New("client does not support SyncCommitteeMessagesSubmitter")
+}
+
+// GetSyncCommitteeContribution implements Beacon interface, waits until two-third of the slot has transpired
+func (gc *goClient) GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error) {
+	gc.waitToSlotTwoThirds(uint64(slot))
+
+	if provider, isProvider := gc.client().(eth2client.SyncCommitteeContributionProvider); isProvider {
+		contribution, err := provider.SyncCommitteeContribution(gc.ctx, slot, subcommitteeIndex, blockRoot)
+		if err != nil {
+			return nil, err
+		}
+		if contribution == nil {
+			return nil, errors.New("received an empty sync committee contribution")
+		}
+		return contribution, nil
+	}
+	return nil, errors.New("client does not support SyncCommitteeContributionProvider")
+}
+
+// SubmitSignedContributionAndProof implements Beacon interface
+func (gc *goClient) SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error {
+	if provider, isProvider := gc.client().(eth2client.SyncCommitteeContributionsSubmitter); isProvider {
+		return provider.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{msg})
+	}
+	return errors.New("client does not support SyncCommitteeContributionsSubmitter")
+}
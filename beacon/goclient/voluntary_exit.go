@@ -0,0 +1,18 @@
+package goclient
+
+import (
+	client "github.com/attestantio/go-eth2-client"
+	eth2client "github.com/attestantio/go-eth2-client"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SubmitVoluntaryExit implements Beacon interface
+func (gc *goClient) SubmitVoluntaryExit(exit *spec.SignedVoluntaryExit) error {
+	return gc.nodes.withFailover("SubmitVoluntaryExit", func(c client.Service) error {
+		if provider, isProvider := c.(eth2client.VoluntaryExitSubmitter); isProvider {
+			return provider.SubmitVoluntaryExit(gc.ctx, exit)
+		}
+		return errors.New("client does not support VoluntaryExitSubmitter")
+	})
+}
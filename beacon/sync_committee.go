@@ -0,0 +1,58 @@
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	// SyncCommitteeSize is the number of validators in a sync committee
+	SyncCommitteeSize = 512
+	// SyncCommitteeSubnetCount is the number of sync committee subnets (subcommittees)
+	SyncCommitteeSubnetCount = 4
+	// TargetAggregatorsPerSyncSubcommittee is the target number of aggregators in each sync subcommittee
+	TargetAggregatorsPerSyncSubcommittee = 16
+	// EpochsPerSyncCommitteePeriod is the number of epochs a sync committee serves
+	EpochsPerSyncCommitteePeriod = 256
+)
+
+// SyncCommitteePeriod returns the sync committee period of the given epoch
+func SyncCommitteePeriod(epoch spec.Epoch) uint64 {
+	return uint64(epoch) / EpochsPerSyncCommitteePeriod
+}
+
+// SyncSubcommitteeIndex returns the subcommittee (subnet) of the given index in the sync committee
+func SyncSubcommitteeIndex(syncCommitteeIndex spec.CommitteeIndex) uint64 {
+	return uint64(syncCommitteeIndex) / (SyncCommitteeSize / SyncCommitteeSubnetCount)
+}
+
+// SyncSubcommitteeIndices returns the distinct subcommittees of the given sync committee indices, in order of appearance
+func SyncSubcommitteeIndices(syncCommitteeIndices []spec.CommitteeIndex) []uint64 {
+	var ret []uint64
+	seen := make(map[uint64]bool)
+	for _, index := range syncCommitteeIndices {
+		subcommittee := SyncSubcommitteeIndex(index)
+		if !seen[subcommittee] {
+			seen[subcommittee] = true
+			ret = append(ret, subcommittee)
+		}
+	}
+	return ret
+}
+
+// IsSyncCommitteeAggregator returns true if the given sync committee selection proof selects the validator as a contribution aggregator
+//
+// Spec pseudocode definition:
+//   def is_sync_committee_aggregator(signature: BLSSignature) -> bool:
+//    modulo = max(1, SYNC_COMMITTEE_SIZE // SYNC_COMMITTEE_SUBNET_COUNT // TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE)
+//    return bytes_to_uint64(hash(signature)[0:8]) % modulo == 0
+func IsSyncCommitteeAggregator(sig []byte) bool {
+	modulo := uint64(SyncCommitteeSize / SyncCommitteeSubnetCount / TargetAggregatorsPerSyncSubcommittee)
+	if modulo < 1 {
+		modulo = 1
+	}
+	h := sha256.Sum256(sig)
+	return binary.LittleEndian.Uint64(h[:8])%modulo == 0
+}
@@ -0,0 +1,54 @@
+package beacon
+
+import (
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIsSyncCommitteeAggregator(t *testing.T) {
+	selectedSig := make([]byte, 96)
+	selectedSig[0] = 1
+
+	tests := []struct {
+		name     string
+		sig      []byte
+		expected bool
+	}{
+		// sha256 of 96 zero bytes starts with 0x2e 0xa9..., modulo 8 (512 / 4 / 16) is 6
+		{"not selected", make([]byte, 96), false},
+		{"selected", selectedSig, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, IsSyncCommitteeAggregator(test.sig))
+		})
+	}
+}
+
+func TestSyncSubcommitteeIndices(t *testing.T) {
+	tests := []struct {
+		name     string
+		indices  []spec.CommitteeIndex
+		expected []uint64
+	}{
+		{"no indices", nil, nil},
+		{"first subcommittee", []spec.CommitteeIndex{0, 127}, []uint64{0}},
+		{"last subcommittee", []spec.CommitteeIndex{511}, []uint64{3}},
+		{"multiple subcommittees", []spec.CommitteeIndex{300, 5, 260}, []uint64{2, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, SyncSubcommitteeIndices(test.indices))
+		})
+	}
+}
+
+func TestSyncCommitteePeriod(t *testing.T) {
+	require.EqualValues(t, 0, SyncCommitteePeriod(0))
+	require.EqualValues(t, 0, SyncCommitteePeriod(EpochsPerSyncCommitteePeriod-1))
+	require.EqualValues(t, 1, SyncCommitteePeriod(EpochsPerSyncCommitteePeriod))
+	require.EqualValues(t, 2, SyncCommitteePeriod(2*EpochsPerSyncCommitteePeriod+5))
+}
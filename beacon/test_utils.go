@@ -2,6 +2,8 @@ package beacon
 
 import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/ibft/proto"
 	"github.com/herumi/bls-eth-go-binary/bls"
@@ -40,6 +42,26 @@ func (m *mockBeacon) GetDuties(epoch spec.Epoch, validatorIndices []spec.Validat
 	return m.dutiesResults[uint64(epoch)], nil
 }
 
+func (m *mockBeacon) GetLookaheadDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*Duty, error) {
+	var duties []*Duty
+	for _, duty := range m.dutiesResults[uint64(epoch)] {
+		if duty.Type != RoleTypeProposer {
+			duties = append(duties, duty)
+		}
+	}
+	return duties, nil
+}
+
+func (m *mockBeacon) GetProposerDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*Duty, error) {
+	var duties []*Duty
+	for _, duty := range m.dutiesResults[uint64(epoch)] {
+		if duty.Type == RoleTypeProposer {
+			duties = append(duties, duty)
+		}
+	}
+	return duties, nil
+}
+
 func (m *mockBeacon) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*v1.Validator, error) {
 	results := map[spec.ValidatorIndex]*v1.Validator{}
 	for _, pk := range validatorPubKeys {
@@ -66,10 +88,92 @@ func (m *mockBeacon) SubscribeToCommitteeSubnet(subscription []*v1.BeaconCommitt
 	return nil
 }
 
+func (m *mockBeacon) GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*eth2spec.VersionedBeaconBlock, error) {
+	return nil, nil
+}
+
+func (m *mockBeacon) SubmitBeaconBlock(block *eth2spec.VersionedSignedBeaconBlock) error {
+	return nil
+}
+
+func (m *mockBeacon) SignRandaoReveal(duty *Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SignBeaconBlock(block *eth2spec.VersionedBeaconBlock, duty *Duty, pk []byte) (*eth2spec.VersionedSignedBeaconBlock, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex, attestationDataRoot *spec.Root) (*spec.Attestation, error) {
+	return nil, nil
+}
+
+func (m *mockBeacon) SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error {
+	return nil
+}
+
+func (m *mockBeacon) SignSelectionProof(duty *Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SubscribeToHeadEvents(handler func(event *v1.HeadEvent)) {}
+
+func (m *mockBeacon) SubscribeToSyncCommitteeSubnet(subscriptions []*v1.SyncCommitteeSubscription) error {
+	return nil
+}
+
+func (m *mockBeacon) GetSyncCommitteeBlockRoot(slot spec.Slot) (*spec.Root, error) {
+	return nil, nil
+}
+
+func (m *mockBeacon) SubmitSyncCommitteeMessage(msg *altair.SyncCommitteeMessage) error {
+	return nil
+}
+
+func (m *mockBeacon) GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error) {
+	return nil, nil
+}
+
+func (m *mockBeacon) SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error {
+	return nil
+}
+
+func (m *mockBeacon) SignSyncCommitteeMessage(blockRoot spec.Root, duty *Duty, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SignSyncCommitteeSelectionProof(subcommitteeIndex uint64, duty *Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SignContributionAndProof(msg *altair.ContributionAndProof, duty *Duty, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (m *mockBeacon) SubmitVoluntaryExit(exit *spec.SignedVoluntaryExit) error {
+	return nil
+}
+
+func (m *mockBeacon) SignVoluntaryExit(duty *Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
 func (m *mockBeacon) AddShare(shareKey *bls.SecretKey) error {
 	return nil
 }
 
+func (m *mockBeacon) RemoveShare(pubKey string) error {
+	return nil
+}
+
+func (m *mockBeacon) RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error) {
+	return nil, nil
+}
+
 func (m *mockBeacon) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	return nil, nil
 }
@@ -77,6 +181,9 @@ func (m *mockBeacon) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte,
 func (m *mockBeacon) GetDomain(data *spec.AttestationData) ([]byte, error) {
 	panic("implement")
 }
+func (m *mockBeacon) GetEpochDomain(domainType DomainType, epoch spec.Epoch) ([]byte, error) {
+	panic("implement")
+}
 func (m *mockBeacon) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
 	panic("implement")
 }
@@ -14,6 +14,12 @@ func (r RoleType) String() string {
 		return "AGGREGATOR"
 	case RoleTypeProposer:
 		return "PROPOSER"
+	case RoleTypeSyncCommittee:
+		return "SYNC_COMMITTEE"
+	case RoleTypeSyncCommitteeContribution:
+		return "SYNC_COMMITTEE_CONTRIBUTION"
+	case RoleTypeVoluntaryExit:
+		return "VOLUNTARY_EXIT"
 	default:
 		return "UNDEFINED"
 	}
@@ -25,4 +31,23 @@ const (
 	RoleTypeAttester
 	RoleTypeAggregator
 	RoleTypeProposer
+	RoleTypeSyncCommittee
+	RoleTypeSyncCommitteeContribution
+	RoleTypeVoluntaryExit
+)
+
+// DomainType is the name of a beacon chain signature domain, as exposed by the beacon node spec
+type DomainType string
+
+// List of domain types
+const (
+	DomainBeaconProposer              DomainType = "DOMAIN_BEACON_PROPOSER"
+	DomainBeaconAttester              DomainType = "DOMAIN_BEACON_ATTESTER"
+	DomainRandao                      DomainType = "DOMAIN_RANDAO"
+	DomainAggregateAndProof           DomainType = "DOMAIN_AGGREGATE_AND_PROOF"
+	DomainSelectionProof              DomainType = "DOMAIN_SELECTION_PROOF"
+	DomainSyncCommittee               DomainType = "DOMAIN_SYNC_COMMITTEE"
+	DomainSyncCommitteeSelectionProof DomainType = "DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF"
+	DomainContributionAndProof        DomainType = "DOMAIN_CONTRIBUTION_AND_PROOF"
+	DomainVoluntaryExit               DomainType = "DOMAIN_VOLUNTARY_EXIT"
 )
@@ -1,8 +1,7 @@
 package valcheck
 
 import (
-	"encoding/json"
-	"github.com/bloxapp/ssv/ibft/proto"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
 
@@ -12,16 +11,14 @@ type AggregatorValueCheck struct {
 
 // Check returns error if value is invalid
 func (v *AggregatorValueCheck) Check(value []byte) error {
-	// try and parse to attestation data
-	inputValue := &proto.InputValue_Aggregation{}
-	if err := json.Unmarshal(value, &inputValue); err != nil {
-		return errors.Wrap(err, "could not parse input value storing attestation data")
+	// try and parse to aggregate and proof
+	inputValue := &spec.AggregateAndProof{}
+	if err := inputValue.UnmarshalSSZ(value); err != nil {
+		return errors.Wrap(err, "could not parse input value storing aggregate and proof")
 	}
 
-	//if inputValue.Aggregation.Message.Aggregate.Data.Slot == 0 {
-	//	return errors.New("this is an example test error")
-	//}
-
-	// TODO - test for slashing protection
+	if inputValue.Aggregate == nil || inputValue.Aggregate.Data == nil {
+		return errors.New("missing aggregate attestation data")
+	}
 	return nil
 }
@@ -7,6 +7,8 @@ import (
 
 // AttestationValueCheck checks for an Attestation type value
 type AttestationValueCheck struct {
+	records HighestAttestationProvider
+	pk      []byte
 }
 
 // Check returns error if value is invalid
@@ -16,11 +18,40 @@ func (v *AttestationValueCheck) Check(value []byte) error {
 	if err := inputValue.UnmarshalSSZ(value); err != nil {
 		return errors.Wrap(err, "could not parse input value storing attestation data")
 	}
+	if inputValue.Source == nil || inputValue.Target == nil {
+		return errors.New("missing attestation checkpoints")
+	}
+	if inputValue.Source.Epoch > inputValue.Target.Epoch {
+		return errors.Errorf("source epoch %d is higher than target epoch %d", inputValue.Source.Epoch, inputValue.Target.Epoch)
+	}
 
-	if inputValue.Slot == 100 {
-		return errors.New("TEST - failed on slot 100")
+	highest, err := v.records.RetrieveHighestAttestation(v.pk)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve highest attestation")
+	}
+	if highest == nil || highest.Source == nil || highest.Target == nil {
+		return errors.New("highest attestation data is nil, can't determine if attestation is slashable")
 	}
+	return isSlashableAttestation(inputValue, highest)
+}
+
+// isSlashableAttestation returns an error if the given attestation data can't be signed on top of the highest known attestation,
+// the signer keeps only the highest source and target epochs so any vote which is not strictly newer is refused.
+func isSlashableAttestation(data *spec.AttestationData, highest *spec.AttestationData) error {
+	source, target := data.Source.Epoch, data.Target.Epoch
+	highestSource, highestTarget := highest.Source.Epoch, highest.Target.Epoch
 
-	// TODO - test for slashing protection
+	switch {
+	case target == highestTarget:
+		return errors.Errorf("slashable attestation: double vote on target epoch %d", target)
+	case source < highestSource && target > highestTarget:
+		return errors.Errorf("slashable attestation: surrounding vote (source %d, target %d) of highest attestation (source %d, target %d)",
+			source, target, highestSource, highestTarget)
+	case source > highestSource && target < highestTarget:
+		return errors.Errorf("slashable attestation: surrounded vote (source %d, target %d) by highest attestation (source %d, target %d)",
+			source, target, highestSource, highestTarget)
+	case target < highestTarget:
+		return errors.Errorf("slashable attestation: target epoch %d is lower than highest target epoch %d", target, highestTarget)
+	}
 	return nil
 }
@@ -0,0 +1,167 @@
+package valcheck
+
+import (
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testHighestAttestationProvider struct {
+	highest *spec.AttestationData
+	err     error
+}
+
+func (p *testHighestAttestationProvider) RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error) {
+	return p.highest, p.err
+}
+
+func testAttestationData(source, target spec.Epoch) *spec.AttestationData {
+	return &spec.AttestationData{
+		Slot:            spec.Slot(target * 32),
+		Index:           1,
+		BeaconBlockRoot: [32]byte{1, 2, 3, 4},
+		Source:          &spec.Checkpoint{Epoch: source, Root: [32]byte{}},
+		Target:          &spec.Checkpoint{Epoch: target, Root: [32]byte{}},
+	}
+}
+
+func TestAttestationValueCheck_Check(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         *spec.AttestationData
+		rawValue      []byte
+		highest       *spec.AttestationData
+		providerErr   error
+		expectedError string
+	}{
+		{
+			"valid, first attestation",
+			testAttestationData(0, 1),
+			nil,
+			testAttestationData(0, 0),
+			nil,
+			"",
+		},
+		{
+			"valid, higher source and target",
+			testAttestationData(3, 4),
+			nil,
+			testAttestationData(2, 3),
+			nil,
+			"",
+		},
+		{
+			"valid, same source higher target",
+			testAttestationData(2, 5),
+			nil,
+			testAttestationData(2, 3),
+			nil,
+			"",
+		},
+		{
+			"double vote",
+			testAttestationData(2, 3),
+			nil,
+			testAttestationData(2, 3),
+			nil,
+			"slashable attestation: double vote on target epoch 3",
+		},
+		{
+			"double vote, different source",
+			testAttestationData(1, 3),
+			nil,
+			testAttestationData(2, 3),
+			nil,
+			"slashable attestation: double vote on target epoch 3",
+		},
+		{
+			"surrounding vote",
+			testAttestationData(1, 5),
+			nil,
+			testAttestationData(2, 3),
+			nil,
+			"slashable attestation: surrounding vote (source 1, target 5) of highest attestation (source 2, target 3)",
+		},
+		{
+			"surrounded vote",
+			testAttestationData(3, 4),
+			nil,
+			testAttestationData(2, 5),
+			nil,
+			"slashable attestation: surrounded vote (source 3, target 4) by highest attestation (source 2, target 5)",
+		},
+		{
+			"lower target, same source",
+			testAttestationData(2, 4),
+			nil,
+			testAttestationData(2, 5),
+			nil,
+			"slashable attestation: target epoch 4 is lower than highest target epoch 5",
+		},
+		{
+			"lower source and target",
+			testAttestationData(1, 4),
+			nil,
+			testAttestationData(2, 5),
+			nil,
+			"slashable attestation: target epoch 4 is lower than highest target epoch 5",
+		},
+		{
+			"source higher than target",
+			testAttestationData(5, 4),
+			nil,
+			testAttestationData(0, 0),
+			nil,
+			"source epoch 5 is higher than target epoch 4",
+		},
+		{
+			"nil highest attestation",
+			testAttestationData(0, 1),
+			nil,
+			nil,
+			nil,
+			"highest attestation data is nil, can't determine if attestation is slashable",
+		},
+		{
+			"provider error",
+			testAttestationData(0, 1),
+			nil,
+			nil,
+			errors.New("test error"),
+			"could not retrieve highest attestation: test error",
+		},
+		{
+			"invalid ssz",
+			nil,
+			[]byte{1, 2, 3, 4},
+			testAttestationData(0, 0),
+			nil,
+			"could not parse input value storing attestation data: incorrect size",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := New(&testHighestAttestationProvider{
+				highest: test.highest,
+				err:     test.providerErr,
+			}).AttestationSlashingProtector([]byte{1, 2, 3, 4})
+
+			value := test.rawValue
+			if test.value != nil {
+				byts, err := test.value.MarshalSSZ()
+				require.NoError(t, err)
+				value = byts
+			}
+
+			err := check.Check(value)
+			if len(test.expectedError) > 0 {
+				require.EqualError(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
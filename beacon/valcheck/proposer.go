@@ -2,26 +2,35 @@ package valcheck
 
 import (
 	"encoding/json"
-	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/pkg/errors"
 )
 
-// ProposerValueCheck checks for an Aggregator type value
+// ProposerValueCheck checks for a Proposer type value
 type ProposerValueCheck struct {
 }
 
 // Check returns error if value is invalid
 func (v *ProposerValueCheck) Check(value []byte) error {
-	// try and parse to attestation data
-	inputValue := &proto.InputValue_Block{}
-	if err := json.Unmarshal(value, &inputValue); err != nil {
-		return errors.Wrap(err, "could not parse input value storing attestation data")
+	// try and parse to beacon block
+	inputValue := &spec.VersionedBeaconBlock{}
+	if err := json.Unmarshal(value, inputValue); err != nil {
+		return errors.Wrap(err, "could not parse input value storing beacon block")
 	}
 
-	//if inputValue.Block.Block.Slot == 0 {
-	//	return errors.New("this is an example test error")
-	//}
+	switch inputValue.Version {
+	case spec.DataVersionPhase0:
+		if inputValue.Phase0 == nil || inputValue.Phase0.Body == nil {
+			return errors.New("missing phase0 beacon block")
+		}
+	case spec.DataVersionAltair:
+		if inputValue.Altair == nil || inputValue.Altair.Body == nil {
+			return errors.New("missing altair beacon block")
+		}
+	default:
+		return errors.Errorf("unsupported beacon block version %d", inputValue.Version)
+	}
 
-	// TODO - test for slashing protection
+	// proposal slashing protection is done by the signer, based on the highest proposal
 	return nil
 }
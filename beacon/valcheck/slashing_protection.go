@@ -1,17 +1,33 @@
 package valcheck
 
+import (
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// HighestAttestationProvider provides the highest attestation signed by a share, as recorded by the signer
+type HighestAttestationProvider interface {
+	// RetrieveHighestAttestation returns the highest attestation signed by the given share public key
+	RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error)
+}
+
 // SlashingProtection is a controller for different types of ethereum value and slashing protection instances
 type SlashingProtection struct {
+	attestations HighestAttestationProvider
 }
 
 // New returns a new instance of slashing protection
-func New() *SlashingProtection {
-	return &SlashingProtection{}
+func New(attestations HighestAttestationProvider) *SlashingProtection {
+	return &SlashingProtection{
+		attestations: attestations,
+	}
 }
 
-// AttestationSlashingProtector returns an attestation slashing protection value check
-func (sp *SlashingProtection) AttestationSlashingProtector() *AttestationValueCheck {
-	return &AttestationValueCheck{}
+// AttestationSlashingProtector returns an attestation slashing protection value check for the given share public key
+func (sp *SlashingProtection) AttestationSlashingProtector(pk []byte) *AttestationValueCheck {
+	return &AttestationValueCheck{
+		records: sp.attestations,
+		pk:      pk,
+	}
 }
 
 // ProposalSlashingProtector returns a proposal slashing protection value check
@@ -23,3 +39,13 @@ func (sp *SlashingProtection) ProposalSlashingProtector() *ProposerValueCheck {
 func (sp *SlashingProtection) AggregationValidation() *AggregatorValueCheck {
 	return &AggregatorValueCheck{}
 }
+
+// SyncCommitteeValidation returns a sync committee message value check
+func (sp *SlashingProtection) SyncCommitteeValidation() *SyncCommitteeValueCheck {
+	return &SyncCommitteeValueCheck{}
+}
+
+// SyncCommitteeContributionValidation returns a sync committee contribution value check
+func (sp *SlashingProtection) SyncCommitteeContributionValidation() *SyncCommitteeContributionValueCheck {
+	return &SyncCommitteeContributionValueCheck{}
+}
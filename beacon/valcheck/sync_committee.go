@@ -0,0 +1,37 @@
+package valcheck
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// SyncCommitteeValueCheck checks for a sync committee message type value (beacon block root)
+type SyncCommitteeValueCheck struct {
+}
+
+// Check returns error if value is invalid
+func (v *SyncCommitteeValueCheck) Check(value []byte) error {
+	if len(value) != len(spec.Root{}) {
+		return errors.Errorf("sync committee block root has an invalid length %d", len(value))
+	}
+	return nil
+}
+
+// SyncCommitteeContributionValueCheck checks for a sync committee contribution type value
+type SyncCommitteeContributionValueCheck struct {
+}
+
+// Check returns error if value is invalid
+func (v *SyncCommitteeContributionValueCheck) Check(value []byte) error {
+	// try and parse to contribution and proof
+	inputValue := &altair.ContributionAndProof{}
+	if err := inputValue.UnmarshalSSZ(value); err != nil {
+		return errors.Wrap(err, "could not parse input value storing contribution and proof")
+	}
+
+	if inputValue.Contribution == nil {
+		return errors.New("missing sync committee contribution")
+	}
+	return nil
+}
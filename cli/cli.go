@@ -35,4 +35,11 @@ func init() {
 	RootCmd.AddCommand(bootnode.StartBootNodeCmd)
 	RootCmd.AddCommand(exporter.StartExporterNodeCmd)
 	RootCmd.AddCommand(operator.StartNodeCmd)
+	RootCmd.AddCommand(operator.ExportSlashingProtectionCmd)
+	RootCmd.AddCommand(operator.ImportSlashingProtectionCmd)
+	RootCmd.AddCommand(operator.ListPeersReputationCmd)
+	RootCmd.AddCommand(operator.BanPeerCmd)
+	RootCmd.AddCommand(operator.UnbanPeerCmd)
+	RootCmd.AddCommand(operator.ListVoluntaryExitsCmd)
+	RootCmd.AddCommand(operator.ApproveVoluntaryExitCmd)
 }
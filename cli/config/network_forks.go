@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/bloxapp/ssv/network/forks"
+	networkForkV0 "github.com/bloxapp/ssv/network/forks/v0"
+	networkForkV1 "github.com/bloxapp/ssv/network/forks/v1"
+	networkForkV2 "github.com/bloxapp/ssv/network/forks/v2"
+)
+
+// NetworkForksConfig expose the slots of the network forks
+type NetworkForksConfig struct {
+	NetworkForkV1Slot uint64 `yaml:"NetworkForkV1Slot" env:"NETWORK_FORK_V1_SLOT" env-description:"slot from which network messages are encoded with protobuf and snappy, disabled if not set"`
+	NetworkForkV2Slot uint64 `yaml:"NetworkForkV2Slot" env:"NETWORK_FORK_V2_SLOT" env-description:"slot from which validators are mapped into subnet topics, disabled if not set"`
+	SubnetsCount      uint64 `yaml:"SubnetsCount" env:"SUBNETS_COUNT" env-default:"128" env-description:"number of subnets that validators are mapped into from network fork v2"`
+}
+
+// NetworkFork returns the network fork according to the configured slots,
+// each fork wraps the previous one which is used until its own slot
+func (c NetworkForksConfig) NetworkFork() forks.Fork {
+	var fork forks.Fork = networkForkV0.New()
+	if c.NetworkForkV1Slot > 0 {
+		fork = networkForkV1.New(c.NetworkForkV1Slot)
+	}
+	if c.NetworkForkV2Slot > 0 {
+		fork = networkForkV2.New(fork, c.NetworkForkV2Slot, c.SubnetsCount)
+	}
+	return fork
+}
@@ -1,8 +1,10 @@
 package exporter
 
 import (
+	"context"
 	"crypto/rsa"
 	"fmt"
+	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/beacon/goclient"
 	global_config "github.com/bloxapp/ssv/cli/config"
@@ -12,7 +14,7 @@ import (
 	"github.com/bloxapp/ssv/exporter/api"
 	"github.com/bloxapp/ssv/monitoring/metrics"
 	"github.com/bloxapp/ssv/network"
-	networkForkV0 "github.com/bloxapp/ssv/network/forks/v0"
+	networkForks "github.com/bloxapp/ssv/network/forks"
 	"github.com/bloxapp/ssv/network/p2p"
 	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
@@ -21,6 +23,7 @@ import (
 	"github.com/bloxapp/ssv/utils/logex"
 	"github.com/bloxapp/ssv/utils/migrationutils"
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/prysmaticlabs/prysm/time/slots"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"log"
@@ -30,10 +33,11 @@ import (
 
 type config struct {
 	global_config.GlobalConfig `yaml:"global"`
-	DBOptions                  basedb.Options `yaml:"db"`
-	P2pNetworkConfig           p2p.Config     `yaml:"p2p"`
-	ETH1Options                eth1.Options   `yaml:"eth1"`
-	ETH2Options                beacon.Options `yaml:"eth2"`
+	DBOptions                  basedb.Options                   `yaml:"db"`
+	P2pNetworkConfig           p2p.Config                       `yaml:"p2p"`
+	ETH1Options                eth1.Options                     `yaml:"eth1"`
+	ETH2Options                beacon.Options                   `yaml:"eth2"`
+	NetworkForks               global_config.NetworkForksConfig `yaml:"networkForks"`
 
 	WsAPIPort                       int           `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-default:"14000" env-description:"port of exporter WS api"`
 	MetricsAPIPort                  int           `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"port of metrics api"`
@@ -95,8 +99,15 @@ var StartExporterNodeCmd = &cobra.Command{
 			log.Fatal("Failed to get p2p privateKey", zap.Error(err))
 		}
 		cfg.P2pNetworkConfig.ReportLastMsg = true
+		cfg.P2pNetworkConfig.Reputation, err = p2p.NewReputationStore(db, Logger)
+		if err != nil {
+			Logger.Fatal("failed to create peers reputation store", zap.Error(err))
+		}
 		// TODO add fork interface for exporter or use the same forks as in operator
-		cfg.P2pNetworkConfig.Fork = networkForkV0.New()
+		networkFork := cfg.NetworkForks.NetworkFork()
+		// the exporter doesn't run duties, therefore the slots are ticked here for the network fork
+		go tickNetworkFork(cmd.Context(), core.NetworkFromString(cfg.ETH2Options.Network), networkFork)
+		cfg.P2pNetworkConfig.Fork = networkFork
 		cfg.P2pNetworkConfig.NodeType = p2p.Exporter
 		network, err := p2p.New(cmd.Context(), Logger, &cfg.P2pNetworkConfig)
 		if err != nil {
@@ -116,6 +127,7 @@ var StartExporterNodeCmd = &cobra.Command{
 			NodeAddr:             cfg.ETH1Options.ETH1Addr,
 			ContractABI:          eth1.ContractABI(),
 			ConnectionTimeout:    cfg.ETH1Options.ETH1ConnectionTimeout,
+			ConfirmationBlocks:   cfg.ETH1Options.ETH1ConfirmationBlocks,
 			RegistryContractAddr: cfg.ETH1Options.RegistryContractAddr,
 			// using an empty private key provider
 			// because the exporter doesn't run in the context of an operator
@@ -180,3 +192,18 @@ func startMetricsHandler(logger *zap.Logger, net network.Network, port int, enab
 		logger.Error("failed to start metrics handler", zap.Error(err))
 	}
 }
+
+// tickNetworkFork updates the network fork with the current slot until the context is done
+func tickNetworkFork(ctx context.Context, eth2Network core.Network, fork networkForks.Fork) {
+	genesisTime := time.Unix(int64(eth2Network.MinGenesisTime()), 0)
+	slotTicker := slots.NewSlotTicker(genesisTime, uint64(eth2Network.SlotDurationSec().Seconds()))
+	defer slotTicker.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case slot := <-slotTicker.C():
+			fork.SlotTick(uint64(slot))
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	peerIDFlag      = "peer-id"
+	banDurationFlag = "duration"
+)
+
+// AddPeerIDFlag adds the peer id flag to the command
+func AddPeerIDFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, peerIDFlag, "", "Peer ID", true)
+}
+
+// GetPeerIDFlagValue gets the peer id flag from the command
+func GetPeerIDFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(peerIDFlag)
+}
+
+// AddBanDurationFlag adds the ban duration flag to the command
+func AddBanDurationFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, banDurationFlag, "", "Duration of the ban (e.g. 24h), the peer is banned permanently if not provided", false)
+}
+
+// GetBanDurationFlagValue gets the ban duration flag from the command
+func GetBanDurationFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(banDurationFlag)
+}
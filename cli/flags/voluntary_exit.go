@@ -0,0 +1,62 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	adminAPIFlag           = "admin-api"
+	adminAPITokenFlag      = "admin-api-token"
+	validatorPublicKeyFlag = "public-key"
+	exitEpochFlag          = "epoch"
+)
+
+// AddAdminAPIFlag adds the admin api address flag to the command
+func AddAdminAPIFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, adminAPIFlag, "http://localhost:16000", "Address of the admin api of the node", false)
+}
+
+// GetAdminAPIFlagValue gets the admin api address flag from the command
+func GetAdminAPIFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(adminAPIFlag)
+}
+
+// AddAdminAPITokenFlag adds the admin api token flag to the command
+func AddAdminAPITokenFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, adminAPITokenFlag, "", "Token of the admin api of the node (AdminAPIToken)", true)
+}
+
+// GetAdminAPITokenFlagValue gets the admin api token flag from the command
+func GetAdminAPITokenFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(adminAPITokenFlag)
+}
+
+// AddValidatorPublicKeyFlag adds the validator public key flag to the command
+func AddValidatorPublicKeyFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, validatorPublicKeyFlag, "", "Validator public key (hex)", true)
+}
+
+// GetValidatorPublicKeyFlagValue gets the validator public key flag from the command
+func GetValidatorPublicKeyFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(validatorPublicKeyFlag)
+}
+
+// AddExitEpochFlag adds the voluntary exit epoch flag to the command
+func AddExitEpochFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, exitEpochFlag, 0, "Epoch of the voluntary exit, the current epoch is used if not provided", false)
+}
+
+// GetExitEpochFlagValue gets the voluntary exit epoch flag from the command, returns nil if the flag was not provided
+func GetExitEpochFlagValue(c *cobra.Command) (*uint64, error) {
+	if !c.Flags().Changed(exitEpochFlag) {
+		return nil, nil
+	}
+	epoch, err := c.Flags().GetUint64(exitEpochFlag)
+	if err != nil {
+		return nil, err
+	}
+	return &epoch, nil
+}
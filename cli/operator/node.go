@@ -11,7 +11,9 @@ import (
 	"github.com/bloxapp/ssv/monitoring/metrics"
 	"github.com/bloxapp/ssv/network/p2p"
 	"github.com/bloxapp/ssv/operator"
+	"github.com/bloxapp/ssv/operator/forks"
 	v0 "github.com/bloxapp/ssv/operator/forks/v0"
+	v1 "github.com/bloxapp/ssv/operator/forks/v1"
 	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils/commons"
@@ -27,14 +29,17 @@ import (
 
 type config struct {
 	global_config.GlobalConfig `yaml:"global"`
-	DBOptions                  basedb.Options   `yaml:"db"`
-	SSVOptions                 operator.Options `yaml:"ssv"`
-	ETH1Options                eth1.Options     `yaml:"eth1"`
-	ETH2Options                beacon.Options   `yaml:"eth2"`
-	P2pNetworkConfig           p2p.Config       `yaml:"p2p"`
+	DBOptions                  basedb.Options                   `yaml:"db"`
+	SSVOptions                 operator.Options                 `yaml:"ssv"`
+	ETH1Options                eth1.Options                     `yaml:"eth1"`
+	ETH2Options                beacon.Options                   `yaml:"eth2"`
+	P2pNetworkConfig           p2p.Config                       `yaml:"p2p"`
+	NetworkForks               global_config.NetworkForksConfig `yaml:"networkForks"`
 
 	OperatorPrivateKey string `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	MetricsAPIPort     int    `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"port of metrics api"`
+	AdminAPIAddr       string `yaml:"AdminAPIAddr" env:"ADMIN_API_ADDR" env-description:"address of admin api (e.g. localhost:16000) for approving voluntary exits, disabled if not set. requires AdminAPIToken"`
+	AdminAPIToken      string `yaml:"AdminAPIToken" env:"ADMIN_API_TOKEN" env-description:"token that authenticates the requests of the admin api, required if the admin api is enabled"`
 	EnableProfile      bool   `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
 	NetworkPrivateKey  string `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
 }
@@ -69,6 +74,10 @@ var StartNodeCmd = &cobra.Command{
 			Logger.Warn(fmt.Sprintf("Default log level set to %s", loggerLevel), zap.Error(errLogLevel))
 		}
 
+		if len(cfg.AdminAPIAddr) > 0 && len(cfg.AdminAPIToken) == 0 {
+			Logger.Fatal("admin api token is required when the admin api is enabled")
+		}
+
 		// TODO remove once all operators updated to vXXX
 		ok, err := migrationutils.E2kmMigration(Logger, cfg.DBOptions.Path)
 		if err != nil {
@@ -79,7 +88,12 @@ var StartNodeCmd = &cobra.Command{
 		}
 
 		// TODO - change via command line?
-		fork := v0.New()
+		var fork forks.Fork = v0.New()
+		if cfg.NetworkForks.NetworkForkV1Slot > 0 || cfg.NetworkForks.NetworkForkV2Slot > 0 {
+			Logger.Info("using network forks", zap.Uint64("forkV1Slot", cfg.NetworkForks.NetworkForkV1Slot),
+				zap.Uint64("forkV2Slot", cfg.NetworkForks.NetworkForkV2Slot), zap.Uint64("subnetsCount", cfg.NetworkForks.SubnetsCount))
+			fork = v1.New(cfg.NetworkForks.NetworkFork())
+		}
 
 		cfg.DBOptions.Logger = Logger
 		cfg.DBOptions.Ctx = cmd.Context()
@@ -120,6 +134,10 @@ var StartNodeCmd = &cobra.Command{
 			Logger.Fatal("failed to get p2p private key", zap.Error(err))
 		}
 		cfg.P2pNetworkConfig.NetworkPrivateKey = p2pPrivKey
+		cfg.P2pNetworkConfig.Reputation, err = p2p.NewReputationStore(db, Logger)
+		if err != nil {
+			Logger.Fatal("failed to create peers reputation store", zap.Error(err))
+		}
 		cfg.P2pNetworkConfig.Fork = fork.NetworkFork()
 		cfg.P2pNetworkConfig.NodeType = p2p.Operator
 		p2pNet, err := p2p.New(cmd.Context(), Logger, &cfg.P2pNetworkConfig)
@@ -160,6 +178,7 @@ var StartNodeCmd = &cobra.Command{
 			Logger:                     Logger,
 			NodeAddr:                   cfg.ETH1Options.ETH1Addr,
 			ConnectionTimeout:          cfg.ETH1Options.ETH1ConnectionTimeout,
+			ConfirmationBlocks:         cfg.ETH1Options.ETH1ConfirmationBlocks,
 			ContractABI:                eth1.ContractABI(),
 			RegistryContractAddr:       cfg.ETH1Options.RegistryContractAddr,
 			ShareEncryptionKeyProvider: operatorStorage.GetPrivateKey,
@@ -182,6 +201,9 @@ var StartNodeCmd = &cobra.Command{
 		if cfg.MetricsAPIPort > 0 {
 			go startMetricsHandler(Logger, cfg.MetricsAPIPort, cfg.EnableProfile)
 		}
+		if len(cfg.AdminAPIAddr) > 0 {
+			go startAdminHandler(Logger, cfg.AdminAPIAddr, cfg.AdminAPIToken, eth2Network, validatorCtrl)
+		}
 		if err := operatorNode.Start(); err != nil {
 			Logger.Fatal("failed to start SSV node", zap.Error(err))
 		}
@@ -192,6 +214,18 @@ func init() {
 	global_config.ProcessArgs(&cfg, &globalArgs, StartNodeCmd)
 }
 
+func startAdminHandler(logger *zap.Logger, addr string, token string, ethNetwork core.Network, validatorCtrl validator.IController) {
+	logger.Info("setup admin api", zap.String("addr", addr))
+	handler, err := operator.NewAdminHandler(logger, ethNetwork, validatorCtrl, token)
+	if err != nil {
+		logger.Error("failed to create admin api", zap.Error(err))
+		return
+	}
+	if err := http.ListenAndServe(addr, handler); err != nil {
+		logger.Error("failed to start admin api", zap.Error(err))
+	}
+}
+
 func startMetricsHandler(logger *zap.Logger, port int, enableProf bool) {
 	// init and start HTTP handler
 	metricsHandler := metrics.NewMetricsHandler(logger, enableProf, operatorNode.(metrics.HealthCheckAgent))
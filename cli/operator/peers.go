@@ -0,0 +1,152 @@
+package operator
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/network/p2p"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type peersReputationConfig struct {
+	global_config.GlobalConfig `yaml:"global"`
+	DBOptions                  basedb.Options `yaml:"db"`
+}
+
+var peersReputationCfg peersReputationConfig
+
+var peersReputationArgs global_config.Args
+
+// ListPeersReputationCmd is the command to list the reputation of the known peers
+var ListPeersReputationCmd = &cobra.Command{
+	Use:   "list-peers-reputation",
+	Short: "Lists the offenses records and bans of the known peers",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, db, reputation := loadPeersReputation(cmd)
+		defer db.Close()
+
+		reps, err := reputation.GetAll()
+		if err != nil {
+			logger.Fatal("failed to get peers reputation", zap.Error(err))
+		}
+		sort.Slice(reps, func(i, j int) bool {
+			return reps[i].Score > reps[j].Score
+		})
+		now := time.Now()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PEER\tSCORE\tOFFENSES\tTEMP BANS\tBANNED")
+		for _, rep := range reps {
+			banned := "no"
+			if rep.PermanentBan {
+				banned = "permanently"
+			} else if rep.IsBanned(now) {
+				banned = fmt.Sprintf("until %s", time.Unix(rep.BannedUntil, 0).Format(time.RFC3339))
+			}
+			fmt.Fprintf(w, "%s\t%.2f\t%d\t%d\t%s\n", rep.PeerID, rep.Score, rep.Offenses, rep.TempBans, banned)
+		}
+		if err := w.Flush(); err != nil {
+			logger.Fatal("failed to print peers reputation", zap.Error(err))
+		}
+	},
+}
+
+// BanPeerCmd is the command to ban a peer, temporarily or permanently
+var BanPeerCmd = &cobra.Command{
+	Use:   "ban-peer",
+	Short: "Bans a peer, the node won't connect with the peer while it is banned",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, db, reputation := loadPeersReputation(cmd)
+		defer db.Close()
+
+		pid := getPeerIDFlagValue(logger, cmd)
+		durationStr, err := flags.GetBanDurationFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get ban duration flag value", zap.Error(err))
+		}
+		var duration time.Duration
+		if len(durationStr) > 0 {
+			if duration, err = time.ParseDuration(durationStr); err != nil || duration <= 0 {
+				logger.Fatal("invalid ban duration", zap.String("duration", durationStr), zap.Error(err))
+			}
+		}
+		if err := reputation.Ban(pid, duration); err != nil {
+			logger.Fatal("failed to ban peer", zap.Error(err))
+		}
+		logger.Info("banned peer", zap.String("peer", pid.String()), zap.Duration("duration", duration),
+			zap.Bool("permanent", duration == 0))
+	},
+}
+
+// UnbanPeerCmd is the command to remove the ban of a peer
+var UnbanPeerCmd = &cobra.Command{
+	Use:   "unban-peer",
+	Short: "Removes the ban of a peer and resets its offenses score",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, db, reputation := loadPeersReputation(cmd)
+		defer db.Close()
+
+		pid := getPeerIDFlagValue(logger, cmd)
+		if err := reputation.Unban(pid); err != nil {
+			logger.Fatal("failed to unban peer", zap.Error(err))
+		}
+		logger.Info("unbanned peer", zap.String("peer", pid.String()))
+	},
+}
+
+// loadPeersReputation reads the configuration and opens the db and the peers reputation store of the node,
+// the node must be stopped as the db can't be shared between processes.
+func loadPeersReputation(cmd *cobra.Command) (*zap.Logger, basedb.IDb, p2p.ReputationStore) {
+	if err := cleanenv.ReadConfig(peersReputationArgs.ConfigPath, &peersReputationCfg); err != nil {
+		log.Fatal(err)
+	}
+	loggerLevel, _ := logex.GetLoggerLevelValue(peersReputationCfg.LogLevel)
+	Logger := logex.Build(cmd.Parent().Short, loggerLevel, &logex.EncodingConfig{
+		Format:       peersReputationCfg.GlobalConfig.LogFormat,
+		LevelEncoder: logex.LevelEncoder([]byte(peersReputationCfg.LogLevelFormat)),
+	})
+
+	peersReputationCfg.DBOptions.Logger = Logger
+	peersReputationCfg.DBOptions.Ctx = cmd.Context()
+	db, err := storage.GetStorageFactory(peersReputationCfg.DBOptions)
+	if err != nil {
+		Logger.Fatal("failed to create db!", zap.Error(err))
+	}
+	reputation, err := p2p.NewReputationStore(db, Logger)
+	if err != nil {
+		Logger.Fatal("failed to create peers reputation store", zap.Error(err))
+	}
+	return Logger, db, reputation
+}
+
+func getPeerIDFlagValue(logger *zap.Logger, cmd *cobra.Command) peer.ID {
+	peerIDStr, err := flags.GetPeerIDFlagValue(cmd)
+	if err != nil {
+		logger.Fatal("failed to get peer id flag value", zap.Error(err))
+	}
+	pid, err := peer.Decode(peerIDStr)
+	if err != nil {
+		logger.Fatal("invalid peer id", zap.String("peer", peerIDStr), zap.Error(err))
+	}
+	return pid
+}
+
+func init() {
+	global_config.ProcessArgs(&peersReputationCfg, &peersReputationArgs, ListPeersReputationCmd)
+	global_config.ProcessArgs(&peersReputationCfg, &peersReputationArgs, BanPeerCmd)
+	global_config.ProcessArgs(&peersReputationCfg, &peersReputationArgs, UnbanPeerCmd)
+	flags.AddPeerIDFlag(BanPeerCmd)
+	flags.AddBanDurationFlag(BanPeerCmd)
+	flags.AddPeerIDFlag(UnbanPeerCmd)
+}
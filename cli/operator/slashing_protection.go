@@ -0,0 +1,132 @@
+package operator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon/goclient/ekm"
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+	validatorstorage "github.com/bloxapp/ssv/validator/storage"
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+type slashingProtectionConfig struct {
+	global_config.GlobalConfig `yaml:"global"`
+	DBOptions                  basedb.Options `yaml:"db"`
+	ETH2Options                struct {
+		Network string `yaml:"Network" env:"NETWORK" env-default:"prater" env-description:"ETH2 network the slashing protection data belongs to"`
+	} `yaml:"eth2"`
+}
+
+var slashingProtectionCfg slashingProtectionConfig
+
+var slashingProtectionArgs global_config.Args
+
+// ExportSlashingProtectionCmd is the command to export the slashing protection data of all shares to an EIP-3076 interchange file
+var ExportSlashingProtectionCmd = &cobra.Command{
+	Use:   "export-slashing-protection",
+	Short: "Exports slashing protection data of all shares in EIP-3076 interchange format",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, db, network, shares := loadSlashingProtectionData(cmd)
+		defer db.Close()
+
+		filePath, err := flags.GetInterchangeFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get interchange file flag value", zap.Error(err))
+		}
+
+		interchange, err := ekm.ExportSlashingProtection(db, network, shares)
+		if err != nil {
+			logger.Fatal("failed to export slashing protection data", zap.Error(err))
+		}
+		byts, err := json.MarshalIndent(interchange, "", "  ")
+		if err != nil {
+			logger.Fatal("failed to marshal slashing protection data", zap.Error(err))
+		}
+		if err := ioutil.WriteFile(filePath, byts, 0600); err != nil {
+			logger.Fatal("failed to write interchange file", zap.Error(err))
+		}
+		logger.Info("exported slashing protection data", zap.Int("validators", len(interchange.Data)),
+			zap.String("file", filePath))
+	},
+}
+
+// ImportSlashingProtectionCmd is the command to import the slashing protection data of all shares from an EIP-3076 interchange file
+var ImportSlashingProtectionCmd = &cobra.Command{
+	Use:   "import-slashing-protection",
+	Short: "Imports slashing protection data of all shares from an EIP-3076 interchange file",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, db, network, shares := loadSlashingProtectionData(cmd)
+		defer db.Close()
+
+		filePath, err := flags.GetInterchangeFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get interchange file flag value", zap.Error(err))
+		}
+
+		byts, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			logger.Fatal("failed to read interchange file", zap.Error(err))
+		}
+		interchange := &ekm.Interchange{}
+		if err := json.Unmarshal(byts, interchange); err != nil {
+			logger.Fatal("failed to unmarshal interchange file", zap.Error(err))
+		}
+
+		imported, err := ekm.ImportSlashingProtection(db, network, shares, interchange)
+		if err != nil {
+			logger.Fatal("failed to import slashing protection data", zap.Error(err))
+		}
+		logger.Info("imported slashing protection data", zap.Int("validators", imported),
+			zap.Int("skipped", len(interchange.Data)-imported), zap.String("file", filePath))
+	},
+}
+
+// loadSlashingProtectionData reads the configuration and opens the db and the validators shares of the node,
+// the node must be stopped as the db can't be shared between processes.
+func loadSlashingProtectionData(cmd *cobra.Command) (*zap.Logger, basedb.IDb, core.Network, []*validatorstorage.Share) {
+	if err := cleanenv.ReadConfig(slashingProtectionArgs.ConfigPath, &slashingProtectionCfg); err != nil {
+		log.Fatal(err)
+	}
+	loggerLevel, _ := logex.GetLoggerLevelValue(slashingProtectionCfg.LogLevel)
+	Logger := logex.Build(cmd.Parent().Short, loggerLevel, &logex.EncodingConfig{
+		Format:       slashingProtectionCfg.GlobalConfig.LogFormat,
+		LevelEncoder: logex.LevelEncoder([]byte(slashingProtectionCfg.LogLevelFormat)),
+	})
+
+	slashingProtectionCfg.DBOptions.Logger = Logger
+	slashingProtectionCfg.DBOptions.Ctx = cmd.Context()
+	db, err := storage.GetStorageFactory(slashingProtectionCfg.DBOptions)
+	if err != nil {
+		Logger.Fatal("failed to create db!", zap.Error(err))
+	}
+
+	eth2Network := core.NetworkFromString(slashingProtectionCfg.ETH2Options.Network)
+	if eth2Network == "" {
+		Logger.Fatal("unknown eth2 network", zap.String("network", slashingProtectionCfg.ETH2Options.Network))
+	}
+
+	shares, err := validatorstorage.NewCollection(validatorstorage.CollectionOptions{
+		DB:     db,
+		Logger: Logger,
+	}).GetAllValidatorsShare()
+	if err != nil {
+		Logger.Fatal("failed to get validators shares", zap.Error(err))
+	}
+	return Logger, db, eth2Network, shares
+}
+
+func init() {
+	global_config.ProcessArgs(&slashingProtectionCfg, &slashingProtectionArgs, ExportSlashingProtectionCmd)
+	global_config.ProcessArgs(&slashingProtectionCfg, &slashingProtectionArgs, ImportSlashingProtectionCmd)
+	flags.AddInterchangeFileFlag(ExportSlashingProtectionCmd)
+	flags.AddInterchangeFileFlag(ImportSlashingProtectionCmd)
+}
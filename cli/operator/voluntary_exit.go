@@ -0,0 +1,127 @@
+package operator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/operator"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/validator"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const adminAPITimeout = 10 * time.Second
+
+// ListVoluntaryExitsCmd is the command to list the voluntary exit requests known to a running node
+var ListVoluntaryExitsCmd = &cobra.Command{
+	Use:   "list-voluntary-exits",
+	Short: "Lists the voluntary exit requests of the validators, requires the admin api of a running node",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(cmd.Parent().Short, zapcore.InfoLevel, nil)
+
+		var exits []*validator.VoluntaryExit
+		if err := callAdminAPI(cmd, http.MethodGet, nil, &exits); err != nil {
+			logger.Fatal("failed to get voluntary exits", zap.Error(err))
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PUBLIC KEY\tINDEX\tEPOCH\tSIGNERS\tAPPROVED\tSUBMITTED")
+		for _, exit := range exits {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d/%d %v\t%t\t%t\n", exit.PubKey, exit.Index, exit.Epoch,
+				len(exit.Signers), exit.Threshold, exit.Signers, exit.Approved, exit.Submitted)
+		}
+		if err := w.Flush(); err != nil {
+			logger.Fatal("failed to print voluntary exits", zap.Error(err))
+		}
+	},
+}
+
+// ApproveVoluntaryExitCmd is the command to request or approve a voluntary exit of a validator,
+// the exit is submitted once enough operators of the validator approved it
+var ApproveVoluntaryExitCmd = &cobra.Command{
+	Use:   "approve-voluntary-exit",
+	Short: "Signs a voluntary exit of a validator, the exit is submitted once enough operators approved it. requires the admin api of a running node",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(cmd.Parent().Short, zapcore.InfoLevel, nil)
+
+		pubKey, err := flags.GetValidatorPublicKeyFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get public key flag value", zap.Error(err))
+		}
+		epoch, err := flags.GetExitEpochFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get epoch flag value", zap.Error(err))
+		}
+		req := &operator.VoluntaryExitRequest{
+			PubKey: strings.TrimPrefix(pubKey, "0x"),
+			Epoch:  epoch,
+		}
+		exit := &validator.VoluntaryExit{}
+		if err := callAdminAPI(cmd, http.MethodPost, req, exit); err != nil {
+			logger.Fatal("failed to approve voluntary exit", zap.Error(err))
+		}
+		logger.Info("approved voluntary exit", zap.String("pubKey", exit.PubKey), zap.Uint64("epoch", exit.Epoch),
+			zap.Uint64s("signers", exit.Signers), zap.Int("threshold", exit.Threshold), zap.Bool("submitted", exit.Submitted))
+	},
+}
+
+// callAdminAPI sends a request to the voluntary exits endpoint of the admin api and parses the response into res
+func callAdminAPI(cmd *cobra.Command, method string, body interface{}, res interface{}) error {
+	addr, err := flags.GetAdminAPIFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to get admin api flag value")
+	}
+	token, err := flags.GetAdminAPITokenFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to get admin api token flag value")
+	}
+	if !strings.HasPrefix(addr, "http") {
+		addr = fmt.Sprintf("http://%s", addr)
+	}
+	var reqBody []byte
+	if body != nil {
+		if reqBody, err = json.Marshal(body); err != nil {
+			return errors.Wrap(err, "failed to marshal request")
+		}
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(addr, "/")+operator.VoluntaryExitsPath, bytes.NewReader(reqBody))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(operator.AdminAuthHeader, "Bearer "+token)
+	resp, err := (&http.Client{Timeout: adminAPITimeout}).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call admin api")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, res)
+}
+
+func init() {
+	flags.AddAdminAPIFlag(ListVoluntaryExitsCmd)
+	flags.AddAdminAPIFlag(ApproveVoluntaryExitCmd)
+	flags.AddAdminAPITokenFlag(ListVoluntaryExitsCmd)
+	flags.AddAdminAPITokenFlag(ApproveVoluntaryExitCmd)
+	flags.AddValidatorPublicKeyFlag(ApproveVoluntaryExitCmd)
+	flags.AddExitEpochFlag(ApproveVoluntaryExitCmd)
+}
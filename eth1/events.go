@@ -18,7 +18,7 @@ import (
 )
 
 var (
-	contractABI = `[{"anonymous":false,"inputs":[{"indexed":false,"internalType":"bytes","name":"validatorPublicKey","type":"bytes"},{"indexed":false,"internalType":"uint256","name":"index","type":"uint256"},{"indexed":false,"internalType":"bytes","name":"operatorPublicKey","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"sharedPublicKey","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"encryptedKey","type":"bytes"}],"name":"OessAdded","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"string","name":"name","type":"string"},{"indexed":false,"internalType":"address","name":"ownerAddress","type":"address"},{"indexed":false,"internalType":"bytes","name":"publicKey","type":"bytes"}],"name":"OperatorAdded","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"ownerAddress","type":"address"},{"indexed":false,"internalType":"bytes","name":"publicKey","type":"bytes"},{"components":[{"internalType":"uint256","name":"index","type":"uint256"},{"internalType":"bytes","name":"operatorPublicKey","type":"bytes"},{"internalType":"bytes","name":"sharedPublicKey","type":"bytes"},{"internalType":"bytes","name":"encryptedKey","type":"bytes"}],"indexed":false,"internalType":"struct ISSVNetwork.Oess[]","name":"oessList","type":"tuple[]"}],"name":"ValidatorAdded","type":"event"},{"inputs":[{"internalType":"string","name":"_name","type":"string"},{"internalType":"address","name":"_ownerAddress","type":"address"},{"internalType":"bytes","name":"_publicKey","type":"bytes"}],"name":"addOperator","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_ownerAddress","type":"address"},{"internalType":"bytes","name":"_publicKey","type":"bytes"},{"internalType":"bytes[]","name":"_operatorPublicKeys","type":"bytes[]"},{"internalType":"bytes[]","name":"_sharesPublicKeys","type":"bytes[]"},{"internalType":"bytes[]","name":"_encryptedKeys","type":"bytes[]"}],"name":"addValidator","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"operatorCount","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"bytes","name":"","type":"bytes"}],"name":"operators","outputs":[{"internalType":"string","name":"name","type":"string"},{"internalType":"address","name":"ownerAddress","type":"address"},{"internalType":"bytes","name":"publicKey","type":"bytes"},{"internalType":"uint256","name":"score","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"validatorCount","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+	contractABI = `[{"anonymous":false,"inputs":[{"indexed":false,"internalType":"bytes","name":"validatorPublicKey","type":"bytes"},{"indexed":false,"internalType":"uint256","name":"index","type":"uint256"},{"indexed":false,"internalType":"bytes","name":"operatorPublicKey","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"sharedPublicKey","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"encryptedKey","type":"bytes"}],"name":"OessAdded","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"string","name":"name","type":"string"},{"indexed":false,"internalType":"address","name":"ownerAddress","type":"address"},{"indexed":false,"internalType":"bytes","name":"publicKey","type":"bytes"}],"name":"OperatorAdded","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"ownerAddress","type":"address"},{"indexed":false,"internalType":"bytes","name":"publicKey","type":"bytes"},{"components":[{"internalType":"uint256","name":"index","type":"uint256"},{"internalType":"bytes","name":"operatorPublicKey","type":"bytes"},{"internalType":"bytes","name":"sharedPublicKey","type":"bytes"},{"internalType":"bytes","name":"encryptedKey","type":"bytes"}],"indexed":false,"internalType":"struct ISSVNetwork.Oess[]","name":"oessList","type":"tuple[]"}],"name":"ValidatorAdded","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"ownerAddress","type":"address"},{"indexed":false,"internalType":"bytes","name":"publicKey","type":"bytes"}],"name":"ValidatorRemoved","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"ownerAddress","type":"address"},{"indexed":false,"internalType":"bytes","name":"publicKey","type":"bytes"},{"components":[{"internalType":"uint256","name":"index","type":"uint256"},{"internalType":"bytes","name":"operatorPublicKey","type":"bytes"},{"internalType":"bytes","name":"sharedPublicKey","type":"bytes"},{"internalType":"bytes","name":"encryptedKey","type":"bytes"}],"indexed":false,"internalType":"struct ISSVNetwork.Oess[]","name":"oessList","type":"tuple[]"}],"name":"ValidatorUpdated","type":"event"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"ownerAddress","type":"address"},{"indexed":false,"internalType":"bytes","name":"publicKey","type":"bytes"}],"name":"OperatorRemoved","type":"event"},{"inputs":[{"internalType":"string","name":"_name","type":"string"},{"internalType":"address","name":"_ownerAddress","type":"address"},{"internalType":"bytes","name":"_publicKey","type":"bytes"}],"name":"addOperator","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"internalType":"address","name":"_ownerAddress","type":"address"},{"internalType":"bytes","name":"_publicKey","type":"bytes"},{"internalType":"bytes[]","name":"_operatorPublicKeys","type":"bytes[]"},{"internalType":"bytes[]","name":"_sharesPublicKeys","type":"bytes[]"},{"internalType":"bytes[]","name":"_encryptedKeys","type":"bytes[]"}],"name":"addValidator","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[],"name":"operatorCount","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"bytes","name":"","type":"bytes"}],"name":"operators","outputs":[{"internalType":"string","name":"name","type":"string"},{"internalType":"address","name":"ownerAddress","type":"address"},{"internalType":"bytes","name":"publicKey","type":"bytes"},{"internalType":"uint256","name":"score","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"validatorCount","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
 )
 
 // LoadABI enables to load a custom abi json
@@ -79,6 +79,26 @@ type OperatorAddedEvent struct {
 	OwnerAddress   common.Address
 }
 
+// ValidatorUpdatedEvent struct represents event received by the smart contract,
+// it is emitted once the validator was reshared to a new set of operators
+type ValidatorUpdatedEvent struct {
+	PublicKey    []byte
+	OwnerAddress common.Address
+	OessList     []Oess
+}
+
+// ValidatorRemovedEvent struct represents event received by the smart contract
+type ValidatorRemovedEvent struct {
+	OwnerAddress common.Address
+	PublicKey    []byte
+}
+
+// OperatorRemovedEvent struct represents event received by the smart contract
+type OperatorRemovedEvent struct {
+	OwnerAddress common.Address
+	PublicKey    []byte
+}
+
 // ParseOperatorAddedEvent parses an OperatorAddedEvent
 func ParseOperatorAddedEvent(logger *zap.Logger, operatorPrivateKey *rsa.PrivateKey, data []byte, contractAbi abi.ABI) (*OperatorAddedEvent, bool, error) {
 	var operatorAddedEvent OperatorAddedEvent
@@ -121,18 +141,47 @@ func ParseValidatorAddedEvent(logger *zap.Logger, operatorPrivateKey *rsa.Privat
 		zap.String("Validator PublicKey", hex.EncodeToString(validatorAddedEvent.PublicKey)),
 		zap.String("Owner Address", validatorAddedEvent.OwnerAddress.String()))
 
+	isEventBelongsToOperator, err := decodeOessList(operatorPrivateKey, validatorAddedEvent.OessList)
+	if err != nil {
+		return nil, false, err
+	}
+	return &validatorAddedEvent, isEventBelongsToOperator, nil
+}
+
+// ParseValidatorUpdatedEvent parses ValidatorUpdatedEvent
+func ParseValidatorUpdatedEvent(logger *zap.Logger, operatorPrivateKey *rsa.PrivateKey, data []byte, contractAbi abi.ABI) (*ValidatorUpdatedEvent, bool, error) {
+	var validatorUpdatedEvent ValidatorUpdatedEvent
+	err := contractAbi.UnpackIntoInterface(&validatorUpdatedEvent, "ValidatorUpdated", data)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to unpack ValidatorUpdated event")
+	}
+
+	logger.Debug("ValidatorUpdated Event",
+		zap.String("Validator PublicKey", hex.EncodeToString(validatorUpdatedEvent.PublicKey)),
+		zap.String("Owner Address", validatorUpdatedEvent.OwnerAddress.String()))
+
+	isEventBelongsToOperator, err := decodeOessList(operatorPrivateKey, validatorUpdatedEvent.OessList)
+	if err != nil {
+		return nil, false, err
+	}
+	return &validatorUpdatedEvent, isEventBelongsToOperator, nil
+}
+
+// decodeOessList decodes the operators public keys of the given list and decrypts the share of this operator,
+// returns true if one of the shares belongs to this operator
+func decodeOessList(operatorPrivateKey *rsa.PrivateKey, oessList []Oess) (bool, error) {
 	var isEventBelongsToOperator bool
 
-	for i := range validatorAddedEvent.OessList {
-		validatorShare := &validatorAddedEvent.OessList[i]
+	for i := range oessList {
+		validatorShare := &oessList[i]
 
 		outAbi, err := getOutAbi()
 		if err != nil {
-			return nil, false, errors.Wrap(err, "failed to define ABI")
+			return false, errors.Wrap(err, "failed to define ABI")
 		}
 		operatorPublicKey, err := readOperatorPubKey(validatorShare.OperatorPublicKey, outAbi)
 		if err != nil {
-			return nil, false, errors.Wrap(err, "failed to unpack OperatorPublicKey")
+			return false, errors.Wrap(err, "failed to unpack OperatorPublicKey")
 		}
 
 		validatorShare.OperatorPublicKey = []byte(operatorPublicKey) // set for further use in code
@@ -141,19 +190,19 @@ func ParseValidatorAddedEvent(logger *zap.Logger, operatorPrivateKey *rsa.Privat
 		}
 		nodeOperatorPubKey, err := rsaencryption.ExtractPublicKey(operatorPrivateKey)
 		if err != nil {
-			return nil, false, errors.Wrap(err, "failed to extract public key")
+			return false, errors.Wrap(err, "failed to extract public key")
 		}
 		if strings.EqualFold(operatorPublicKey, nodeOperatorPubKey) {
 			out, err := outAbi.Unpack("method", validatorShare.EncryptedKey)
 			if err != nil {
-				return nil, false, errors.Wrap(err, "failed to unpack EncryptedKey")
+				return false, errors.Wrap(err, "failed to unpack EncryptedKey")
 			}
 
 			if encryptedSharePrivateKey, ok := out[0].(string); ok {
 				decryptedSharePrivateKey, err := rsaencryption.DecodeKey(operatorPrivateKey, encryptedSharePrivateKey)
 				decryptedSharePrivateKey = strings.Replace(decryptedSharePrivateKey, "0x", "", 1)
 				if err != nil {
-					return nil, false, errors.Wrap(err, "failed to decrypt share private key")
+					return false, errors.Wrap(err, "failed to decrypt share private key")
 				}
 				validatorShare.EncryptedKey = []byte(decryptedSharePrivateKey)
 				isEventBelongsToOperator = true
@@ -161,7 +210,52 @@ func ParseValidatorAddedEvent(logger *zap.Logger, operatorPrivateKey *rsa.Privat
 		}
 	}
 
-	return &validatorAddedEvent, isEventBelongsToOperator, nil
+	return isEventBelongsToOperator, nil
+}
+
+// ParseValidatorRemovedEvent parses ValidatorRemovedEvent
+func ParseValidatorRemovedEvent(logger *zap.Logger, data []byte, contractAbi abi.ABI) (*ValidatorRemovedEvent, error) {
+	var validatorRemovedEvent ValidatorRemovedEvent
+	err := contractAbi.UnpackIntoInterface(&validatorRemovedEvent, "ValidatorRemoved", data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unpack ValidatorRemoved event")
+	}
+
+	logger.Debug("ValidatorRemoved Event",
+		zap.String("Validator PublicKey", hex.EncodeToString(validatorRemovedEvent.PublicKey)),
+		zap.String("Owner Address", validatorRemovedEvent.OwnerAddress.String()))
+
+	return &validatorRemovedEvent, nil
+}
+
+// ParseOperatorRemovedEvent parses OperatorRemovedEvent
+func ParseOperatorRemovedEvent(logger *zap.Logger, operatorPrivateKey *rsa.PrivateKey, data []byte, contractAbi abi.ABI) (*OperatorRemovedEvent, bool, error) {
+	var operatorRemovedEvent OperatorRemovedEvent
+	err := contractAbi.UnpackIntoInterface(&operatorRemovedEvent, "OperatorRemoved", data)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to unpack OperatorRemoved event")
+	}
+	outAbi, err := getOutAbi()
+	if err != nil {
+		return nil, false, err
+	}
+	pubKey, err := readOperatorPubKey(operatorRemovedEvent.PublicKey, outAbi)
+	if err != nil {
+		return nil, false, err
+	}
+	operatorRemovedEvent.PublicKey = []byte(pubKey)
+	logger.Debug("OperatorRemoved Event",
+		zap.String("Operator PublicKey", pubKey),
+		zap.String("Owner Address", operatorRemovedEvent.OwnerAddress.String()))
+	var nodeOperatorPubKey string
+	if operatorPrivateKey != nil {
+		nodeOperatorPubKey, err = rsaencryption.ExtractPublicKey(operatorPrivateKey)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to extract public key")
+		}
+	}
+	isEventBelongsToOperator := strings.EqualFold(pubKey, nodeOperatorPubKey)
+	return &operatorRemovedEvent, isEventBelongsToOperator, nil
 }
 
 func readOperatorPubKey(operatorPublicKey []byte, outAbi abi.ABI) (string, error) {
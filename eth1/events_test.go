@@ -3,10 +3,13 @@ package eth1
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"math/big"
 	"strings"
 	"testing"
 )
@@ -68,3 +71,77 @@ func TestParseValidatorAddedEvent(t *testing.T) {
 	require.Equal(t, "91db3a13ab428a6c9c20e7104488cb6961abeab60e56cf4ba199eed3b5f6e7ced670ecb066c9704dc2fa93133792381c",
 		hex.EncodeToString(parsed.PublicKey))
 }
+
+func TestParseValidatorRemovedEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI()))
+	require.NoError(t, err)
+	pk, err := hex.DecodeString("91db3a13ab428a6c9c20e7104488cb6961abeab60e56cf4ba199eed3b5f6e7ced670ecb066c9704dc2fa93133792381c")
+	require.NoError(t, err)
+	owner := common.HexToAddress("0xfeedb14d8b2c76fdf808c29818b06b830e8c2c0e")
+	data, err := contractAbi.Events["ValidatorRemoved"].Inputs.Pack(owner, pk)
+	require.NoError(t, err)
+
+	parsed, err := ParseValidatorRemovedEvent(zap.L(), data, contractAbi)
+	require.NoError(t, err)
+	require.NotNil(t, parsed)
+	require.Equal(t, pk, parsed.PublicKey)
+	require.Equal(t, owner, parsed.OwnerAddress)
+}
+
+func TestParseOperatorRemovedEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI()))
+	require.NoError(t, err)
+	outAbi, err := getOutAbi()
+	require.NoError(t, err)
+	operatorPubKey := "LS0tLS1CRUdJTiBSU0EgUFVCTElDIEtFWS0tLS0tCk1JSUJJakFOQmdrcWhraUc5dzBCQVFFRkFBT0NBUThBTUlJQkNnS0NBUUVBb3dFN09FYnd5TGt2clowVFU0amoKb295SUZ4TnZncllGajcrS1dzbGZJYytkUzNEbUl6MnVUVk1nWTZUbkpxNWNCa3hJS25PWklSWUFiWU9jQ2FNSgpaZVFFc21pMDJnTlhyN0JZL3pMNTRqYm1Zc21DaEE0N3dJd00vUjlmOUNHVFRpNVpvWVZpeDVSdzZFUndnSkJGCjBUcldSNnpVNlVCMFc1TWZiRWRoR0lLTnI5QTBzS1c3TmI4WE9zN2hmMXl1bkdlRTJOWnpJNmlwK2ZObDl0UkcKMkcyR2JZQW9JM1hkaWZESjM4K1ZkMkJQNVNjUENwZEpLOHBpWXJ5OURtSXA3eTF4eVVLNHQyY2I4eFpWVkhtTwppcjlyeWtmdXpaR2NJZ2xVQnBWL1Y0VEVnMXpxWVlpQkVBOXBMWGdTT1RZbmNYS0xzWDFBa3pyU0FHbzdnSEJPCndRSURBUUFCCi0tLS0tRU5EIFJTQSBQVUJMSUMgS0VZLS0tLS0K"
+	encodedPubKey, err := outAbi.Methods["method"].Outputs.Pack(operatorPubKey)
+	require.NoError(t, err)
+	owner := common.HexToAddress("0x67ce5c69260bd819b4e0ad13f4b873074d479811")
+	data, err := contractAbi.Events["OperatorRemoved"].Inputs.Pack(owner, encodedPubKey)
+	require.NoError(t, err)
+
+	parsed, isEventBelongsToOperator, err := ParseOperatorRemovedEvent(zap.L(), nil, data, contractAbi)
+	require.NoError(t, err)
+	require.False(t, isEventBelongsToOperator)
+	require.NotNil(t, parsed)
+	require.Equal(t, operatorPubKey, string(parsed.PublicKey))
+	require.Equal(t, owner, parsed.OwnerAddress)
+}
+
+func TestParseValidatorUpdatedEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI()))
+	require.NoError(t, err)
+	outAbi, err := getOutAbi()
+	require.NoError(t, err)
+
+	pk, err := hex.DecodeString("91db3a13ab428a6c9c20e7104488cb6961abeab60e56cf4ba199eed3b5f6e7ced670ecb066c9704dc2fa93133792381c")
+	require.NoError(t, err)
+	owner := common.HexToAddress("0xfeedb14d8b2c76fdf808c29818b06b830e8c2c0e")
+	var oessList []Oess
+	for i := 0; i < 4; i++ {
+		operatorPubKey, err := outAbi.Methods["method"].Outputs.Pack(fmt.Sprintf("operator-%d", i))
+		require.NoError(t, err)
+		encryptedKey, err := outAbi.Methods["method"].Outputs.Pack(fmt.Sprintf("encrypted-%d", i))
+		require.NoError(t, err)
+		oessList = append(oessList, Oess{
+			Index:             big.NewInt(int64(i)),
+			OperatorPublicKey: operatorPubKey,
+			SharedPublicKey:   []byte{byte(i)},
+			EncryptedKey:      encryptedKey,
+		})
+	}
+	data, err := contractAbi.Events["ValidatorUpdated"].Inputs.Pack(owner, pk, oessList)
+	require.NoError(t, err)
+
+	parsed, isEventBelongsToOperator, err := ParseValidatorUpdatedEvent(zap.L(), nil, data, contractAbi)
+	require.NoError(t, err)
+	require.False(t, isEventBelongsToOperator)
+	require.NotNil(t, parsed)
+	require.Equal(t, pk, parsed.PublicKey)
+	require.Equal(t, owner, parsed.OwnerAddress)
+	require.Len(t, parsed.OessList, 4)
+	for i, oess := range parsed.OessList {
+		require.EqualValues(t, i, oess.Index.Int64())
+		require.Equal(t, fmt.Sprintf("operator-%d", i), string(oess.OperatorPublicKey))
+	}
+}
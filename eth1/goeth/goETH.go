@@ -54,13 +54,22 @@ type ClientOptions struct {
 	RegistryContractAddr       string
 	ContractABI                string
 	ConnectionTimeout          time.Duration
+	ConfirmationBlocks         uint64
 	ShareEncryptionKeyProvider eth1.ShareEncryptionKeyProvider
 }
 
+// ethBackend is the subset of the eth1 node API that is used by the client
+type ethBackend interface {
+	ethereum.LogFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+}
+
 // eth1Client is the internal implementation of Client
 type eth1Client struct {
 	ctx    context.Context
-	conn   *ethclient.Client
+	conn   ethBackend
 	logger *zap.Logger
 
 	shareEncryptionKeyProvider eth1.ShareEncryptionKeyProvider
@@ -70,6 +79,11 @@ type eth1Client struct {
 	contractABI          string
 	connectionTimeout    time.Duration
 
+	// confirmationBlocks is the number of blocks that must be on top of an event before it is applied
+	confirmationBlocks uint64
+	// confirmedBlock is the last block which its events were applied
+	confirmedBlock uint64
+
 	eventsFeed *event.Feed
 }
 
@@ -90,6 +104,7 @@ func NewEth1Client(opts ClientOptions) (eth1.Client, error) {
 		registryContractAddr:       opts.RegistryContractAddr,
 		contractABI:                opts.ContractABI,
 		connectionTimeout:          opts.ConnectionTimeout,
+		confirmationBlocks:         opts.ConfirmationBlocks,
 		eventsFeed:                 new(event.Feed),
 	}
 
@@ -231,25 +246,95 @@ func (ec *eth1Client) subscribeToLogs() (ethereum.Subscription, chan types.Log,
 	return sub, logs, nil
 }
 
-// listenToSubscription listen to new event logs from the contract
+// listenToSubscription listen to new event logs from the contract,
+// logs are applied once they are deep enough and rolled back if they were dropped by a chain reorg
 func (ec *eth1Client) listenToSubscription(logs chan types.Log, sub ethereum.Subscription, contractAbi abi.ABI) error {
+	defer sub.Unsubscribe()
+
+	var pending pendingLogs
+	var heads chan *types.Header
+	var headsErr <-chan error
+	if ec.confirmationBlocks > 0 {
+		heads = make(chan *types.Header)
+		headsSub, err := ec.conn.SubscribeNewHead(ec.ctx, heads)
+		if err != nil {
+			ec.logger.Warn("failed to subscribe to new heads", zap.Error(err))
+			return err
+		}
+		defer headsSub.Unsubscribe()
+		headsErr = headsSub.Err()
+	}
+
+	// logs of blocks that were not applied yet (e.g. during the sync) won't arrive from the subscription
+	if ec.confirmedBlock > 0 {
+		missedLogs, err := ec.conn.FilterLogs(ec.ctx, ethereum.FilterQuery{
+			Addresses: []common.Address{common.HexToAddress(ec.registryContractAddr)},
+			FromBlock: new(big.Int).SetUint64(ec.confirmedBlock + 1),
+		})
+		if err != nil {
+			ec.logger.Warn("failed to get missed event logs", zap.Error(err))
+			return err
+		}
+		for _, vLog := range missedLogs {
+			ec.processStreamedLog(vLog, &pending, contractAbi)
+		}
+	}
+
 	for {
 		select {
 		case err := <-sub.Err():
 			ec.logger.Warn("failed to read logs from subscription", zap.Error(err))
 			return err
+		case err := <-headsErr:
+			ec.logger.Warn("failed to read new heads from subscription", zap.Error(err))
+			return err
 		case vLog := <-logs:
 			ec.logger.Debug("received contract event from stream")
-			err := ec.handleEvent(vLog, contractAbi)
-			if err != nil {
-				ec.logger.Error("Failed to handle event", zap.Error(err))
-				continue
-			}
+			ec.processStreamedLog(vLog, &pending, contractAbi)
+		case head := <-heads:
+			ec.applyConfirmedLogs(head.Number.Uint64(), &pending, contractAbi)
+		}
+	}
+}
+
+// processStreamedLog applies the given log or keeps it until it is deep enough,
+// logs that were dropped by a reorg are removed from the pending logs or rolled back if they were already applied
+func (ec *eth1Client) processStreamedLog(vLog types.Log, pending *pendingLogs, contractAbi abi.ABI) {
+	logger := ec.logger.With(zap.String("txHash", vLog.TxHash.Hex()), zap.Uint64("blockNumber", vLog.BlockNumber))
+	if vLog.Removed {
+		if pending.remove(vLog) {
+			logger.Debug("pending contract event was dropped by a chain reorg")
+			return
+		}
+		logger.Warn("contract event was dropped by a chain reorg after it was applied, rolling back")
+	} else if ec.confirmationBlocks > 0 {
+		pending.add(vLog)
+		return
+	} else if vLog.BlockNumber > ec.confirmedBlock+1 {
+		ec.confirmedBlock = vLog.BlockNumber - 1
+	}
+	if err := ec.handleEvent(vLog, contractAbi); err != nil {
+		logger.Error("Failed to handle event", zap.Error(err))
+	}
+}
+
+// applyConfirmedLogs applies the pending logs that are deep enough, given the current head of the chain
+func (ec *eth1Client) applyConfirmedLogs(head uint64, pending *pendingLogs, contractAbi abi.ABI) {
+	if head < ec.confirmationBlocks {
+		return
+	}
+	confirmedBlock := head - ec.confirmationBlocks
+	for _, vLog := range pending.popConfirmed(confirmedBlock) {
+		if err := ec.handleEvent(vLog, contractAbi); err != nil {
+			ec.logger.Error("Failed to handle event", zap.Error(err))
 		}
 	}
+	if confirmedBlock > ec.confirmedBlock {
+		ec.confirmedBlock = confirmedBlock
+	}
 }
 
-// syncSmartContractsEvents sync events history of the given contract
+// syncSmartContractsEvents sync events history of the given contract, up to the last confirmed block
 func (ec *eth1Client) syncSmartContractsEvents(fromBlock *big.Int) error {
 	ec.logger.Debug("syncing smart contract events",
 		zap.Uint64("fromBlock", fromBlock.Uint64()))
@@ -258,18 +343,20 @@ func (ec *eth1Client) syncSmartContractsEvents(fromBlock *big.Int) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to parse ABI interface")
 	}
-	currentBlock, err := ec.conn.BlockNumber(ec.ctx)
+	head, err := ec.conn.HeaderByNumber(ec.ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to get current block")
 	}
+	var lastBlock uint64
+	if head.Number.Uint64() > ec.confirmationBlocks {
+		lastBlock = head.Number.Uint64() - ec.confirmationBlocks
+	}
 	var logs []types.Log
 	var nSuccess int
-	for {
-		var toBlock *big.Int
-		if currentBlock-fromBlock.Uint64() > blocksInBatch {
+	for fromBlock.Uint64() <= lastBlock {
+		toBlock := big.NewInt(int64(lastBlock))
+		if lastBlock-fromBlock.Uint64() > blocksInBatch {
 			toBlock = big.NewInt(int64(fromBlock.Uint64() + blocksInBatch))
-		} else { // no more batches are required -> setting toBlock to nil
-			toBlock = nil
 		}
 		_logs, _nSuccess, err := ec.fetchAndProcessEvents(fromBlock, toBlock, contractAbi)
 		if err != nil {
@@ -298,13 +385,15 @@ func (ec *eth1Client) syncSmartContractsEvents(fromBlock *big.Int) error {
 		}
 		nSuccess += _nSuccess
 		logs = append(logs, _logs...)
-		if toBlock == nil { // finished
+		if toBlock.Uint64() >= lastBlock { // finished
 			break
 		}
 		fromBlock = toBlock
 	}
+	ec.confirmedBlock = lastBlock
 	ec.logger.Debug("finished syncing registry contract",
-		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess))
+		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess),
+		zap.Uint64("lastBlock", lastBlock))
 	// publishing SyncEndedEvent so other components could track the sync
 	ec.fireEvent(types.Log{}, eth1.SyncEndedEvent{Logs: logs, Success: nSuccess == len(logs)})
 
@@ -382,6 +471,34 @@ func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) error {
 		if isEventBelongsToOperator || shareEncryptionKey == nil {
 			ec.fireEvent(vLog, *parsed)
 		}
+	case "ValidatorUpdated":
+		parsed, isEventBelongsToOperator, err := eth1.ParseValidatorUpdatedEvent(ec.logger, shareEncryptionKey, vLog.Data, contractAbi)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse ValidatorUpdated event")
+		}
+		if isEventBelongsToOperator {
+			ec.logger.Debug("updated validator is assigned to this operator",
+				zap.String("pubKey", hex.EncodeToString(parsed.PublicKey)))
+		}
+		// always triggered as operators that were dropped from the committee must remove the validator
+		ec.fireEvent(vLog, *parsed)
+	case "ValidatorRemoved":
+		parsed, err := eth1.ParseValidatorRemovedEvent(ec.logger, vLog.Data, contractAbi)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse ValidatorRemoved event")
+		}
+		// the event doesn't include the operators of the validator,
+		// therefore it is always triggered and the handler checks whether a share exist
+		ec.fireEvent(vLog, *parsed)
+	case "OperatorRemoved":
+		parsed, isEventBelongsToOperator, err := eth1.ParseOperatorRemovedEvent(ec.logger, shareEncryptionKey, vLog.Data, contractAbi)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse OperatorRemoved event")
+		}
+		// if there is no operator-private-key --> assuming that the event should be triggered (e.g. exporter)
+		if isEventBelongsToOperator || shareEncryptionKey == nil {
+			ec.fireEvent(vLog, *parsed)
+		}
 	default:
 		ec.logger.Debug("unknown contract event was received")
 	}
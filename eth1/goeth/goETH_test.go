@@ -2,14 +2,23 @@ package goeth
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/rsa"
+	"encoding/hex"
 	"encoding/json"
 	"github.com/bloxapp/ssv/eth1"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"math/big"
 	"strings"
 	"sync"
 	"testing"
@@ -60,6 +69,170 @@ func TestEth1Client_handleEvent(t *testing.T) {
 	eventsWg.Wait()
 }
 
+func TestEth1Client_Reorg(t *testing.T) {
+	sim := newSimulatedBackend(t)
+	ec := newEth1Client()
+	ec.conn = sim
+	ec.registryContractAddr = sim.contract.Hex()
+	ec.contractABI = eth1.ContractABI()
+	ec.confirmationBlocks = 2
+	events := subscribeToEvents(ec)
+
+	sim.emit(t, rawOperatorAdded) // block 2
+	sim.Commit()
+	sim.Commit()
+	sim.Commit()
+	sim.emit(t, rawValidatorAdded) // block 5
+	sim.Commit()
+
+	t.Run("sync up to confirmed block", func(t *testing.T) {
+		require.NoError(t, ec.Sync(big.NewInt(0)))
+		e := nextEvent(t, events)
+		require.IsType(t, eth1.OperatorAddedEvent{}, e.Data)
+		require.EqualValues(t, 2, e.Log.BlockNumber)
+		e = nextEvent(t, events)
+		require.IsType(t, eth1.SyncEndedEvent{}, e.Data)
+		require.Len(t, e.Data.(eth1.SyncEndedEvent).Logs, 1)
+		require.EqualValues(t, 3, ec.confirmedBlock)
+	})
+
+	t.Run("stream applies events once confirmed", func(t *testing.T) {
+		require.NoError(t, ec.Start())
+		time.Sleep(100 * time.Millisecond)
+		sim.Commit() // block 6
+		noEvent(t, events)
+		sim.Commit() // block 7
+		e := nextEvent(t, events)
+		require.IsType(t, eth1.ValidatorAddedEvent{}, e.Data)
+		require.EqualValues(t, 5, e.Log.BlockNumber)
+		require.False(t, e.Log.Removed)
+	})
+
+	t.Run("pending event dropped by reorg", func(t *testing.T) {
+		parent := sim.blockHash(t, 7)
+		sim.emit(t, rawOperatorAdded) // block 8
+		sim.Commit()
+		noEvent(t, events)
+
+		require.NoError(t, sim.Fork(context.Background(), parent))
+		sim.Commit() // block 8'
+		sim.Commit() // block 9', reorg
+		time.Sleep(100 * time.Millisecond)
+		sim.Commit() // block 10'
+		sim.Commit() // block 11'
+		noEvent(t, events)
+	})
+}
+
+func TestEth1Client_ReorgRollback(t *testing.T) {
+	sim := newSimulatedBackend(t)
+	ec := newEth1Client()
+	ec.conn = sim
+	ec.registryContractAddr = sim.contract.Hex()
+	ec.contractABI = eth1.ContractABI()
+	events := subscribeToEvents(ec)
+
+	require.NoError(t, ec.Start())
+	time.Sleep(100 * time.Millisecond)
+	parent := sim.blockHash(t, 1)
+	sim.emit(t, rawValidatorAdded) // block 2
+	sim.Commit()
+	e := nextEvent(t, events)
+	require.IsType(t, eth1.ValidatorAddedEvent{}, e.Data)
+	require.False(t, e.Log.Removed)
+
+	require.NoError(t, sim.Fork(context.Background(), parent))
+	sim.Commit() // block 2'
+	sim.Commit() // block 3', reorg
+	e = nextEvent(t, events)
+	require.IsType(t, eth1.ValidatorAddedEvent{}, e.Data)
+	require.True(t, e.Log.Removed)
+}
+
+// simulatedBackend wraps a simulated chain with a contract that emits the logs it receives
+type simulatedBackend struct {
+	*backends.SimulatedBackend
+	key      *ecdsa.PrivateKey
+	contract common.Address
+	nonce    uint64
+}
+
+// SyncProgress implements ethBackend, the simulated chain is never syncing
+func (sb *simulatedBackend) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	return nil, nil
+}
+
+func newSimulatedBackend(t *testing.T) *simulatedBackend {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sb := &simulatedBackend{
+		SimulatedBackend: backends.NewSimulatedBackend(core.GenesisAlloc{
+			crypto.PubkeyToAddress(key.PublicKey): {Balance: big.NewInt(1e18)},
+		}, 10000000),
+		key: key,
+	}
+	// the contract emits LOG1 with the first 32 bytes of the calldata as topic and the rest as data
+	code, err := hex.DecodeString("6012600c60003960126000f3" + "60203603806020600037600035906000a100")
+	require.NoError(t, err)
+	tx := sb.sendTx(t, nil, code)
+	sb.Commit() // block 1
+	receipt, err := sb.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	sb.contract = receipt.ContractAddress
+	return sb
+}
+
+func (sb *simulatedBackend) sendTx(t *testing.T, to *common.Address, data []byte) *types.Transaction {
+	tx, err := types.SignNewTx(sb.key, types.LatestSignerForChainID(big.NewInt(1337)), &types.LegacyTx{
+		Nonce:    sb.nonce,
+		GasPrice: big.NewInt(params.GWei),
+		Gas:      1000000,
+		To:       to,
+		Data:     data,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sb.SendTransaction(context.Background(), tx))
+	sb.nonce++
+	return tx
+}
+
+// emit sends a transaction that emits the given raw log from the contract
+func (sb *simulatedBackend) emit(t *testing.T, rawLog string) {
+	var vLog types.Log
+	require.NoError(t, json.Unmarshal([]byte(rawLog), &vLog))
+	sb.sendTx(t, &sb.contract, append(vLog.Topics[0].Bytes(), vLog.Data...))
+}
+
+func (sb *simulatedBackend) blockHash(t *testing.T, number int64) common.Hash {
+	block, err := sb.BlockByNumber(context.Background(), big.NewInt(number))
+	require.NoError(t, err)
+	return block.Hash()
+}
+
+func subscribeToEvents(ec *eth1Client) chan *eth1.Event {
+	cn := make(chan *eth1.Event, 10)
+	ec.EventsFeed().Subscribe(cn)
+	return cn
+}
+
+func nextEvent(t *testing.T, events chan *eth1.Event) *eth1.Event {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "event was not received")
+	}
+	return nil
+}
+
+func noEvent(t *testing.T, events chan *eth1.Event) {
+	select {
+	case e := <-events:
+		require.FailNow(t, "unexpected event", "%T", e.Data)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
 func newEth1Client() *eth1Client {
 	ec := eth1Client{
 		ctx:    context.TODO(),
@@ -0,0 +1,52 @@
+package goeth
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// pendingLogs holds logs that were received from the stream but are not deep enough to be applied,
+// it is used by a single goroutine and therefore is not thread-safe
+type pendingLogs struct {
+	logs []types.Log
+}
+
+// add adds the given log, duplicated logs are ignored
+func (pl *pendingLogs) add(vLog types.Log) {
+	if pl.indexOf(vLog) >= 0 {
+		return
+	}
+	pl.logs = append(pl.logs, vLog)
+}
+
+// remove removes a log that was dropped by a chain reorg, returns true if the log was pending
+func (pl *pendingLogs) remove(vLog types.Log) bool {
+	i := pl.indexOf(vLog)
+	if i < 0 {
+		return false
+	}
+	pl.logs = append(pl.logs[:i], pl.logs[i+1:]...)
+	return true
+}
+
+// popConfirmed removes and returns (by the order of arrival) the logs up to the given block number
+func (pl *pendingLogs) popConfirmed(blockNumber uint64) []types.Log {
+	var confirmed, pending []types.Log
+	for _, vLog := range pl.logs {
+		if vLog.BlockNumber <= blockNumber {
+			confirmed = append(confirmed, vLog)
+		} else {
+			pending = append(pending, vLog)
+		}
+	}
+	pl.logs = pending
+	return confirmed
+}
+
+func (pl *pendingLogs) indexOf(vLog types.Log) int {
+	for i, l := range pl.logs {
+		if l.BlockHash == vLog.BlockHash && l.TxHash == vLog.TxHash && l.Index == vLog.Index {
+			return i
+		}
+	}
+	return -1
+}
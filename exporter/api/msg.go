@@ -50,6 +50,10 @@ const (
 	RoleAggregator DutyRole = "AGGREGATOR"
 	// RoleProposer is an enum for proposer role
 	RoleProposer DutyRole = "PROPOSER"
+	// RoleSyncCommittee is an enum for sync committee role
+	RoleSyncCommittee DutyRole = "SYNC_COMMITTEE"
+	// RoleSyncCommitteeContribution is an enum for sync committee contribution role
+	RoleSyncCommitteeContribution DutyRole = "SYNC_COMMITTEE_CONTRIBUTION"
 )
 
 // ValidatorsMessage represents message for validators response
@@ -36,14 +36,36 @@ func (exp *exporter) listenToEth1Events(eventsFeed *event.Feed) <-chan error {
 // ListenToEth1Events register for eth1 events
 func (exp *exporter) handleEth1Event(e eth1.Event) error {
 	var err error = nil
+	if e.Log.Removed {
+		return exp.rollbackEth1Event(e)
+	}
 	if validatorAddedEvent, ok := e.Data.(eth1.ValidatorAddedEvent); ok {
 		err = exp.handleValidatorAddedEvent(validatorAddedEvent)
 	} else if opertaorAddedEvent, ok := e.Data.(eth1.OperatorAddedEvent); ok {
 		err = exp.handleOperatorAddedEvent(opertaorAddedEvent)
+	} else if validatorRemovedEvent, ok := e.Data.(eth1.ValidatorRemovedEvent); ok {
+		err = exp.handleValidatorRemovedEvent(validatorRemovedEvent)
+	} else if operatorRemovedEvent, ok := e.Data.(eth1.OperatorRemovedEvent); ok {
+		exp.logger.Info("operator removed event", zap.String("eventType", "OperatorRemoved"),
+			zap.String("pubKey", string(operatorRemovedEvent.PublicKey)))
 	}
 	return err
 }
 
+// rollbackEth1Event reverts an event that was dropped by a chain reorg,
+// added validators are marked as removed while other events are only logged
+func (exp *exporter) rollbackEth1Event(e eth1.Event) error {
+	if validatorAddedEvent, ok := e.Data.(eth1.ValidatorAddedEvent); ok {
+		return exp.handleValidatorRemovedEvent(eth1.ValidatorRemovedEvent{
+			OwnerAddress: validatorAddedEvent.OwnerAddress,
+			PublicKey:    validatorAddedEvent.PublicKey,
+		})
+	}
+	exp.logger.Warn("could not roll back contract event",
+		zap.String("txHash", e.Log.TxHash.Hex()), zap.Uint64("blockNumber", e.Log.BlockNumber))
+	return nil
+}
+
 // handleValidatorAddedEvent parses the given event and sync the ibft-data of the validator
 func (exp *exporter) handleValidatorAddedEvent(event eth1.ValidatorAddedEvent) error {
 	pubKeyHex := hex.EncodeToString(event.PublicKey)
@@ -94,6 +116,33 @@ func (exp *exporter) handleValidatorAddedEvent(event eth1.ValidatorAddedEvent) e
 	return nil
 }
 
+// handleValidatorRemovedEvent marks the given validator as removed
+func (exp *exporter) handleValidatorRemovedEvent(event eth1.ValidatorRemovedEvent) error {
+	pubKeyHex := hex.EncodeToString(event.PublicKey)
+	logger := exp.logger.With(zap.String("eventType", "ValidatorRemoved"), zap.String("pubKey", pubKeyHex))
+	logger.Info("validator removed event")
+	vi, found, err := exp.storage.MarkValidatorRemoved(pubKeyHex)
+	if err != nil {
+		return errors.Wrap(err, "failed to mark validator as removed")
+	}
+	if !found {
+		logger.Warn("could not find removed validator")
+		return nil
+	}
+	logger.Debug("validator was marked as removed")
+
+	go func() {
+		n := exp.ws.BroadcastFeed().Send(api.Message{
+			Type:   api.TypeValidator,
+			Filter: api.MessageFilter{From: vi.Index, To: vi.Index},
+			Data:   []storage.ValidatorInformation{*vi},
+		})
+		logger.Debug("msg was sent on outbound feed", zap.Int("num of subscribers", n))
+	}()
+
+	return nil
+}
+
 // handleOperatorAddedEvent parses the given event and saves operator information
 func (exp *exporter) handleOperatorAddedEvent(event eth1.OperatorAddedEvent) error {
 	logger := exp.logger.With(zap.String("eventType", "OperatorAdded"),
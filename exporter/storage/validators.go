@@ -16,6 +16,8 @@ type ValidatorInformation struct {
 	Index     int64              `json:"index"`
 	PublicKey string             `json:"publicKey"`
 	Operators []OperatorNodeLink `json:"operators"`
+	// Removed is true once the validator was removed from the registry contract
+	Removed bool `json:"removed"`
 }
 
 // ValidatorsCollection is the interface for managing validators information
@@ -23,6 +25,7 @@ type ValidatorsCollection interface {
 	GetValidatorInformation(validatorPubKey string) (*ValidatorInformation, bool, error)
 	SaveValidatorInformation(validatorInformation *ValidatorInformation) error
 	ListValidators(from int64, to int64) ([]ValidatorInformation, error)
+	MarkValidatorRemoved(validatorPubKey string) (*ValidatorInformation, bool, error)
 }
 
 // OperatorNodeLink links a validator to an operator
@@ -89,6 +92,10 @@ func (es *exporterStorage) SaveValidatorInformation(validatorInformation *Valida
 		es.logger.Debug("validator already exist",
 			zap.String("pubKey", validatorInformation.PublicKey))
 		validatorInformation.Index = info.Index
+		// a validator that was removed and added again is saved with the new information
+		if info.Removed {
+			return es.saveValidatorNotSafe(validatorInformation)
+		}
 		// TODO: update validator information (i.e. change operator)
 		return nil
 	}
@@ -99,6 +106,25 @@ func (es *exporterStorage) SaveValidatorInformation(validatorInformation *Valida
 	return es.saveValidatorNotSafe(validatorInformation)
 }
 
+// MarkValidatorRemoved marks the given validator as removed, the information is kept to preserve indices
+func (es *exporterStorage) MarkValidatorRemoved(validatorPubKey string) (*ValidatorInformation, bool, error) {
+	es.validatorsLock.Lock()
+	defer es.validatorsLock.Unlock()
+
+	info, found, err := es.getValidatorInformationNotSafe(validatorPubKey)
+	if err != nil {
+		return nil, found, errors.Wrap(err, "could not read information from DB")
+	}
+	if !found {
+		return nil, false, nil
+	}
+	info.Removed = true
+	if err := es.saveValidatorNotSafe(info); err != nil {
+		return nil, true, errors.Wrap(err, "could not save validator information")
+	}
+	return info, true, nil
+}
+
 func (es *exporterStorage) saveValidatorNotSafe(val *ValidatorInformation) error {
 	raw, err := json.Marshal(val)
 	if err != nil {
@@ -96,6 +96,34 @@ func TestStorage_SaveAndGetValidatorInformation(t *testing.T) {
 			i++
 		}
 	})
+
+	t.Run("mark validator removed", func(t *testing.T) {
+		removed, found, err := storage.MarkValidatorRemoved(validatorInfo.PublicKey)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.True(t, removed.Removed)
+		validatorInfoFromDB, _, err := storage.GetValidatorInformation(validatorInfo.PublicKey)
+		require.NoError(t, err)
+		require.True(t, validatorInfoFromDB.Removed)
+		require.Equal(t, int64(0), validatorInfoFromDB.Index)
+
+		_, found, err = storage.MarkValidatorRemoved("dummyPK")
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+
+	t.Run("add removed validator", func(t *testing.T) {
+		vi := ValidatorInformation{
+			PublicKey: validatorInfo.PublicKey,
+			Operators: validatorInfo.Operators[1:],
+		}
+		require.NoError(t, storage.SaveValidatorInformation(&vi))
+		validatorInfoFromDB, _, err := storage.GetValidatorInformation(validatorInfo.PublicKey)
+		require.NoError(t, err)
+		require.False(t, validatorInfoFromDB.Removed)
+		require.Equal(t, int64(0), validatorInfoFromDB.Index)
+		require.Equal(t, 3, len(validatorInfoFromDB.Operators))
+	})
 }
 
 func TestStorage_ListValidators(t *testing.T) {
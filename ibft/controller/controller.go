@@ -32,8 +32,12 @@ type Controller struct {
 	// flags
 	initFinished bool
 
+	// stopCh is closed once the controller was stopped
+	stopCh   chan struct{}
+	stopOnce sync.Once
+
 	// locks
-	currentInstanceLock sync.Locker
+	currentInstanceLock sync.RWMutex
 	syncingLock         *semaphore.Weighted
 }
 
@@ -64,9 +68,10 @@ func New(
 		// flags
 		initFinished: false,
 
+		stopCh: make(chan struct{}),
+
 		// locks
-		currentInstanceLock: &sync.Mutex{},
-		syncingLock:         semaphore.NewWeighted(1),
+		syncingLock: semaphore.NewWeighted(1),
 	}
 
 	ret.setFork(fork)
@@ -120,6 +125,44 @@ func (i *Controller) StartInstance(opts ibft.ControllerStartInstanceOptions) (re
 	return res, err
 }
 
+// Stop stops the running instance (if any) and all the background processes of the controller,
+// the controller can't be used once stopped
+func (i *Controller) Stop() {
+	i.stopOnce.Do(func() {
+		close(i.stopCh)
+		if currentInstance := i.getCurrentInstance(); currentInstance != nil {
+			currentInstance.Stop()
+		}
+		i.logger.Info("iBFT controller stopped")
+	})
+}
+
+// getCurrentInstance returns the running instance, nil if there is no running instance
+func (i *Controller) getCurrentInstance() ibft.Instance {
+	i.currentInstanceLock.RLock()
+	defer i.currentInstanceLock.RUnlock()
+
+	return i.currentInstance
+}
+
+// setCurrentInstance sets the running instance
+func (i *Controller) setCurrentInstance(instance ibft.Instance) {
+	i.currentInstanceLock.Lock()
+	defer i.currentInstanceLock.Unlock()
+
+	i.currentInstance = instance
+}
+
+// stopped returns true if the controller was stopped
+func (i *Controller) stopped() bool {
+	select {
+	case <-i.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetIBFTCommittee returns a map of the iBFT committee where the key is the member's id.
 func (i *Controller) GetIBFTCommittee() map[uint64]*proto.Node {
 	return i.ValidatorShare.Committee
@@ -14,7 +14,7 @@ import (
 // processDecidedQueueMessages is listen for all the ibft decided msg's and process them
 func (i *Controller) processDecidedQueueMessages() {
 	go func() {
-		for {
+		for !i.stopped() {
 			if decidedMsg := i.msgQueue.PopMessage(msgqueue.DecidedIndexKey(i.GetIdentifier())); decidedMsg != nil {
 				i.ProcessDecidedMessage(decidedMsg.SignedMessage)
 			}
@@ -105,8 +105,8 @@ func (i *Controller) ProcessDecidedMessage(msg *proto.SignedMessage) {
 func (i *Controller) forceDecideCurrentInstance(msg *proto.SignedMessage) bool {
 	if i.decidedForCurrentInstance(msg) {
 		// stop current instance
-		if i.currentInstance != nil {
-			i.currentInstance.ForceDecide(msg)
+		if currentInstance := i.getCurrentInstance(); currentInstance != nil {
+			currentInstance.ForceDecide(msg)
 		}
 		return true
 	}
@@ -148,7 +148,8 @@ func (i *Controller) checkDecidedMessageSigners(msg *proto.SignedMessage) (bool,
 
 // decidedForCurrentInstance returns true if msg has same seq number is current instance
 func (i *Controller) decidedForCurrentInstance(msg *proto.SignedMessage) bool {
-	return i.currentInstance != nil && i.currentInstance.State().SeqNumber.Get() == msg.Message.SeqNumber
+	currentInstance := i.getCurrentInstance()
+	return currentInstance != nil && currentInstance.State().SeqNumber.Get() == msg.Message.SeqNumber
 }
 
 // decidedRequiresSync returns true if:
@@ -39,12 +39,20 @@ func (i *Controller) listenToNetworkMessages() {
 	msgChan, done := i.network.ReceivedMsgChan()
 	go func() {
 		defer done()
-		for msg := range msgChan {
-			if msg.Message != nil && i.equalIdentifier(msg.Message.Lambda) {
-				i.msgQueue.AddMessage(&network.Message{
-					SignedMessage: msg,
-					Type:          network.NetworkMsg_IBFTType,
-				})
+		for {
+			select {
+			case <-i.stopCh:
+				return
+			case msg, ok := <-msgChan:
+				if !ok {
+					return
+				}
+				if msg.Message != nil && i.equalIdentifier(msg.Message.Lambda) {
+					i.msgQueue.AddMessage(&network.Message{
+						SignedMessage: msg,
+						Type:          network.NetworkMsg_IBFTType,
+					})
+				}
 			}
 		}
 	}()
@@ -54,12 +62,20 @@ func (i *Controller) listenToNetworkDecidedMessages() {
 	decidedChan, done := i.network.ReceivedDecidedChan()
 	go func() {
 		defer done()
-		for msg := range decidedChan {
-			if msg.Message != nil && i.equalIdentifier(msg.Message.Lambda) {
-				i.msgQueue.AddMessage(&network.Message{
-					SignedMessage: msg,
-					Type:          network.NetworkMsg_DecidedType,
-				})
+		for {
+			select {
+			case <-i.stopCh:
+				return
+			case msg, ok := <-decidedChan:
+				if !ok {
+					return
+				}
+				if msg.Message != nil && i.equalIdentifier(msg.Message.Lambda) {
+					i.msgQueue.AddMessage(&network.Message{
+						SignedMessage: msg,
+						Type:          network.NetworkMsg_DecidedType,
+					})
+				}
 			}
 		}
 	}()
@@ -69,13 +85,21 @@ func (i *Controller) listenToSyncMessages() {
 	syncChan, done := i.network.ReceivedSyncMsgChan()
 	go func() {
 		defer done()
-		for msg := range syncChan {
-			if msg.Msg != nil && i.equalIdentifier(msg.Msg.Lambda) {
-				i.msgQueue.AddMessage(&network.Message{
-					SyncMessage: msg.Msg,
-					Stream:      msg.Stream,
-					Type:        network.NetworkMsg_SyncType,
-				})
+		for {
+			select {
+			case <-i.stopCh:
+				return
+			case msg, ok := <-syncChan:
+				if !ok {
+					return
+				}
+				if msg.Msg != nil && i.equalIdentifier(msg.Msg.Lambda) {
+					i.msgQueue.AddMessage(&network.Message{
+						SyncMessage: msg.Msg,
+						Stream:      msg.Stream,
+						Type:        network.NetworkMsg_SyncType,
+					})
+				}
 			}
 		}
 	}()
@@ -18,20 +18,21 @@ import (
 // startInstanceWithOptions will start an iBFT instance with the provided options.
 // Does not pre-check instance validity and start validity!
 func (i *Controller) startInstanceWithOptions(instanceOpts *instance.InstanceOptions, value []byte) (*ibft.InstanceResult, error) {
-	i.currentInstance = instance.NewInstance(instanceOpts)
-	i.currentInstance.Init()
-	stageChan := i.currentInstance.GetStageChan()
+	currentInstance := instance.NewInstance(instanceOpts)
+	i.setCurrentInstance(currentInstance)
+	currentInstance.Init()
+	stageChan := currentInstance.GetStageChan()
 
 	// reset leader seed for sequence
-	if err := i.currentInstance.Start(value); err != nil {
+	if err := currentInstance.Start(value); err != nil {
 		return nil, errors.WithMessage(err, "could not start iBFT instance")
 	}
 
 	pk, role := format.IdentifierUnformat(string(i.Identifier))
-	metricsCurrentSequence.WithLabelValues(role, pk).Set(float64(i.currentInstance.State().SeqNumber.Get()))
+	metricsCurrentSequence.WithLabelValues(role, pk).Set(float64(currentInstance.State().SeqNumber.Get()))
 
 	// catch up if we can
-	go i.fastChangeRoundCatchup(i.currentInstance)
+	go i.fastChangeRoundCatchup(currentInstance)
 
 	// main instance callback loop
 	var retRes *ibft.InstanceResult
@@ -39,11 +40,11 @@ func (i *Controller) startInstanceWithOptions(instanceOpts *instance.InstanceOpt
 instanceLoop:
 	for {
 		stage := <-stageChan
-		if i.currentInstance == nil {
+		if i.getCurrentInstance() == nil {
 			i.logger.Debug("stage channel was invoked but instance is already empty", zap.Any("stage", stage))
 			break instanceLoop
 		}
-		exit, e := i.instanceStageChange(stage)
+		exit, e := i.instanceStageChange(currentInstance, stage)
 		if e != nil {
 			err = e
 			break instanceLoop
@@ -72,9 +73,9 @@ instanceLoop:
 		}
 	}
 	// saves state as instance will be cleared
-	seq := i.currentInstance.State().SeqNumber.Get()
+	seq := currentInstance.State().SeqNumber.Get()
 	// when main instance loop breaks, nil current instance
-	i.currentInstance = nil
+	i.setCurrentInstance(nil)
 	i.logger.Debug("iBFT instance result loop stopped")
 
 	i.afterInstance(seq, retRes, err)
@@ -93,14 +94,14 @@ func (i *Controller) afterInstance(seq uint64, res *ibft.InstanceResult, err err
 }
 
 // instanceStageChange processes a stage change for the current instance, returns true if requires stopping the instance after stage process.
-func (i *Controller) instanceStageChange(stage proto.RoundState) (bool, error) {
+func (i *Controller) instanceStageChange(currentInstance ibft.Instance, stage proto.RoundState) (bool, error) {
 	switch stage {
 	case proto.RoundState_Prepare:
-		if err := i.ibftStorage.SaveCurrentInstance(i.GetIdentifier(), i.currentInstance.State()); err != nil {
+		if err := i.ibftStorage.SaveCurrentInstance(i.GetIdentifier(), currentInstance.State()); err != nil {
 			return true, errors.Wrap(err, "could not save prepare msg to storage")
 		}
 	case proto.RoundState_Decided:
-		agg, err := i.currentInstance.CommittedAggregatedMsg()
+		agg, err := currentInstance.CommittedAggregatedMsg()
 		if err != nil {
 			return true, errors.Wrap(err, "could not get aggregated commit msg and save to storage")
 		}
@@ -116,7 +117,7 @@ func (i *Controller) instanceStageChange(stage proto.RoundState) (bool, error) {
 		i.logger.Info("decided current instance", zap.String("identifier", string(agg.Message.Lambda)), zap.Uint64("seqNum", agg.Message.SeqNumber))
 		return false, nil
 	case proto.RoundState_Stopped:
-		i.logger.Info("current iBFT instance stopped, nilling currentInstance", zap.Uint64("seqNum", i.currentInstance.State().SeqNumber.Get()))
+		i.logger.Info("current iBFT instance stopped, nilling currentInstance", zap.Uint64("seqNum", currentInstance.State().SeqNumber.Get()))
 		return true, nil
 	}
 	return false, nil
@@ -18,8 +18,11 @@ func (i *Controller) canStartNewInstance(opts instance.InstanceOptions) error {
 	if !i.initFinished {
 		return errors.New("iBFT hasn't initialized yet")
 	}
-	if i.currentInstance != nil {
-		return errors.Errorf("current instance (%d) is still running", i.currentInstance.State().SeqNumber.Get())
+	if i.stopped() {
+		return errors.New("iBFT controller was stopped")
+	}
+	if currentInstance := i.getCurrentInstance(); currentInstance != nil {
+		return errors.Errorf("current instance (%d) is still running", currentInstance.State().SeqNumber.Get())
 	}
 
 	highestKnown, err := i.highestKnownDecided()
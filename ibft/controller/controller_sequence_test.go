@@ -167,3 +167,28 @@ func TestCanStartNewInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestCanStartNewInstanceAfterStop(t *testing.T) {
+	sks, nodes := GenerateNodes(4)
+
+	i := testIBFTInstance(t)
+	i.stopCh = make(chan struct{})
+	i.logger = zap.L()
+	i.initFinished = true
+	i.ibftStorage = populatedStorage(t, sks, 10)
+	i.ValidatorShare = &validatorstorage.Share{
+		NodeID:    1,
+		PublicKey: validatorPK(sks),
+		Committee: nodes,
+	}
+	i.instanceConfig = proto.DefaultConsensusParams()
+	instanceOpts, err := i.instanceOptionsFromStartOptions(ibft.ControllerStartInstanceOptions{
+		SeqNumber: 11,
+	})
+	require.NoError(t, err)
+	require.NoError(t, i.canStartNewInstance(*instanceOpts))
+
+	i.Stop()
+	i.Stop() // stopping twice is a no-op
+	require.EqualError(t, i.canStartNewInstance(*instanceOpts), "iBFT controller was stopped")
+}
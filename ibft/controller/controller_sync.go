@@ -17,7 +17,7 @@ const syncRetries = 3
 // processSyncQueueMessages is listen for all the ibft sync msg's and process them
 func (i *Controller) processSyncQueueMessages() {
 	go func() {
-		for {
+		for !i.stopped() {
 			if syncMsg := i.msgQueue.PopMessage(msgqueue.SyncIndexKey(i.Identifier)); syncMsg != nil {
 				i.ProcessSyncMessage(&network.SyncChanObj{
 					Msg:    syncMsg.SyncMessage,
@@ -34,9 +34,9 @@ func (i *Controller) processSyncQueueMessages() {
 func (i *Controller) ProcessSyncMessage(msg *network.SyncChanObj) {
 	var lastChangeRoundMsg *proto.SignedMessage
 	currentInstaceSeqNumber := int64(-1)
-	if i.currentInstance != nil {
-		lastChangeRoundMsg = i.currentInstance.GetLastChangeRoundMsg()
-		currentInstaceSeqNumber = int64(i.currentInstance.State().SeqNumber.Get())
+	if currentInstance := i.getCurrentInstance(); currentInstance != nil {
+		lastChangeRoundMsg = currentInstance.GetLastChangeRoundMsg()
+		currentInstaceSeqNumber = int64(currentInstance.State().SeqNumber.Get())
 	}
 	s := incoming.New(i.logger, i.Identifier, currentInstaceSeqNumber, i.network, i.ibftStorage, lastChangeRoundMsg)
 	go s.Process(msg)
@@ -52,8 +52,8 @@ func (i *Controller) SyncIBFT() error {
 	i.logger.Info("syncing iBFT..")
 
 	// stop current instance and return any waiting chan.
-	if i.currentInstance != nil {
-		i.currentInstance.Stop()
+	if currentInstance := i.getCurrentInstance(); currentInstance != nil {
+		currentInstance.Stop()
 	}
 
 	return i.syncIBFT()
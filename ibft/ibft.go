@@ -43,6 +43,9 @@ type Controller interface {
 
 	// GetIdentifier returns ibft identifier made of public key and role (type)
 	GetIdentifier() []byte
+
+	// Stop stops the controller and its running instance, used once the validator was removed
+	Stop()
 }
 
 // Instance represents an iBFT instance (a single sequence number)
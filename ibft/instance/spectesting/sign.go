@@ -2,6 +2,8 @@ package spectesting
 
 import (
 	"encoding/hex"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/herumi/bls-eth-go-binary/bls"
@@ -42,6 +44,15 @@ func (km *testKM) AddShare(shareKey *bls.SecretKey) error {
 	return nil
 }
 
+func (km *testKM) RemoveShare(pubKey string) error {
+	delete(km.keys, pubKey)
+	return nil
+}
+
+func (km *testKM) RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error) {
+	return nil, nil
+}
+
 func (km *testKM) getKey(key *bls.PublicKey) *bls.SecretKey {
 	return km.keys[key.SerializeToHexStr()]
 }
@@ -60,3 +71,35 @@ func (km *testKM) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, er
 func (km *testKM) SignAttestation(data *spec.AttestationData, duty *beacon.Duty, pk []byte) (*spec.Attestation, []byte, error) {
 	return nil, nil, nil
 }
+
+func (km *testKM) SignRandaoReveal(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testKM) SignBeaconBlock(block *eth2spec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*eth2spec.VersionedSignedBeaconBlock, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testKM) SignSelectionProof(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testKM) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testKM) SignSyncCommitteeMessage(blockRoot spec.Root, duty *beacon.Duty, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testKM) SignSyncCommitteeSelectionProof(subcommitteeIndex uint64, duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testKM) SignContributionAndProof(msg *altair.ContributionAndProof, duty *beacon.Duty, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (km *testKM) SignVoluntaryExit(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"encoding/hex"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/ibft"
@@ -71,6 +73,14 @@ func (s *testSigner) AddShare(shareKey *bls.SecretKey) error {
 	return nil
 }
 
+func (s *testSigner) RemoveShare(pubKey string) error {
+	return nil
+}
+
+func (s *testSigner) RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error) {
+	return nil, nil
+}
+
 func (s *testSigner) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	return nil, nil
 }
@@ -79,6 +89,38 @@ func (s *testSigner) SignAttestation(data *spec.AttestationData, duty *beacon.Du
 	return nil, nil, nil
 }
 
+func (s *testSigner) SignRandaoReveal(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (s *testSigner) SignBeaconBlock(block *eth2spec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*eth2spec.VersionedSignedBeaconBlock, []byte, error) {
+	return nil, nil, nil
+}
+
+func (s *testSigner) SignSelectionProof(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (s *testSigner) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (s *testSigner) SignSyncCommitteeMessage(blockRoot spec.Root, duty *beacon.Duty, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	return nil, nil, nil
+}
+
+func (s *testSigner) SignSyncCommitteeSelectionProof(subcommitteeIndex uint64, duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
+func (s *testSigner) SignContributionAndProof(msg *altair.ContributionAndProof, duty *beacon.Duty, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	return nil, nil, nil
+}
+
+func (s *testSigner) SignVoluntaryExit(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return nil, nil, nil
+}
+
 func db() collections.Iibft {
 	db, err := storage.GetStorageFactory(basedb.Options{
 		Type:   "badger-memory",
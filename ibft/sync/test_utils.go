@@ -136,6 +136,16 @@ func (n *TestNetwork) ReceivedSignatureChan() (<-chan *proto.SignedMessage, func
 	return nil, func() {}
 }
 
+// BroadcastPreConsensusSignature impl
+func (n *TestNetwork) BroadcastPreConsensusSignature(topicName []byte, msg *proto.SignedMessage) error {
+	return nil
+}
+
+// ReceivedPreConsensusSignatureChan impl
+func (n *TestNetwork) ReceivedPreConsensusSignatureChan() (<-chan *proto.SignedMessage, func()) {
+	return nil, func() {}
+}
+
 // BroadcastDecided impl
 func (n *TestNetwork) BroadcastDecided(topicName []byte, msg *proto.SignedMessage) error {
 	return nil
@@ -274,6 +284,14 @@ func (n *TestNetwork) SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) er
 	return nil
 }
 
+// UnsubscribeFromValidatorNetwork implementation
+func (n *TestNetwork) UnsubscribeFromValidatorNetwork(validatorPk *bls.PublicKey) error {
+	return nil
+}
+
+// RegisterMsgValidator implementation
+func (n *TestNetwork) RegisterMsgValidator(validator network.MsgValidator) {}
+
 // AllPeers returns all connected peers for a validator PK
 func (n *TestNetwork) AllPeers(validatorPk []byte) ([]string, error) {
 	return n.peers, nil
@@ -10,7 +10,10 @@ type Fork interface {
 }
 
 type pubSubMapping interface {
+	// ValidatorTopicID returns the topic that is used to broadcast messages of the given validator
 	ValidatorTopicID(pk []byte) string
+	// ValidatorTopicIDs returns the topics that should be subscribed for the given validator
+	ValidatorTopicIDs(pk []byte) []string
 }
 
 type encoding interface {
@@ -8,3 +8,8 @@ import (
 func (v0 *ForkV0) ValidatorTopicID(pkByts []byte) string {
 	return hex.EncodeToString(pkByts)
 }
+
+// ValidatorTopicIDs - genesis version 0
+func (v0 *ForkV0) ValidatorTopicIDs(pkByts []byte) []string {
+	return []string{v0.ValidatorTopicID(pkByts)}
+}
@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// MaxMessageSize is the maximum size of an uncompressed network message
+const MaxMessageSize = 1 << 20
+
+// the encoded message is a snappy compressed protobuf of the following schema:
+//
+//	message Message {
+//	  NetworkMsg Type                   = 1;
+//	  proto.SignedMessage SignedMessage = 2;
+//	  SyncMessage SyncMessage           = 3;
+//	}
+const (
+	typeField          protowire.Number = 1
+	signedMessageField protowire.Number = 2
+	syncMessageField   protowire.Number = 3
+)
+
+// EncodeNetworkMsg encodes the message with protobuf + snappy once the fork is active
+func (v1 *ForkV1) EncodeNetworkMsg(msg *network.Message) ([]byte, error) {
+	if !v1.isActive() {
+		return v1.Fork.EncodeNetworkMsg(msg)
+	}
+	raw, err := marshalNetworkMsg(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > MaxMessageSize {
+		return nil, errors.Errorf("message is too big: %d bytes", len(raw))
+	}
+	return snappy.Encode(nil, raw), nil
+}
+
+// DecodeNetworkMsg decodes both json and protobuf + snappy messages
+func (v1 *ForkV1) DecodeNetworkMsg(data []byte) (*network.Message, error) {
+	// json messages always start with '{', which is also a valid (but rare) snappy header
+	if len(data) > 0 && data[0] == '{' {
+		if msg, err := v1.Fork.DecodeNetworkMsg(data); err == nil {
+			return msg, nil
+		}
+	}
+	if len(data) > snappy.MaxEncodedLen(MaxMessageSize) {
+		return nil, errors.Errorf("message is too big: %d bytes", len(data))
+	}
+	n, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read message length")
+	}
+	if n > MaxMessageSize {
+		return nil, errors.Errorf("message is too big: %d bytes", n)
+	}
+	raw, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress message")
+	}
+	return unmarshalNetworkMsg(raw)
+}
+
+func marshalNetworkMsg(msg *network.Message) ([]byte, error) {
+	var b []byte
+	b = protowire.AppendTag(b, typeField, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(msg.Type))
+	if msg.SignedMessage != nil {
+		data, err := protobuf.Marshal(msg.SignedMessage)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal signed message")
+		}
+		b = protowire.AppendTag(b, signedMessageField, protowire.BytesType)
+		b = protowire.AppendBytes(b, data)
+	}
+	if msg.SyncMessage != nil {
+		data, err := protobuf.Marshal(msg.SyncMessage)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal sync message")
+		}
+		b = protowire.AppendTag(b, syncMessageField, protowire.BytesType)
+		b = protowire.AppendBytes(b, data)
+	}
+	return b, nil
+}
+
+func unmarshalNetworkMsg(b []byte) (*network.Message, error) {
+	msg := &network.Message{}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errors.Wrap(protowire.ParseError(n), "could not parse field tag")
+		}
+		b = b[n:]
+		switch {
+		case num == typeField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return nil, errors.Wrap(protowire.ParseError(n), "could not parse message type")
+			}
+			msg.Type = network.NetworkMsg(v)
+			b = b[n:]
+		case num == signedMessageField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return nil, errors.Wrap(protowire.ParseError(n), "could not parse signed message")
+			}
+			msg.SignedMessage = &proto.SignedMessage{}
+			if err := protobuf.Unmarshal(v, msg.SignedMessage); err != nil {
+				return nil, errors.Wrap(err, "could not unmarshal signed message")
+			}
+			b = b[n:]
+		case num == syncMessageField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return nil, errors.Wrap(protowire.ParseError(n), "could not parse sync message")
+			}
+			msg.SyncMessage = &network.SyncMessage{}
+			if err := protobuf.Unmarshal(v, msg.SyncMessage); err != nil {
+				return nil, errors.Wrap(err, "could not unmarshal sync message")
+			}
+			b = b[n:]
+		default: // unknown fields are skipped
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return nil, errors.Wrap(protowire.ParseError(n), "could not parse unknown field")
+			}
+			b = b[n:]
+		}
+	}
+	return msg, nil
+}
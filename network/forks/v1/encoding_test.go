@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testSignedMessage() *proto.SignedMessage {
+	return &proto.SignedMessage{
+		Message: &proto.Message{
+			Type:      proto.RoundState_Commit,
+			Round:     2,
+			Lambda:    []byte("lambda_11"),
+			SeqNumber: 3,
+			Value:     []byte("value"),
+		},
+		Signature: make([]byte, 96),
+		SignerIds: []uint64{1, 2, 3},
+	}
+}
+
+func TestForkV1_EncodeNetworkMsg(t *testing.T) {
+	fork := New(10)
+	msg := &network.Message{
+		SignedMessage: testSignedMessage(),
+		Type:          network.NetworkMsg_DecidedType,
+	}
+
+	jsonData, err := fork.EncodeNetworkMsg(msg)
+	require.NoError(t, err)
+	require.EqualValues(t, '{', jsonData[0])
+
+	fork.SlotTick(10)
+	data, err := fork.EncodeNetworkMsg(msg)
+	require.NoError(t, err)
+	require.NotEqualValues(t, '{', data[0])
+	require.Less(t, len(data), len(jsonData))
+
+	t.Run("decode protobuf", func(t *testing.T) {
+		res, err := fork.DecodeNetworkMsg(data)
+		require.NoError(t, err)
+		require.EqualValues(t, network.NetworkMsg_DecidedType, res.Type)
+		require.Nil(t, res.SyncMessage)
+		require.EqualValues(t, msg.SignedMessage.Message.Lambda, res.SignedMessage.Message.Lambda)
+		require.EqualValues(t, msg.SignedMessage.Message.Value, res.SignedMessage.Message.Value)
+		require.EqualValues(t, msg.SignedMessage.SignerIds, res.SignedMessage.SignerIds)
+	})
+
+	t.Run("decode json", func(t *testing.T) {
+		res, err := fork.DecodeNetworkMsg(jsonData)
+		require.NoError(t, err)
+		require.EqualValues(t, network.NetworkMsg_DecidedType, res.Type)
+		require.EqualValues(t, msg.SignedMessage.Message.Lambda, res.SignedMessage.Message.Lambda)
+	})
+
+	t.Run("sync message", func(t *testing.T) {
+		syncMsg := &network.Message{
+			SyncMessage: &network.SyncMessage{
+				SignedMessages: []*proto.SignedMessage{testSignedMessage()},
+				FromPeerID:     "peer",
+				Params:         []uint64{1, 5},
+				Lambda:         []byte("lambda_11"),
+				Type:           network.Sync_GetInstanceRange,
+			},
+			Type: network.NetworkMsg_SyncType,
+		}
+		data, err := fork.EncodeNetworkMsg(syncMsg)
+		require.NoError(t, err)
+		res, err := fork.DecodeNetworkMsg(data)
+		require.NoError(t, err)
+		require.EqualValues(t, network.NetworkMsg_SyncType, res.Type)
+		require.Nil(t, res.SignedMessage)
+		require.EqualValues(t, "peer", res.SyncMessage.FromPeerID)
+		require.EqualValues(t, []uint64{1, 5}, res.SyncMessage.Params)
+		require.EqualValues(t, network.Sync_GetInstanceRange, res.SyncMessage.Type)
+		require.Len(t, res.SyncMessage.SignedMessages, 1)
+	})
+}
+
+func TestForkV1_MaxMessageSize(t *testing.T) {
+	fork := New(0)
+	fork.SlotTick(1)
+
+	msg := &network.Message{
+		SignedMessage: testSignedMessage(),
+		Type:          network.NetworkMsg_IBFTType,
+	}
+	msg.SignedMessage.Message.Value = make([]byte, MaxMessageSize)
+	_, err := fork.EncodeNetworkMsg(msg)
+	require.Error(t, err)
+
+	_, err = fork.DecodeNetworkMsg(snappy.Encode(nil, make([]byte, MaxMessageSize+1)))
+	require.EqualError(t, err, "message is too big: 1048577 bytes")
+
+	_, err = fork.DecodeNetworkMsg([]byte{1, 2, 3})
+	require.Error(t, err)
+}
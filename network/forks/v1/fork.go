@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"github.com/bloxapp/ssv/network/forks"
+	v0 "github.com/bloxapp/ssv/network/forks/v0"
+	"sync/atomic"
+)
+
+// ForkV1 is the fork that changes the encoding of network messages from json to protobuf + snappy,
+// json messages are still decoded so the network could transition smoothly
+type ForkV1 struct {
+	forks.Fork // v0 fork, used for pubsub mapping and json encoding
+
+	forkSlot    uint64
+	currentSlot uint64
+}
+
+// New returns an instance of ForkV1 that will be activated on the given slot
+func New(forkSlot uint64) forks.Fork {
+	return &ForkV1{
+		Fork:     v0.New(),
+		forkSlot: forkSlot,
+	}
+}
+
+// SlotTick implementation
+func (v1 *ForkV1) SlotTick(slot uint64) {
+	atomic.StoreUint64(&v1.currentSlot, slot)
+	v1.Fork.SlotTick(slot)
+}
+
+// isActive returns true once the fork slot was reached
+func (v1 *ForkV1) isActive() bool {
+	return atomic.LoadUint64(&v1.currentSlot) >= v1.forkSlot
+}
@@ -0,0 +1,37 @@
+package v2
+
+import (
+	"github.com/bloxapp/ssv/network/forks"
+	"sync/atomic"
+)
+
+// DefaultSubnetsCount is the number of subnets that is used if not configured
+const DefaultSubnetsCount = 128
+
+// ForkV2 is the fork that maps validators into a fixed number of subnet topics,
+// the previous fork is used for encoding and for topics mapping before the fork slot
+type ForkV2 struct {
+	forks.Fork // previous fork
+
+	forkSlot     uint64
+	subnetsCount uint64
+	currentSlot  uint64
+}
+
+// New returns an instance of ForkV2 that will be activated on the given slot
+func New(prevFork forks.Fork, forkSlot uint64, subnetsCount uint64) forks.Fork {
+	if subnetsCount == 0 {
+		subnetsCount = DefaultSubnetsCount
+	}
+	return &ForkV2{
+		Fork:         prevFork,
+		forkSlot:     forkSlot,
+		subnetsCount: subnetsCount,
+	}
+}
+
+// SlotTick implementation
+func (v2 *ForkV2) SlotTick(slot uint64) {
+	atomic.StoreUint64(&v2.currentSlot, slot)
+	v2.Fork.SlotTick(slot)
+}
@@ -0,0 +1,40 @@
+package v2
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+)
+
+// migrationSlots is the number of slots before and after the fork slot,
+// where both the topics of the previous fork and the subnets are subscribed
+const migrationSlots = 32
+
+// ValidatorTopicID returns the subnet of the validator once the fork is active
+func (v2 *ForkV2) ValidatorTopicID(pkByts []byte) string {
+	if atomic.LoadUint64(&v2.currentSlot) < v2.forkSlot {
+		return v2.Fork.ValidatorTopicID(pkByts)
+	}
+	return v2.subnetTopicID(pkByts)
+}
+
+// ValidatorTopicIDs returns the topics of the previous fork and the subnet of the validator during the migration,
+// once it is done only the subnet is returned
+func (v2 *ForkV2) ValidatorTopicIDs(pkByts []byte) []string {
+	slot := atomic.LoadUint64(&v2.currentSlot)
+	if slot+migrationSlots < v2.forkSlot {
+		return v2.Fork.ValidatorTopicIDs(pkByts)
+	}
+	subnet := v2.subnetTopicID(pkByts)
+	if slot >= v2.forkSlot+migrationSlots {
+		return []string{subnet}
+	}
+	return append(v2.Fork.ValidatorTopicIDs(pkByts), subnet)
+}
+
+// subnetTopicID hashes the given public key into one of the subnets
+func (v2 *ForkV2) subnetTopicID(pkByts []byte) string {
+	h := sha256.Sum256(pkByts)
+	return fmt.Sprintf("subnet.%d", binary.BigEndian.Uint64(h[:8])%v2.subnetsCount)
+}
@@ -0,0 +1,55 @@
+package v2
+
+import (
+	"encoding/hex"
+	v0 "github.com/bloxapp/ssv/network/forks/v0"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestForkV2_ValidatorTopicID(t *testing.T) {
+	threshold.Init()
+	sk := bls.SecretKey{}
+	sk.SetByCSPRNG()
+	pk := sk.GetPublicKey().Serialize()
+	pkHex := hex.EncodeToString(pk)
+
+	fork := New(v0.New(), 100, 4)
+	subnet := fork.(*ForkV2).subnetTopicID(pk)
+	require.Contains(t, []string{"subnet.0", "subnet.1", "subnet.2", "subnet.3"}, subnet)
+	require.Equal(t, subnet, fork.(*ForkV2).subnetTopicID(pk))
+
+	tests := []struct {
+		name     string
+		slot     uint64
+		topicID  string
+		topicIDs []string
+	}{
+		{"before migration", 10, pkHex, []string{pkHex}},
+		{"migration before fork", 68, pkHex, []string{pkHex, subnet}},
+		{"fork slot", 100, subnet, []string{pkHex, subnet}},
+		{"migration after fork", 131, subnet, []string{pkHex, subnet}},
+		{"after migration", 132, subnet, []string{subnet}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fork.SlotTick(test.slot)
+			require.Equal(t, test.topicID, fork.ValidatorTopicID(pk))
+			require.Equal(t, test.topicIDs, fork.ValidatorTopicIDs(pk))
+		})
+	}
+}
+
+func TestForkV2_SubnetsDistribution(t *testing.T) {
+	threshold.Init()
+	fork := New(v0.New(), 0, 8).(*ForkV2)
+	subnets := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		sk := bls.SecretKey{}
+		sk.SetByCSPRNG()
+		subnets[fork.subnetTopicID(sk.GetPublicKey().Serialize())]++
+	}
+	require.Len(t, subnets, 8)
+}
@@ -16,6 +16,7 @@ type Local struct {
 	localPeerID        peer.ID
 	msgC               []chan *proto.SignedMessage
 	sigC               []chan *proto.SignedMessage
+	preSigC            []chan *proto.SignedMessage
 	decidedC           []chan *proto.SignedMessage
 	syncC              []chan *network.SyncChanObj
 	syncPeers          map[string]chan *network.SyncChanObj
@@ -27,6 +28,7 @@ func NewLocalNetwork() *Local {
 	return &Local{
 		msgC:               make([]chan *proto.SignedMessage, 0),
 		sigC:               make([]chan *proto.SignedMessage, 0),
+		preSigC:            make([]chan *proto.SignedMessage, 0),
 		decidedC:           make([]chan *proto.SignedMessage, 0),
 		syncC:              make([]chan *network.SyncChanObj, 0),
 		syncPeers:          make(map[string]chan *network.SyncChanObj),
@@ -41,6 +43,7 @@ func (n *Local) CopyWithLocalNodeID(id peer.ID) *Local {
 		localPeerID:        id,
 		msgC:               n.msgC,
 		sigC:               n.sigC,
+		preSigC:            n.preSigC,
 		decidedC:           n.decidedC,
 		syncC:              n.syncC,
 		syncPeers:          n.syncPeers,
@@ -89,6 +92,27 @@ func (n *Local) BroadcastSignature(topicName []byte, msg *proto.SignedMessage) e
 	return nil
 }
 
+// ReceivedPreConsensusSignatureChan returns the channel with pre-consensus partial signatures
+func (n *Local) ReceivedPreConsensusSignatureChan() (<-chan *proto.SignedMessage, func()) {
+	n.createChannelMutex.Lock()
+	defer n.createChannelMutex.Unlock()
+	c := make(chan *proto.SignedMessage)
+	n.preSigC = append(n.preSigC, c)
+	return c, func() {}
+}
+
+// BroadcastPreConsensusSignature broadcasts the given partial signature, required before consensus, for the given lambda
+func (n *Local) BroadcastPreConsensusSignature(topicName []byte, msg *proto.SignedMessage) error {
+	n.createChannelMutex.Lock()
+	go func() {
+		for _, c := range n.preSigC {
+			c <- msg
+		}
+		n.createChannelMutex.Unlock()
+	}()
+	return nil
+}
+
 // BroadcastDecided broadcasts a decided instance with collected signatures
 func (n *Local) BroadcastDecided(topicName []byte, msg *proto.SignedMessage) error {
 	n.createChannelMutex.Lock()
@@ -174,6 +198,14 @@ func (n *Local) SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) error {
 	return nil
 }
 
+// UnsubscribeFromValidatorNetwork implementation
+func (n *Local) UnsubscribeFromValidatorNetwork(validatorPk *bls.PublicKey) error {
+	return nil
+}
+
+// RegisterMsgValidator implementation, messages are not validated by the local network
+func (n *Local) RegisterMsgValidator(validator network.MsgValidator) {}
+
 // AllPeers returns all connected peers for a validator PK
 func (n *Local) AllPeers(validatorPk []byte) ([]string, error) {
 	ret := make([]string, 0)
@@ -76,6 +76,39 @@ func TestSigChan(t *testing.T) {
 	require.EqualValues(t, []bool{true, true, true, true}, doneFlags)
 }
 
+func TestPreConsensusSigChan(t *testing.T) {
+	net := NewLocalNetwork()
+	c1, _ := net.ReceivedPreConsensusSignatureChan()
+	c2, _ := net.ReceivedPreConsensusSignatureChan()
+	c3, _ := net.ReceivedPreConsensusSignatureChan()
+	c4, _ := net.ReceivedPreConsensusSignatureChan()
+	testMsg := &proto.SignedMessage{
+		Signature: []byte{1, 2, 3, 4},
+		SignerIds: []uint64{1, 2, 3, 4},
+	}
+
+	lock := sync.Mutex{}
+	doneFlags := []bool{false, false, false, false}
+	for i, c := range []<-chan *proto.SignedMessage{c1, c2, c3, c4} {
+		go func(c <-chan *proto.SignedMessage, flag *bool) {
+			msg := <-c
+			lock.Lock()
+			defer lock.Unlock()
+			require.EqualValues(t, []byte{1, 2, 3, 4}, msg.Signature)
+			require.EqualValues(t, []uint64{1, 2, 3, 4}, msg.SignerIds)
+			*flag = true
+		}(c, &doneFlags[i])
+	}
+
+	time.Sleep(time.Millisecond * 100)
+	net.BroadcastPreConsensusSignature([]byte{1}, testMsg)
+	time.Sleep(time.Millisecond * 100)
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.EqualValues(t, []bool{true, true, true, true}, doneFlags)
+}
+
 func TestDecidedChan(t *testing.T) {
 	net := NewLocalNetwork()
 	c1, _ := net.ReceivedDecidedChan()
@@ -37,6 +37,7 @@ func New() *MessageQueue {
 		indexFuncs: []IndexFunc{
 			iBFTMessageIndex(),
 			sigMessageIndex(),
+			preConsensusSigMessageIndex(),
 			decidedMessageIndex(),
 			syncMessageIndex(),
 		},
@@ -44,18 +44,39 @@ type SyncStream interface {
 	WriteWithTimeout(data []byte, timeout time.Duration) error
 }
 
+// MsgValidationResult is the result of validating a message that was received on a validator topic
+type MsgValidationResult int32
+
+const (
+	// MsgValidationAccept means that the message is valid and should be propagated to other peers
+	MsgValidationAccept MsgValidationResult = iota
+	// MsgValidationIgnore means that the message should be dropped without penalizing the sender (e.g. stale messages)
+	MsgValidationIgnore
+	// MsgValidationReject means that the message is invalid, it is dropped and the sender is penalized
+	MsgValidationReject
+)
+
+// MsgValidator validates messages that are received on validator topics, before they are propagated
+type MsgValidator func(msg *Message) MsgValidationResult
+
 // Reader is the interface for reading messages from the network
 type Reader interface {
 	// ReceivedMsgChan is a channel that forwards new propagated messages to a subscriber
 	ReceivedMsgChan() (<-chan *proto.SignedMessage, func())
 	// ReceivedSignatureChan returns the channel with signatures
 	ReceivedSignatureChan() (<-chan *proto.SignedMessage, func())
+	// ReceivedPreConsensusSignatureChan returns the channel with pre-consensus partial signatures
+	ReceivedPreConsensusSignatureChan() (<-chan *proto.SignedMessage, func())
 	// ReceivedDecidedChan returns the channel for decided messages
 	ReceivedDecidedChan() (<-chan *proto.SignedMessage, func())
 	// ReceivedSyncMsgChan returns the channel for sync messages
 	ReceivedSyncMsgChan() (<-chan *SyncChanObj, func())
 	// SubscribeToValidatorNetwork subscribes and listens to validator's network
 	SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) error
+	// UnsubscribeFromValidatorNetwork stops listening to validator's network
+	UnsubscribeFromValidatorNetwork(validatorPk *bls.PublicKey) error
+	// RegisterMsgValidator registers the validator of messages that are received on validator topics
+	RegisterMsgValidator(validator MsgValidator)
 	// AllPeers returns all connected peers for a validator PK
 	AllPeers(validatorPk []byte) ([]string, error)
 	// SubscribeToMainTopic subscribes to main topic
@@ -70,6 +91,8 @@ type Broadcaster interface {
 	Broadcast(topicName []byte, msg *proto.SignedMessage) error
 	// BroadcastSignature broadcasts the given signature for the given lambda
 	BroadcastSignature(topicName []byte, msg *proto.SignedMessage) error
+	// BroadcastPreConsensusSignature broadcasts the given partial signature, required before consensus, for the given lambda
+	BroadcastPreConsensusSignature(topicName []byte, msg *proto.SignedMessage) error
 	// BroadcastDecided broadcasts a decided instance with collected signatures
 	BroadcastDecided(topicName []byte, msg *proto.SignedMessage) error
 	// BroadcastMainTopic broadcasts the given msg on main channel
@@ -20,11 +20,17 @@ type Config struct {
 	HostDNS          string        `yaml:"HostDNS" env:"HOST_DNS" env-description:"External DNS node is exposed for discovery"`
 	RequestTimeout   time.Duration `yaml:"RequestTimeout" env:"P2P_REQUEST_TIMEOUT"  env-default:"5s"`
 	MaxBatchResponse uint64        `yaml:"MaxBatchResponse" env:"P2P_MAX_BATCH_RESPONSE" env-default:"50" env-description:"maximum number of returned objects in a batch"`
+	MaxPeers         int           `yaml:"MaxPeers" env:"P2P_MAX_PEERS" env-default:"60" env-description:"maximum number of connected peers, excess peers that are not protected are trimmed"`
+	MinTopicPeers    int           `yaml:"MinTopicPeers" env:"P2P_MIN_TOPIC_PEERS" env-default:"4" env-description:"minimum number of peers per subscribed topic, new nodes are dialed for topics with less peers"`
 	PubSubTraceOut   string        `yaml:"PubSubTraceOut" env:"PUBSUB_TRACE_OUT" env-description:"File path to hold collected pubsub traces"`
 	//PubSubTracer     string        `yaml:"PubSubTracer" env:"PUBSUB_TRACER" env-description:"A remote tracer that collects pubsub traces"`
 
 	NetworkTrace bool `yaml:"NetworkTrace" env:"NETWORK_TRACE" env-description:"A boolean flag to turn on network debugging"`
 
+	PeerScoring ScoringConfig `yaml:"PeerScoring"`
+
+	SyncRateLimit SyncRateLimitConfig `yaml:"SyncRateLimit"`
+
 	ExporterPeerID string `yaml:"ExporterPeerID" env:"EXPORTER_PEER_ID"  env-default:"16Uiu2HAkvaBh2xjstjs1koEx3jpBn5Hsnz7Bv8pE4SuwFySkiAuf"  env-description:"peer id of exporter"`
 
 	Fork forks.Fork
@@ -42,6 +48,8 @@ type Config struct {
 	ReportLastMsg bool
 	// NodeType differentiate exporters peers from others
 	NodeType NodeType
+	// Reputation records offenses of peers and prevents connections with banned peers
+	Reputation ReputationStore
 }
 
 // NodeType indicate node operation type. In purpose for distinguish between different types of peers
@@ -0,0 +1,185 @@
+package p2p
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/async"
+	"go.uber.org/zap"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// connManagerInterval is the interval of connections management
+	connManagerInterval = 30 * time.Second
+	// maxDialsPerRound is the max number of nodes that are dialed in a single round for under-served topics
+	maxDialsPerRound = 8
+	// dialNodesTimeout is the time to look for new nodes in a single round
+	dialNodesTimeout = 10 * time.Second
+)
+
+// watchConnections manages the connections of the node periodically:
+// peers of the subscribed validator topics are protected, excess peers are trimmed
+// and new nodes are dialed for topics that don't have enough peers
+func (n *p2pNetwork) watchConnections() {
+	async.RunEvery(n.ctx, connManagerInterval, func() {
+		n.manageConnections()
+	})
+}
+
+// manageConnections runs a single round of connections management
+func (n *p2pNetwork) manageConnections() {
+	topicsPeers := n.subscribedTopicsPeers()
+	protected := n.protectedPeers(topicsPeers)
+	metricsConnManagerProtectedPeers.Set(float64(len(protected)))
+
+	if trimmed := n.trimPeers(protected); len(trimmed) > 0 {
+		n.logger.Info("trimmed excess peers", zap.Int("count", len(trimmed)), zap.Any("peers", trimmed))
+	}
+
+	underServed := n.underServedTopics(topicsPeers)
+	metricsConnManagerUnderServedTopics.Set(float64(len(underServed)))
+	if len(underServed) > 0 {
+		n.logger.Debug("found under-served topics", zap.Strings("topics", underServed))
+		go n.dialNewNodes(underServed)
+	}
+}
+
+// underServedTopics returns the topics that have less peers than required
+func (n *p2pNetwork) underServedTopics(topicsPeers map[string][]peer.ID) []string {
+	var underServed []string
+	for topicID, peers := range topicsPeers {
+		if len(peers) < n.cfg.MinTopicPeers {
+			underServed = append(underServed, topicID)
+		}
+	}
+	return underServed
+}
+
+// subscribedTopicsPeers returns the peers of every subscribed validator topic
+func (n *p2pNetwork) subscribedTopicsPeers() map[string][]peer.ID {
+	n.psTopicsLock.RLock()
+	defer n.psTopicsLock.RUnlock()
+
+	topicsPeers := make(map[string][]peer.ID)
+	for topicID := range n.psSubs {
+		if topic, ok := n.cfg.Topics[topicID]; ok {
+			topicsPeers[topicID] = topic.ListPeers()
+		}
+	}
+	return topicsPeers
+}
+
+// protectedPeers returns the peers that should not be trimmed: peers of subscribed topics and the exporter
+func (n *p2pNetwork) protectedPeers(topicsPeers map[string][]peer.ID) map[peer.ID]bool {
+	protected := make(map[peer.ID]bool)
+	for _, peers := range topicsPeers {
+		for _, p := range peers {
+			protected[p] = true
+		}
+	}
+	if len(n.cfg.ExporterPeerID) > 0 {
+		if exporterPeerID, err := peerFromString(n.cfg.ExporterPeerID); err == nil {
+			protected[exporterPeerID] = true
+		}
+	}
+	return protected
+}
+
+// trimPeers disconnects excess peers that are not protected, starting with the least valuable ones
+func (n *p2pNetwork) trimPeers(protected map[peer.ID]bool) []peer.ID {
+	connected := n.host.Network().Peers()
+	excess := len(connected) - n.maxPeers()
+	if excess <= 0 {
+		return nil
+	}
+	var candidates []peer.ID
+	for _, p := range connected {
+		if !protected[p] {
+			candidates = append(candidates, p)
+		}
+	}
+	n.sortPeersByValue(candidates)
+	if len(candidates) > excess {
+		candidates = candidates[:excess]
+	}
+	for _, p := range candidates {
+		n.logger.Debug("trimming peer", zap.String("peer", p.String()), zap.Float64("score", n.peerScore(p)))
+		n.disconnectPeer(p)
+	}
+	metricsConnManagerTrimmedPeers.Add(float64(len(candidates)))
+	return candidates
+}
+
+// sortPeersByValue sorts the given peers from the least valuable to the most valuable,
+// peers that didn't prove an operator identity are less valuable, then peers with lower score
+func (n *p2pNetwork) sortPeersByValue(peers []peer.ID) {
+	verified := make(map[peer.ID]bool)
+	scores := make(map[peer.ID]float64)
+	for _, p := range peers {
+		verified[p] = n.isVerifiedOperator(p.String())
+		scores[p] = n.peerScore(p)
+	}
+	sort.SliceStable(peers, func(i, j int) bool {
+		if verified[peers[i]] != verified[peers[j]] {
+			return !verified[peers[i]]
+		}
+		return scores[peers[i]] < scores[peers[j]]
+	})
+}
+
+// dialNewNodes dials discovered nodes that advertise the given under-served topics,
+// only one round of dialing runs at a time
+func (n *p2pNetwork) dialNewNodes(topics []string) {
+	if n.dv5Listener == nil || !atomic.CompareAndSwapInt32(&n.dialing, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&n.dialing, 0)
+
+	count := len(topics)
+	if count > maxDialsPerRound {
+		count = maxDialsPerRound
+	}
+	topicsEntry := topicsBitvector(topics)
+	iterator := enode.Filter(n.dv5Listener.RandomNodes(), func(node *enode.Node) bool {
+		return servesTopics(node, topicsEntry)
+	})
+	defer iterator.Close()
+	// closing the iterator releases Next() once the timeout is reached
+	timer := time.AfterFunc(dialNodesTimeout, iterator.Close)
+	defer timer.Stop()
+
+	dialed := 0
+	for dialed < count && iterator.Next() {
+		info, err := convertToAddrInfo(iterator.Node())
+		if err != nil || info.ID == n.host.ID() || n.host.Network().Connectedness(info.ID) == libp2pnetwork.Connected {
+			continue
+		}
+		dialed++
+		go func(info peer.AddrInfo) {
+			if err := n.connectWithPeer(n.ctx, info); err != nil {
+				n.trace("can't connect with peer", zap.String("peerID", info.ID.String()), zap.Error(err))
+			}
+		}(*info)
+	}
+	metricsConnManagerDialedNodes.Add(float64(dialed))
+	n.logger.Debug("dialed new nodes for under-served topics", zap.Int("count", dialed))
+}
+
+// maxPeers returns the configured max peers
+func (n *p2pNetwork) maxPeers() int {
+	if n.cfg.MaxPeers > 0 {
+		return n.cfg.MaxPeers
+	}
+	return maxPeers
+}
+
+// peerScore returns the last known gossipsub score of the given peer
+func (n *p2pNetwork) peerScore(pid peer.ID) float64 {
+	n.peersScoresLock.RLock()
+	defer n.peersScoresLock.RUnlock()
+
+	return n.peersScores[pid]
+}
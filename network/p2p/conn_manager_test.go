@@ -0,0 +1,97 @@
+package p2p
+
+import (
+	"context"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestP2pNetwork_SortPeersByValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := testHandshakeNetwork(ctx, t, Operator)
+	pids := []peer.ID{"peer-a", "peer-b", "peer-c", "peer-d"}
+	n.peersIndex.SetPeerData(pids[0].String(), OperatorPubKeyHashKey, "xxx")
+	n.peersIndex.SetPeerData(pids[2].String(), OperatorPubKeyHashKey, "yyy")
+	n.inspectPeersScores(map[peer.ID]float64{
+		pids[0]: -5,
+		pids[1]: 10,
+		pids[2]: 1,
+		pids[3]: -1,
+	})
+
+	peers := append([]peer.ID{}, pids...)
+	n.sortPeersByValue(peers)
+	// unverified peers first (by score), then verified peers (by score)
+	require.Equal(t, []peer.ID{pids[3], pids[1], pids[0], pids[2]}, peers)
+}
+
+func TestP2pNetwork_ProtectedPeers(t *testing.T) {
+	exporter, err := peer.Decode("QmUuUXxNPyFhFnzkyB1o8s9NckG8VpKx3rzyYd9qaMWpvj")
+	require.NoError(t, err)
+	n := &p2pNetwork{cfg: &Config{ExporterPeerID: exporter.String()}}
+
+	protected := n.protectedPeers(map[string][]peer.ID{
+		"topic-a": {"peer-a", "peer-b"},
+		"topic-b": {"peer-b", "peer-c"},
+	})
+	require.Len(t, protected, 4)
+	for _, p := range []peer.ID{"peer-a", "peer-b", "peer-c", exporter} {
+		require.True(t, protected[p])
+	}
+	require.False(t, protected["peer-d"])
+}
+
+func TestP2pNetwork_TrimPeers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := testHandshakeNetwork(ctx, t, Operator)
+	n.cfg.MaxPeers = 3
+
+	var hosts []host.Host
+	for i := 0; i < 4; i++ {
+		h, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		require.NoError(t, err)
+		require.NoError(t, n.host.Connect(ctx, peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()}))
+		hosts = append(hosts, h)
+	}
+	require.Len(t, n.host.Network().Peers(), 4)
+
+	t.Run("no excess peers", func(t *testing.T) {
+		n.cfg.MaxPeers = 10
+		defer func() { n.cfg.MaxPeers = 3 }()
+		require.Len(t, n.trimPeers(nil), 0)
+	})
+
+	t.Run("trim unprotected peers", func(t *testing.T) {
+		// the 2nd peer is less valuable than the 3rd one
+		n.inspectPeersScores(map[peer.ID]float64{hosts[1].ID(): -10, hosts[2].ID(): 5})
+		protected := map[peer.ID]bool{hosts[0].ID(): true, hosts[3].ID(): true}
+
+		trimmed := n.trimPeers(protected)
+		require.Equal(t, []peer.ID{hosts[1].ID()}, trimmed)
+		require.Eventually(t, func() bool {
+			return len(n.host.Network().Peers()) == 3
+		}, 2*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("protected peers are not trimmed", func(t *testing.T) {
+		protected := map[peer.ID]bool{hosts[0].ID(): true, hosts[2].ID(): true, hosts[3].ID(): true}
+		require.Len(t, n.trimPeers(protected), 0)
+		require.Len(t, n.host.Network().Peers(), 3)
+	})
+}
+
+func TestP2pNetwork_MaxPeers(t *testing.T) {
+	n := &p2pNetwork{cfg: &Config{}, logger: zap.L()}
+	require.Equal(t, maxPeers, n.maxPeers())
+	n.cfg.MaxPeers = 60
+	require.Equal(t, 60, n.maxPeers())
+}
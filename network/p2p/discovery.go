@@ -14,6 +14,7 @@ import (
 )
 
 const (
+	// maxPeers is the default max peers, used if not configured
 	maxPeers = 1000
 	udp4     = "udp4"
 	udp6     = "udp6"
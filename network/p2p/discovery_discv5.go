@@ -31,7 +31,7 @@ type discv5Listener interface {
 // setupDiscV5 creates all the required objects for discv5
 func (n *p2pNetwork) setupDiscV5() (*discover.UDPv5, error) {
 	n.peers = peers.NewStatus(n.ctx, &peers.StatusConfig{
-		PeerLimit: maxPeers,
+		PeerLimit: n.maxPeers(),
 		ScorerParams: &scorers.Config{
 			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
 				Threshold:     5,
@@ -132,6 +132,9 @@ func (n *p2pNetwork) createExtendedLocalNode(ipAddr net.IP) (*enode.LocalNode, e
 		}
 	}
 
+	setNodeTypeEntry(localNode, n.nodeType)
+	setTopicsEntry(localNode, bitfield.NewBitvector256())
+
 	// TODO: add fork entry once applicable
 	//localNode, err = addForkEntry(localNode, s.genesisTime, s.genesisValidatorsRoot)
 	//if err != nil {
@@ -188,20 +191,28 @@ func createLocalNode(privKey *ecdsa.PrivateKey, ipAddr net.IP, udpPort, tcpPort
 }
 
 // listenForNewNodes watches for new nodes in the network and connects to unknown peers.
+// nodes that serve subscribed topics without enough peers are preferred, and are dialed even if the peer limit was reached
 func (n *p2pNetwork) listenForNewNodes() {
 	defer n.logger.Debug("done listening for new nodes")
 	iterator := n.dv5Listener.RandomNodes()
-	//iterator = enode.Filter(iterator, s.filterPeer)
 	defer iterator.Close()
 	n.logger.Debug("starting to listen for new nodes")
+	missing := n.missingTopics()
+	lastRefresh := time.Now()
 	for {
 		// Exit if service's context is canceled
 		if n.ctx.Err() != nil {
 			break
 		}
-		if n.isPeerAtLimit() {
+		if time.Since(lastRefresh) > missingTopicsRefreshInterval {
+			missing = n.missingTopics()
+			lastRefresh = time.Now()
+		}
+		atLimit := n.isPeerAtLimit()
+		if atLimit && missing.Count() == 0 {
 			n.logger.Debug("at peer limit")
 			time.Sleep(6 * time.Second)
+			lastRefresh = time.Time{}
 			continue
 		}
 		exists := iterator.Next()
@@ -209,6 +220,9 @@ func (n *p2pNetwork) listenForNewNodes() {
 			break
 		}
 		node := iterator.Node()
+		if !n.shouldDialNode(node, missing, atLimit) {
+			continue
+		}
 		peerInfo, err := convertToAddrInfo(node)
 		if err != nil {
 			n.trace("could not convert node to peer info", zap.Error(err))
@@ -222,11 +236,20 @@ func (n *p2pNetwork) listenForNewNodes() {
 	}
 }
 
+// shouldDialNode decides whether to dial the given node: nodes that serve missing topics are always dialed,
+// while other nodes are dialed only if the peer limit was not reached yet
+func (n *p2pNetwork) shouldDialNode(node *enode.Node, missing bitfield.Bitvector256, atLimit bool) bool {
+	if servesTopics(node, missing) {
+		return true
+	}
+	return !atLimit
+}
+
 // isPeerAtLimit checks for max peers
 func (n *p2pNetwork) isPeerAtLimit() bool {
 	numOfConns := len(n.host.Network().Peers())
 	activePeers := len(n.peers.Active())
-	return activePeers >= maxPeers || numOfConns >= maxPeers
+	return activePeers >= n.maxPeers() || numOfConns >= n.maxPeers()
 }
 
 // dv5Logger implements log.Handler to track logs of discv5
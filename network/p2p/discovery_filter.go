@@ -0,0 +1,119 @@
+package p2p
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/prysmaticlabs/go-bitfield"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// topicsEntryKey is the ENR entry that advertises the topics the node is subscribed to
+	topicsEntryKey = "topics"
+	// nodeTypeEntryKey is the ENR entry that advertises the type of the node (operator / exporter)
+	nodeTypeEntryKey = "type"
+	// subnetTopicPrefix is the prefix of subnet topics
+	subnetTopicPrefix = "subnet."
+	// missingTopicsRefreshInterval is the interval for refreshing the topics that discovery is looking for
+	missingTopicsRefreshInterval = 6 * time.Second
+)
+
+// topicBit returns the bit of the given topic in the topics entry,
+// subnets are mapped to their index while other topics (e.g. validator topics) are hashed into the bitvector.
+// collisions are possible for hashed topics, therefore the entry serves as a hint for prioritizing nodes.
+func topicBit(topicID string) uint64 {
+	topicsLen := bitfield.NewBitvector256().Len()
+	if strings.HasPrefix(topicID, subnetTopicPrefix) {
+		if subnet, err := strconv.ParseUint(strings.TrimPrefix(topicID, subnetTopicPrefix), 10, 64); err == nil {
+			return subnet % topicsLen
+		}
+	}
+	h := sha256.Sum256([]byte(topicID))
+	return binary.BigEndian.Uint64(h[:8]) % topicsLen
+}
+
+// topicsBitvector returns the bitvector that represents the given topics
+func topicsBitvector(topics []string) bitfield.Bitvector256 {
+	bv := bitfield.NewBitvector256()
+	for _, topicID := range topics {
+		bv.SetBitAt(topicBit(topicID), true)
+	}
+	return bv
+}
+
+// setTopicsEntry sets the topics entry ('topics') of the node
+func setTopicsEntry(node *enode.LocalNode, topics bitfield.Bitvector256) {
+	node.Set(enr.WithEntry(topicsEntryKey, topics.Bytes()))
+}
+
+// extractTopicsEntry extracts the value of topics entry ('topics')
+func extractTopicsEntry(record *enr.Record) (bitfield.Bitvector256, error) {
+	var raw []byte
+	if err := record.Load(enr.WithEntry(topicsEntryKey, &raw)); err != nil {
+		return nil, err
+	}
+	bv := bitfield.NewBitvector256()
+	copy(bv, raw)
+	return bv, nil
+}
+
+// setNodeTypeEntry sets the node type entry ('type') of the node
+func setNodeTypeEntry(node *enode.LocalNode, nodeType NodeType) {
+	node.Set(enr.WithEntry(nodeTypeEntryKey, uint8(nodeType)))
+}
+
+// extractNodeTypeEntry extracts the value of node type entry ('type')
+func extractNodeTypeEntry(record *enr.Record) (NodeType, error) {
+	var nodeType uint8
+	if err := record.Load(enr.WithEntry(nodeTypeEntryKey, &nodeType)); err != nil {
+		return Unknown, err
+	}
+	return NodeType(nodeType), nil
+}
+
+// servesTopics returns whether the given node advertises any of the given topics,
+// exporters and nodes without topics entry (older versions) are not considered as serving topics
+func servesTopics(node *enode.Node, topics bitfield.Bitvector256) bool {
+	if nodeType, err := extractNodeTypeEntry(node.Record()); err == nil && nodeType == Exporter {
+		return false
+	}
+	nodeTopics, err := extractTopicsEntry(node.Record())
+	if err != nil {
+		return false
+	}
+	for _, idx := range topics.BitIndices() {
+		if nodeTopics.BitAt(uint64(idx)) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateTopicsEntry updates the topics entry of the local node with the subscribed topics
+// this method is not thread-safe - should be called after psTopicsLock was acquired
+func (n *p2pNetwork) updateTopicsEntry() {
+	if n.dv5Listener == nil {
+		return
+	}
+	topics := make([]string, 0, len(n.psSubs))
+	for topicID := range n.psSubs {
+		topics = append(topics, topicID)
+	}
+	bv := topicsBitvector(topics)
+	localNode := n.dv5Listener.LocalNode()
+	if current, err := extractTopicsEntry(localNode.Node().Record()); err == nil && string(current) == string(bv) {
+		return
+	}
+	setTopicsEntry(localNode, bv)
+	n.trace("updated topics entry", zap.Int("topics", len(topics)), zap.Ints("bits", bv.BitIndices()))
+}
+
+// missingTopics returns the topics entry of subscribed topics that don't have enough peers
+func (n *p2pNetwork) missingTopics() bitfield.Bitvector256 {
+	return topicsBitvector(n.underServedTopics(n.subscribedTopicsPeers()))
+}
@@ -0,0 +1,119 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTopicBit(t *testing.T) {
+	require.Equal(t, uint64(0), topicBit("subnet.0"))
+	require.Equal(t, uint64(17), topicBit("subnet.17"))
+	require.Equal(t, uint64(4), topicBit("subnet.260"))
+	require.Equal(t, topicBit("b48fa2b3"), topicBit("b48fa2b3"))
+	require.Less(t, topicBit("b48fa2b3"), bitfield.NewBitvector256().Len())
+
+	bv := topicsBitvector([]string{"subnet.1", "subnet.5"})
+	require.Equal(t, []int{1, 5}, bv.BitIndices())
+}
+
+func TestENR_DiscoveryEntries(t *testing.T) {
+	node := newTestLocalNode(t, 12000)
+
+	_, err := extractTopicsEntry(node.Node().Record())
+	require.Error(t, err)
+	require.False(t, servesTopics(node.Node(), topicsBitvector([]string{"subnet.1"})))
+
+	setNodeTypeEntry(node, Operator)
+	setTopicsEntry(node, topicsBitvector([]string{"subnet.1", "subnet.3"}))
+	nodeType, err := extractNodeTypeEntry(node.Node().Record())
+	require.NoError(t, err)
+	require.Equal(t, Operator, nodeType)
+	topics, err := extractTopicsEntry(node.Node().Record())
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 3}, topics.BitIndices())
+
+	require.True(t, servesTopics(node.Node(), topicsBitvector([]string{"subnet.3", "subnet.4"})))
+	require.False(t, servesTopics(node.Node(), topicsBitvector([]string{"subnet.2"})))
+	require.False(t, servesTopics(node.Node(), bitfield.NewBitvector256()))
+
+	setNodeTypeEntry(node, Exporter)
+	require.False(t, servesTopics(node.Node(), topicsBitvector([]string{"subnet.1"})))
+}
+
+func TestDiscovery_FilterByTopics(t *testing.T) {
+	bootnode, _ := newTestDiscv5Listener(t, nil)
+	defer bootnode.Close()
+	bootnodes := []*enode.Node{bootnode.Self()}
+
+	// nodes that serve different subnets
+	servingNodes := make(map[enode.ID]bool)
+	for i := 0; i < 6; i++ {
+		listener, localNode := newTestDiscv5Listener(t, bootnodes)
+		defer listener.Close()
+		setNodeTypeEntry(localNode, Operator)
+		if i%3 == 0 {
+			setTopicsEntry(localNode, topicsBitvector([]string{"subnet.7"}))
+			servingNodes[localNode.ID()] = true
+		} else {
+			setTopicsEntry(localNode, topicsBitvector([]string{"subnet.2"}))
+		}
+		require.NoError(t, listener.Ping(bootnode.Self()))
+	}
+	// an exporter is not considered as serving topics
+	exporter, exporterNode := newTestDiscv5Listener(t, bootnodes)
+	defer exporter.Close()
+	setNodeTypeEntry(exporterNode, Exporter)
+	setTopicsEntry(exporterNode, topicsBitvector([]string{"subnet.7"}))
+	require.NoError(t, exporter.Ping(bootnode.Self()))
+
+	searcher, _ := newTestDiscv5Listener(t, bootnodes)
+	defer searcher.Close()
+
+	missing := topicsBitvector([]string{"subnet.7"})
+	iterator := enode.Filter(searcher.RandomNodes(), func(node *enode.Node) bool {
+		return servesTopics(node, missing)
+	})
+	timer := time.AfterFunc(10*time.Second, iterator.Close)
+	defer timer.Stop()
+
+	found := make(map[enode.ID]bool)
+	for len(found) < len(servingNodes) && iterator.Next() {
+		node := iterator.Node()
+		require.True(t, servingNodes[node.ID()], "found node that doesn't serve the missing topics")
+		found[node.ID()] = true
+	}
+	iterator.Close()
+	require.Equal(t, servingNodes, found)
+}
+
+func newTestLocalNode(t *testing.T, port int) *enode.LocalNode {
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	node, err := createLocalNode(convertFromInterfacePrivKey(priv), net.ParseIP("127.0.0.1"), port, port+1000)
+	require.NoError(t, err)
+	return node
+}
+
+func newTestDiscv5Listener(t *testing.T, bootnodes []*enode.Node) (*discover.UDPv5, *enode.LocalNode) {
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	privKey := convertFromInterfacePrivKey(priv)
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	localNode, err := createLocalNode(privKey, net.ParseIP("127.0.0.1"), port, port)
+	require.NoError(t, err)
+	listener, err := discover.ListenV5(conn, localNode, discover.Config{
+		PrivateKey: privKey,
+		Bootnodes:  bootnodes,
+	})
+	require.NoError(t, err)
+	return listener, localNode
+}
@@ -0,0 +1,45 @@
+package p2p
+
+import (
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/control"
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// connectionGater implements connmgr.ConnectionGater, it prevents connections with banned peers
+type connectionGater struct {
+	reputation ReputationStore
+}
+
+// newConnectionGater creates a new instance of connmgr.ConnectionGater
+func newConnectionGater(reputation ReputationStore) connmgr.ConnectionGater {
+	return &connectionGater{reputation: reputation}
+}
+
+// InterceptPeerDial tests whether we're permitted to Dial the specified peer
+func (g *connectionGater) InterceptPeerDial(p peer.ID) bool {
+	return !g.reputation.IsBanned(p)
+}
+
+// InterceptAddrDial tests whether we're permitted to dial the specified multiaddr for the given peer
+func (g *connectionGater) InterceptAddrDial(p peer.ID, _ ma.Multiaddr) bool {
+	return !g.reputation.IsBanned(p)
+}
+
+// InterceptAccept tests whether an incipient inbound connection is allowed,
+// the peer is not known at this point so it is checked once the connection is secured
+func (g *connectionGater) InterceptAccept(_ libp2pnetwork.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured tests whether a given connection, now authenticated, is allowed
+func (g *connectionGater) InterceptSecured(_ libp2pnetwork.Direction, p peer.ID, _ libp2pnetwork.ConnMultiaddrs) bool {
+	return !g.reputation.IsBanned(p)
+}
+
+// InterceptUpgraded tests whether a fully capable connection is allowed
+func (g *connectionGater) InterceptUpgraded(_ libp2pnetwork.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
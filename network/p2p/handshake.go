@@ -0,0 +1,236 @@
+package p2p
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	core "github.com/libp2p/go-libp2p-core"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/multiformats/go-multistream"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"strings"
+)
+
+const (
+	handshakeProtocol = "/ssv/handshake/0.0.1"
+	// handshakeChallengeSize is the size of the random challenge that the remote peer signs
+	handshakeChallengeSize = 32
+
+	// OperatorPubKeyHashKey is the key of the verified operator public key hash of a peer
+	OperatorPubKeyHashKey = "operator-pk-hash"
+	// NodeTypeKey is the key of the node type that was reported by a peer in the handshake
+	NodeTypeKey = "node-type"
+)
+
+// handshakeRequest is sent by the peer that verifies the identity of the remote peer
+type handshakeRequest struct {
+	Challenge []byte `json:"challenge"`
+}
+
+// handshakeResponse is the proof of the remote peer for its operator identity,
+// operator public key and signature are empty for peers that are not operators (e.g. exporter)
+type handshakeResponse struct {
+	NodeType       string `json:"nodeType"`
+	OperatorPubKey string `json:"operatorPubKey,omitempty"`
+	Signature      []byte `json:"signature,omitempty"`
+}
+
+// setHandshakeStreamHandler sets the handler that proves the operator identity of this node to remote peers
+func (n *p2pNetwork) setHandshakeStreamHandler() {
+	n.host.SetStreamHandler(handshakeProtocol, func(stream core.Stream) {
+		s := NewSyncStream(stream)
+		defer func() {
+			if err := s.Close(); err != nil {
+				n.logger.Debug("could not close handshake stream", zap.Error(err))
+			}
+		}()
+		buf, err := s.ReadWithTimeout(n.cfg.RequestTimeout)
+		if err != nil {
+			n.logger.Debug("could not read handshake request", zap.Error(err))
+			return
+		}
+		req := handshakeRequest{}
+		if err := json.Unmarshal(buf, &req); err != nil || len(req.Challenge) != handshakeChallengeSize {
+			n.logger.Debug("invalid handshake request", zap.String("peer", s.RemotePeer()), zap.Error(err))
+			return
+		}
+		res, err := n.newHandshakeResponse(req.Challenge)
+		if err != nil {
+			n.logger.Error("could not create handshake response", zap.Error(err))
+			return
+		}
+		data, err := json.Marshal(res)
+		if err != nil {
+			n.logger.Error("could not marshal handshake response", zap.Error(err))
+			return
+		}
+		if err := s.WriteWithTimeout(data, n.cfg.RequestTimeout); err != nil {
+			n.logger.Debug("could not write handshake response", zap.Error(err))
+		}
+	})
+}
+
+// newHandshakeResponse signs the given challenge, bound to the peer id of this node, with the operator key
+func (n *p2pNetwork) newHandshakeResponse(challenge []byte) (*handshakeResponse, error) {
+	res := &handshakeResponse{NodeType: n.nodeType.String()}
+	if n.operatorPrivKey == nil {
+		return res, nil
+	}
+	pk, err := n.getOperatorPubKey()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := rsa.SignPKCS1v15(rand.Reader, n.operatorPrivKey, crypto.SHA256, handshakeDigest(challenge, n.host.ID()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign handshake challenge")
+	}
+	res.OperatorPubKey = pk
+	res.Signature = sig
+	return res, nil
+}
+
+// handshake requests the given peer to prove its operator identity,
+// returns the hash of the verified operator public key or an empty string if the peer is not an operator
+func (n *p2pNetwork) handshake(pid peer.ID) (*handshakeResponse, string, error) {
+	challenge := make([]byte, handshakeChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, "", errors.Wrap(err, "could not create handshake challenge")
+	}
+	req, err := json.Marshal(handshakeRequest{Challenge: challenge})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not marshal handshake request")
+	}
+
+	ctx, cancel := context.WithTimeout(n.ctx, n.cfg.RequestTimeout)
+	defer cancel()
+	stream, err := n.host.NewStream(ctx, pid, handshakeProtocol)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not open handshake stream")
+	}
+	s := NewSyncStream(stream)
+	defer func() {
+		if err := s.Close(); err != nil {
+			n.logger.Debug("could not close handshake stream", zap.Error(err))
+		}
+	}()
+	if err := s.WriteWithTimeout(req, n.cfg.RequestTimeout); err != nil {
+		return nil, "", errors.Wrap(err, "could not write handshake request")
+	}
+	if err := s.CloseWrite(); err != nil {
+		return nil, "", errors.Wrap(err, "could not close handshake stream for writing")
+	}
+	buf, err := s.ReadWithTimeout(n.cfg.RequestTimeout)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not read handshake response")
+	}
+	res := &handshakeResponse{}
+	if err := json.Unmarshal(buf, res); err != nil {
+		return nil, "", errors.Wrap(err, "could not unmarshal handshake response")
+	}
+	if err := verifyHandshakeResponse(res, challenge, pid); err != nil {
+		return nil, "", err
+	}
+	return res, pubKeyHash(res.OperatorPubKey), nil
+}
+
+// authenticatePeer runs the handshake with the given peer and indexes its verified identity,
+// peers that fail to prove their identity or claim a different operator in their user agent are disconnected
+func (n *p2pNetwork) authenticatePeer(pid peer.ID) {
+	logger := n.logger.With(zap.String("peer", pid.String()))
+	res, pkHash, err := n.handshake(pid)
+	if err != nil {
+		if errors.Is(err, multistream.ErrNotSupported) {
+			// TODO: disconnect peers that don't support handshake once all nodes were upgraded
+			logger.Debug("peer doesn't support handshake")
+			return
+		}
+		logger.Warn("peer failed to prove its identity, disconnecting", zap.Error(err))
+		n.disconnectPeer(pid)
+		return
+	}
+	if claimed := userAgentOperatorHash(n.peersIndex.GetPeerData(pid.String(), UserAgentKey)); len(claimed) > 0 && claimed != pkHash {
+		logger.Warn("peer claimed an operator identity that it couldn't prove, disconnecting",
+			zap.String("claimed", claimed), zap.String("verified", pkHash))
+		n.disconnectPeer(pid)
+		return
+	}
+	n.peersIndex.SetPeerData(pid.String(), NodeTypeKey, res.NodeType)
+	if len(pkHash) > 0 {
+		n.peersIndex.SetPeerData(pid.String(), OperatorPubKeyHashKey, pkHash)
+	}
+	logger.Debug("peer was authenticated", zap.String("nodeType", res.NodeType), zap.String("operatorPubKeyHash", pkHash))
+}
+
+// isVerifiedOperator returns true if the given peer has proved its operator identity
+func (n *p2pNetwork) isVerifiedOperator(pid string) bool {
+	return len(n.peersIndex.GetPeerData(pid, OperatorPubKeyHashKey)) > 0
+}
+
+func (n *p2pNetwork) disconnectPeer(pid peer.ID) {
+	if err := n.host.Network().ClosePeer(pid); err != nil {
+		n.logger.Debug("could not disconnect peer", zap.String("peer", pid.String()), zap.Error(err))
+	}
+}
+
+// verifyHandshakeResponse verifies the signature of the handshake response over the challenge and the peer id
+func verifyHandshakeResponse(res *handshakeResponse, challenge []byte, pid peer.ID) error {
+	if len(res.OperatorPubKey) == 0 {
+		if len(res.Signature) > 0 {
+			return errors.New("signature without operator public key")
+		}
+		return nil
+	}
+	pk, err := parseOperatorPubKey(res.OperatorPubKey)
+	if err != nil {
+		return err
+	}
+	if err := rsa.VerifyPKCS1v15(pk, crypto.SHA256, handshakeDigest(challenge, pid), res.Signature); err != nil {
+		return errors.Wrap(err, "invalid handshake signature")
+	}
+	return nil
+}
+
+// parseOperatorPubKey parses an operator public key, encoded as base64 of the PEM
+func parseOperatorPubKey(pkBase64 string) (*rsa.PublicKey, error) {
+	pemBytes, err := base64.StdEncoding.DecodeString(pkBase64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode operator public key")
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("could not decode operator public key pem")
+	}
+	pk, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse operator public key")
+	}
+	rsaPk, ok := pk.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("operator public key is not an rsa key")
+	}
+	return rsaPk, nil
+}
+
+// handshakeDigest returns the digest that is signed in the handshake, bound to the peer id of the signer
+func handshakeDigest(challenge []byte, pid peer.ID) []byte {
+	h := sha256.New()
+	h.Write(challenge)
+	h.Write([]byte(pid))
+	return h.Sum(nil)
+}
+
+// userAgentOperatorHash returns the operator public key hash that is claimed in the given user agent
+func userAgentOperatorHash(ua string) string {
+	uaParts := strings.Split(ua, ":")
+	if len(uaParts) > 3 {
+		return uaParts[3]
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+package p2p
+
+import (
+	"context"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/libp2p/go-libp2p"
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestP2pNetwork_Handshake(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, skPem, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	operatorKey, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+	require.NoError(t, err)
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(operatorKey)
+	require.NoError(t, err)
+
+	operator := testHandshakeNetwork(ctx, t, Operator)
+	operator.operatorPrivKey = operatorKey
+	exporter := testHandshakeNetwork(ctx, t, Exporter)
+	require.NoError(t, exporter.host.Connect(ctx, peer.AddrInfo{ID: operator.host.ID(), Addrs: operator.host.Addrs()}))
+
+	t.Run("operator proves its identity", func(t *testing.T) {
+		res, pkHash, err := exporter.handshake(operator.host.ID())
+		require.NoError(t, err)
+		require.Equal(t, Operator.String(), res.NodeType)
+		require.Equal(t, pubKeyHash(operatorPubKey), pkHash)
+	})
+
+	t.Run("non operator peer", func(t *testing.T) {
+		res, pkHash, err := operator.handshake(exporter.host.ID())
+		require.NoError(t, err)
+		require.Equal(t, Exporter.String(), res.NodeType)
+		require.Equal(t, "", pkHash)
+	})
+
+	t.Run("signature is bound to the peer id", func(t *testing.T) {
+		challenge := make([]byte, handshakeChallengeSize)
+		res, err := operator.newHandshakeResponse(challenge)
+		require.NoError(t, err)
+		require.NoError(t, verifyHandshakeResponse(res, challenge, operator.host.ID()))
+		require.EqualError(t, verifyHandshakeResponse(res, challenge, exporter.host.ID()),
+			"invalid handshake signature: crypto/rsa: verification error")
+		require.Error(t, verifyHandshakeResponse(res, make([]byte, handshakeChallengeSize+1), operator.host.ID()))
+	})
+
+	t.Run("verified identity is indexed", func(t *testing.T) {
+		exporter.authenticatePeer(operator.host.ID())
+		require.Equal(t, pubKeyHash(operatorPubKey), exporter.peersIndex.GetPeerData(operator.host.ID().String(), OperatorPubKeyHashKey))
+		require.Equal(t, Operator.String(), exporter.peersIndex.GetPeerData(operator.host.ID().String(), NodeTypeKey))
+		require.True(t, exporter.isVerifiedOperator(operator.host.ID().String()))
+		require.False(t, operator.isVerifiedOperator(exporter.host.ID().String()))
+	})
+
+	t.Run("impostor is disconnected", func(t *testing.T) {
+		// the exporter claims an operator identity in its user agent
+		operator.peersIndex.SetPeerData(exporter.host.ID().String(), UserAgentKey, "SSV-Node:v0.0.1:exporter:"+pubKeyHash(operatorPubKey))
+		operator.authenticatePeer(exporter.host.ID())
+		require.Eventually(t, func() bool {
+			return operator.host.Network().Connectedness(exporter.host.ID()) != libp2pnetwork.Connected
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+}
+
+func testHandshakeNetwork(ctx context.Context, t *testing.T, nodeType NodeType) *p2pNetwork {
+	host, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	n := &p2pNetwork{
+		ctx:        ctx,
+		cfg:        &Config{RequestTimeout: 2 * time.Second},
+		logger:     zap.L(),
+		host:       host,
+		peersIndex: NewPeersIndex(host, nil, zap.L()),
+		nodeType:   nodeType,
+	}
+	n.setHandshakeStreamHandler()
+	return n
+}
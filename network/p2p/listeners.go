@@ -13,6 +13,7 @@ import (
 type listener struct {
 	msgCh     chan *proto.SignedMessage
 	sigCh     chan *proto.SignedMessage
+	preSigCh  chan *proto.SignedMessage
 	decidedCh chan *proto.SignedMessage
 	syncCh    chan *network.SyncChanObj
 }
@@ -53,6 +54,8 @@ func (n *p2pNetwork) propagateSignedMsg(cm *network.Message) {
 		go propagateIBFTMessage(listeners, cm.SignedMessage)
 	case network.NetworkMsg_SignatureType:
 		go propagateSigMessage(listeners, cm.SignedMessage)
+	case network.NetworkMsg_PreConsensusSignatureType:
+		go propagatePreConsensusSigMessage(listeners, cm.SignedMessage)
 	case network.NetworkMsg_DecidedType:
 		go propagateDecidedMessage(listeners, cm.SignedMessage)
 	default:
@@ -88,6 +91,14 @@ func propagateSigMessage(listeners []listener, msg *proto.SignedMessage) {
 	}
 }
 
+func propagatePreConsensusSigMessage(listeners []listener, msg *proto.SignedMessage) {
+	for _, ls := range listeners {
+		if ls.preSigCh != nil {
+			ls.preSigCh <- msg
+		}
+	}
+}
+
 func propagateDecidedMessage(listeners []listener, msg *proto.SignedMessage) {
 	for _, ls := range listeners {
 		if ls.decidedCh != nil {
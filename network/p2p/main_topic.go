@@ -6,6 +6,7 @@ import (
 	"github.com/bloxapp/ssv/network"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 // BroadcastMainTopic broadcasts the given msg on main channel
@@ -47,12 +48,15 @@ func (n *p2pNetwork) getMainTopic() (*pubsub.Topic, error) {
 	n.psTopicsLock.RLock()
 	defer n.psTopicsLock.RUnlock()
 
-	name := "main"
+	name := mainTopicName
 	if _, ok := n.cfg.Topics[name]; !ok {
 		topic, err := n.pubsub.Join(getTopicName(name))
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to join main topic")
 		}
+		if err := n.setTopicScoreParams(name, topic); err != nil {
+			n.logger.Warn("could not set main topic score params", zap.Error(err))
+		}
 		n.cfg.Topics[name] = topic
 	}
 	return n.cfg.Topics[name], nil
@@ -28,6 +28,34 @@ var (
 		Name: "ssv:network:peer_last_msg",
 		Help: "Timestamps of last messages",
 	}, []string{"pid"})
+	metricsPeersScores = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:network:peers_scores",
+		Help: "Gossipsub scores of peers",
+	}, []string{"pid"})
+	metricsConnManagerProtectedPeers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:network:conn_manager:protected_peers",
+		Help: "Count peers that are protected from trimming",
+	})
+	metricsConnManagerUnderServedTopics = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:network:conn_manager:under_served_topics",
+		Help: "Count subscribed topics with less peers than required",
+	})
+	metricsConnManagerTrimmedPeers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:network:conn_manager:trimmed_peers",
+		Help: "Count peers that were trimmed",
+	})
+	metricsConnManagerDialedNodes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:network:conn_manager:dialed_nodes",
+		Help: "Count nodes that were dialed for under-served topics",
+	})
+	metricsSyncRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:network:sync:rate_limited",
+		Help: "Count sync requests that exceeded the rate limit by type",
+	}, []string{"type"})
+	metricsPeersOffenses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:network:peers_offenses",
+		Help: "Count offenses of peers by type",
+	}, []string{"offense"})
 )
 
 func init() {
@@ -43,6 +71,27 @@ func init() {
 	if err := prometheus.Register(metricsConnectedPeers); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsPeersScores); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsConnManagerProtectedPeers); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsConnManagerUnderServedTopics); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsConnManagerTrimmedPeers); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsConnManagerDialedNodes); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsPeersOffenses); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsSyncRateLimited); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 func reportAllConnections(n *p2pNetwork) {
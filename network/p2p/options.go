@@ -38,6 +38,9 @@ func (n *p2pNetwork) buildOptions(cfg *Config) ([]libp2p.Option, error) {
 		privKeyOption(n.privKey),
 		libp2p.Transport(libp2ptcp.NewTCPTransport),
 	}
+	if cfg.Reputation != nil {
+		options = append(options, libp2p.ConnectionGater(newConnectionGater(cfg.Reputation)))
+	}
 
 	switch cfg.DiscoveryType {
 	case discoveryTypeMdns:
@@ -145,6 +148,12 @@ func (n *p2pNetwork) newGossipPubsub(cfg *Config) (*pubsub.PubSub, error) {
 		}
 	}
 
+	if cfg.PeerScoring.Enabled {
+		params, thresholds := peerScoreParams(cfg.PeerScoring)
+		psOpts = append(psOpts, pubsub.WithPeerScore(params, thresholds),
+			pubsub.WithPeerScoreInspect(n.inspectPeersScores, scoreInspectInterval))
+	}
+
 	if len(cfg.PubSubTraceOut) > 0 {
 		tracer, err := pubsub.NewPBTracer(cfg.PubSubTraceOut)
 		if err != nil {
@@ -10,11 +10,13 @@ import (
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 	"github.com/prysmaticlabs/prysm/async"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
 	p2pHost "github.com/libp2p/go-libp2p-core/host"
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
 	"github.com/pkg/errors"
@@ -35,6 +37,8 @@ const (
 	MsgChanSize = 128
 
 	topicPrefix = "bloxstaking.ssv"
+
+	subscriptionsSyncInterval = 6 * time.Second
 )
 
 const (
@@ -60,9 +64,17 @@ type p2pNetwork struct {
 	peersIndex      PeersIndex
 	operatorPrivKey *rsa.PrivateKey
 	fork            forks.Fork
+	reputation      ReputationStore
+	syncLimiter     *syncRateLimiter
 
 	psSubs       map[string]context.CancelFunc
+	psValidators map[string][]byte
 	psTopicsLock *sync.RWMutex
+	msgValidator atomic.Value
+
+	peersScores     map[peer.ID]float64
+	peersScoresLock sync.RWMutex
+	dialing         int32
 
 	reportLastMsg bool
 	nodeType      NodeType
@@ -84,12 +96,18 @@ func New(ctx context.Context, logger *zap.Logger, cfg *Config) (network.Network,
 		operatorPrivKey: cfg.OperatorPrivateKey,
 		privKey:         cfg.NetworkPrivateKey,
 		psSubs:          make(map[string]context.CancelFunc),
+		psValidators:    make(map[string][]byte),
 		psTopicsLock:    &sync.RWMutex{},
 		reportLastMsg:   cfg.ReportLastMsg,
 		fork:            cfg.Fork,
+		reputation:      cfg.Reputation,
 		nodeType:        cfg.NodeType,
 	}
 
+	if cfg.SyncRateLimit.Enabled {
+		n.syncLimiter = newSyncRateLimiter(cfg.SyncRateLimit)
+	}
+
 	n.cfg.BootnodesENRs = filterInvalidENRs(n.logger, TransformEnr(n.cfg.Enr))
 	if len(n.cfg.BootnodesENRs) == 0 {
 		n.logger.Warn("missing valid bootnode ENR")
@@ -129,6 +147,8 @@ func New(ctx context.Context, logger *zap.Logger, cfg *Config) (network.Network,
 	}
 	n.pubsub = ps
 
+	n.setStreamHandlers()
+
 	if err := n.setupDiscovery(); err != nil {
 		return nil, errors.Wrap(err, "failed to setup discovery")
 	}
@@ -136,14 +156,16 @@ func New(ctx context.Context, logger *zap.Logger, cfg *Config) (network.Network,
 		return nil, errors.Wrap(err, "failed to start discovery")
 	}
 
-	n.setStreamHandlers()
-
 	n.watchPeers()
+	n.watchSubscriptions()
+	n.watchConnections()
+	n.watchSyncRateLimiter()
 
 	return n, nil
 }
 
 func (n *p2pNetwork) setStreamHandlers() {
+	n.setHandshakeStreamHandler()
 	n.setLegacyStreamHandler() // TODO - remove in v0.1.6
 	//n.setHighestDecidedStreamHandler()
 	//n.setDecidedByRangeStreamHandler()
@@ -164,6 +186,7 @@ func (n *p2pNetwork) notifee() *libp2pnetwork.NotifyBundle {
 					zap.String("peerID", conn.RemotePeer().String()))
 				// TODO: add connection states management
 				n.peersIndex.IndexPeer(conn)
+				n.authenticatePeer(conn.RemotePeer())
 			}()
 		},
 		DisconnectedF: func(net libp2pnetwork.Network, conn libp2pnetwork.Conn) {
@@ -209,7 +232,7 @@ func (n *p2pNetwork) MaxBatch() uint64 {
 // - node version
 // - node type ('operator' | 'exporter')
 // - operator public key hash
-// TODO: will be changed once we have a proper authentication mechanism in place
+// the claimed operator public key hash is verified by peers in the handshake (see authenticatePeer)
 func (n *p2pNetwork) getUserAgent() string {
 	ua := commons.GetBuildData()
 	ua = fmt.Sprintf("%s:%s", ua, n.nodeType.String())
@@ -3,24 +3,89 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"github.com/bloxapp/ssv/network"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async"
 	"go.uber.org/zap"
 	"strings"
 )
 
-// SubscribeToValidatorNetwork  for new validator create new topic, subscribe and start listen
+// SubscribeToValidatorNetwork subscribes and starts to listen to the topics of the given validator
 func (n *p2pNetwork) SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) error {
 	n.psTopicsLock.Lock()
 	defer n.psTopicsLock.Unlock()
 
-	pubKey := validatorPk.SerializeToHexStr()
-	logger := n.logger.With(zap.String("who", "SubscribeToValidatorNetwork"), zap.String("pubKey", pubKey))
+	pkBytes := validatorPk.Serialize()
+	n.psValidators[validatorPk.SerializeToHexStr()] = pkBytes
+	for _, topicID := range n.fork.ValidatorTopicIDs(pkBytes) {
+		if err := n.subscribeToTopic(topicID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnsubscribeFromValidatorNetwork cancels the subscriptions of the given validator that are not used by other validators,
+// topics are closed once the listener of the subscription exits
+func (n *p2pNetwork) UnsubscribeFromValidatorNetwork(validatorPk *bls.PublicKey) error {
+	n.psTopicsLock.Lock()
+	defer n.psTopicsLock.Unlock()
+
+	delete(n.psValidators, validatorPk.SerializeToHexStr())
+	return n.syncSubscriptions()
+}
+
+// watchSubscriptions syncs the subscribed topics periodically, to follow changes of topics mapping between forks.
+// the subscribed topics are advertised in the topics entry of the node's ENR
+func (n *p2pNetwork) watchSubscriptions() {
+	async.RunEvery(n.ctx, subscriptionsSyncInterval, func() {
+		n.psTopicsLock.Lock()
+		defer n.psTopicsLock.Unlock()
+
+		if err := n.syncSubscriptions(); err != nil {
+			n.logger.Warn("could not sync subscriptions", zap.Error(err))
+		}
+		n.updateTopicsEntry()
+	})
+}
+
+// syncSubscriptions subscribes to the topics of all subscribed validators and cancels subscriptions of unused topics
+// this method is not thread-safe - should be called after psTopicsLock was acquired
+func (n *p2pNetwork) syncSubscriptions() error {
+	topics := make(map[string]bool)
+	for _, pk := range n.psValidators {
+		for _, topicID := range n.fork.ValidatorTopicIDs(pk) {
+			topics[topicID] = true
+		}
+	}
+	for topicID, cancel := range n.psSubs {
+		if !topics[topicID] {
+			cancel()
+			delete(n.psSubs, topicID)
+			n.logger.Debug("unsubscribed from topic", zap.String("topic", topicID))
+		}
+	}
+	for topicID := range topics {
+		if err := n.subscribeToTopic(topicID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	if _, ok := n.cfg.Topics[pubKey]; !ok {
-		if err := n.joinTopic(pubKey); err != nil {
+// subscribeToTopic joins and subscribes to the given topic if not subscribed yet
+// this method is not thread-safe - should be called after psTopicsLock was acquired
+func (n *p2pNetwork) subscribeToTopic(topicID string) error {
+	logger := n.logger.With(zap.String("who", "subscribeToTopic"), zap.String("topic", topicID))
+
+	if _, ok := n.psSubs[topicID]; ok {
+		return nil
+	}
+	if _, ok := n.cfg.Topics[topicID]; !ok {
+		if err := n.joinTopic(topicID); err != nil {
 			return errors.Wrap(err, "failed to join to topic")
 		}
 		logger.Debug("joined topic")
@@ -28,43 +93,42 @@ func (n *p2pNetwork) SubscribeToValidatorNetwork(validatorPk *bls.PublicKey) err
 		logger.Debug("known topic")
 	}
 
-	if _, ok := n.psSubs[pubKey]; !ok {
-		sub, err := n.cfg.Topics[pubKey].Subscribe()
+	sub, err := n.cfg.Topics[topicID].Subscribe()
+	if err != nil {
+		if err != pubsub.ErrTopicClosed {
+			return errors.Wrap(err, "failed to subscribe on Topic")
+		}
+		// rejoin a topic in case it was closed, and trying to subscribe again.
+		// the validator of the closed topic might still be registered
+		_ = n.pubsub.UnregisterTopicValidator(getTopicName(topicID))
+		if err := n.joinTopic(topicID); err != nil {
+			return errors.Wrap(err, "failed to join to topic")
+		}
+		sub, err = n.cfg.Topics[topicID].Subscribe()
 		if err != nil {
-			if err != pubsub.ErrTopicClosed {
-				return errors.Wrap(err, "failed to subscribe on Topic")
-			}
-			// rejoin a topic in case it was closed, and trying to subscribe again
-			if err := n.joinTopic(pubKey); err != nil {
-				return errors.Wrap(err, "failed to join to topic")
-			}
-			sub, err = n.cfg.Topics[pubKey].Subscribe()
-			if err != nil {
-				return errors.Wrap(err, "failed to subscribe on Topic")
-			}
+			return errors.Wrap(err, "failed to subscribe on Topic")
 		}
-		logger.Debug("subscribed to topic")
-		ctx, cancel := context.WithCancel(n.ctx)
-		n.psSubs[pubKey] = cancel
-		go func() {
-			topicName := sub.Topic()
-			n.listen(ctx, sub)
-			// close topic and mark it as not subscribed
-			n.psTopicsLock.Lock()
-			defer n.psTopicsLock.Unlock()
+	}
+	logger.Debug("subscribed to topic")
+	ctx, cancel := context.WithCancel(n.ctx)
+	n.psSubs[topicID] = cancel
+	go func() {
+		topicName := sub.Topic()
+		n.listen(ctx, sub)
+		n.psTopicsLock.Lock()
+		defer n.psTopicsLock.Unlock()
+		// listen was done from some reason while the subscription is still registered
+		if ctx.Err() == nil {
+			cancel()
+			delete(n.psSubs, topicID)
+		}
+		// close topic unless it was subscribed again
+		if _, ok := n.psSubs[topicID]; !ok {
 			if err := n.closeTopic(topicName); err != nil {
 				n.logger.Error("failed to close topic", zap.String("topic", topicName), zap.Error(err))
 			}
-			// make sure the context is canceled once listen was done from some reason
-			if cancel, ok := n.psSubs[pubKey]; ok {
-				defer cancel()
-				delete(n.psSubs, pubKey)
-			}
-		}()
-	} else {
-		logger.Debug("subscription exist")
-	}
-
+		}
+	}()
 	return nil
 }
 
@@ -80,21 +144,32 @@ func (n *p2pNetwork) AllPeers(validatorPk []byte) ([]string, error) {
 
 // joinTopic joins to the given topic and mark it in topics map
 // this method is not thread-safe - should be called after psTopicsLock was acquired
-func (n *p2pNetwork) joinTopic(pubKey string) error {
-	topic, err := n.pubsub.Join(getTopicName(pubKey))
+func (n *p2pNetwork) joinTopic(topicID string) error {
+	topicName := getTopicName(topicID)
+	if err := n.pubsub.RegisterTopicValidator(topicName, n.validateTopicMsg); err != nil {
+		return errors.Wrap(err, "failed to register topic validator")
+	}
+	topic, err := n.pubsub.Join(topicName)
 	if err != nil {
+		_ = n.pubsub.UnregisterTopicValidator(topicName)
 		return errors.Wrap(err, "failed to join to topic")
 	}
-	n.cfg.Topics[pubKey] = topic
+	if err := n.setTopicScoreParams(topicID, topic); err != nil {
+		n.logger.Warn("could not set topic score params", zap.String("topic", topicID), zap.Error(err))
+	}
+	n.cfg.Topics[topicID] = topic
 	return nil
 }
 
-// closeTopic closes the given topic
+// closeTopic closes the given topic and unregisters its validator
 func (n *p2pNetwork) closeTopic(topicName string) error {
 	pk := unwrapTopicName(topicName)
 	if t, ok := n.cfg.Topics[pk]; ok {
 		delete(n.cfg.Topics, pk)
-		return t.Close()
+		if err := t.Close(); err != nil {
+			return err
+		}
+		return n.pubsub.UnregisterTopicValidator(topicName)
 	}
 	return nil
 }
@@ -121,14 +196,20 @@ func (n *p2pNetwork) allPeersOfTopic(topic *pubsub.Topic) []string {
 	skippedPeers := map[string]bool{
 		n.cfg.ExporterPeerID: true,
 	}
+	// peers that proved their operator identity are placed first, so they will be preferred (e.g. in sync)
+	var unverified []string
 	for _, p := range topic.ListPeers() {
 		isValidNodeType := validateNodeType(n.peersIndex.GetPeerData, p)
 		if s := peerToString(p); !skippedPeers[s] && isValidNodeType {
-			ret = append(ret, peerToString(p))
+			if n.isVerifiedOperator(s) {
+				ret = append(ret, s)
+			} else {
+				unverified = append(unverified, s)
+			}
 		}
 	}
 
-	return ret
+	return append(ret, unverified...)
 }
 
 // listen listens on the given subscription
@@ -148,10 +229,13 @@ func (n *p2pNetwork) listen(ctx context.Context, sub *pubsub.Subscription) {
 				return
 			}
 			n.trace("received raw network msg", zap.ByteString("network.Message bytes", msg.Data))
-			cm, err := n.fork.DecodeNetworkMsg(msg.Data)
-			if err != nil {
-				n.logger.Error("failed to un-marshal message", zap.Error(err))
-				continue
+			// messages are decoded by the topic validator
+			cm, ok := msg.ValidatorData.(*network.Message)
+			if !ok {
+				if cm, err = n.fork.DecodeNetworkMsg(msg.Data); err != nil {
+					n.logger.Error("failed to un-marshal message", zap.Error(err))
+					continue
+				}
 			}
 			if n.reportLastMsg && len(msg.ReceivedFrom) > 0 {
 				reportLastMsg(msg.ReceivedFrom.String())
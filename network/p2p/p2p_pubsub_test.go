@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"context"
+	"github.com/bloxapp/ssv/network/forks"
+	networkForkV0 "github.com/bloxapp/ssv/network/forks/v0"
+	networkForkV2 "github.com/bloxapp/ssv/network/forks/v2"
+	"github.com/bloxapp/ssv/utils/threshold"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestP2pNetwork_SubscribeToValidatorNetwork(t *testing.T) {
+	threshold.Init()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fork := networkForkV2.New(networkForkV0.New(), 100, 1)
+	n := testPubsubNetwork(ctx, t, fork)
+
+	sk1, sk2 := &bls.SecretKey{}, &bls.SecretKey{}
+	sk1.SetByCSPRNG()
+	sk2.SetByCSPRNG()
+	pk1, pk2 := sk1.GetPublicKey(), sk2.GetPublicKey()
+
+	t.Run("topic per validator", func(t *testing.T) {
+		require.NoError(t, n.SubscribeToValidatorNetwork(pk1))
+		require.NoError(t, n.SubscribeToValidatorNetwork(pk2))
+		requireSubscribedTopics(t, n, pk1.SerializeToHexStr(), pk2.SerializeToHexStr())
+	})
+
+	t.Run("migration to subnets", func(t *testing.T) {
+		fork.SlotTick(100)
+		n.psTopicsLock.Lock()
+		require.NoError(t, n.syncSubscriptions())
+		n.psTopicsLock.Unlock()
+		requireSubscribedTopics(t, n, pk1.SerializeToHexStr(), pk2.SerializeToHexStr(), "subnet.0")
+		_, err := n.getTopic(pk1.Serialize())
+		require.NoError(t, err)
+
+		fork.SlotTick(132)
+		n.psTopicsLock.Lock()
+		require.NoError(t, n.syncSubscriptions())
+		n.psTopicsLock.Unlock()
+		requireSubscribedTopics(t, n, "subnet.0")
+	})
+
+	t.Run("shared subnet", func(t *testing.T) {
+		require.NoError(t, n.UnsubscribeFromValidatorNetwork(pk1))
+		requireSubscribedTopics(t, n, "subnet.0")
+		require.NoError(t, n.UnsubscribeFromValidatorNetwork(pk2))
+		requireSubscribedTopics(t, n)
+
+		// topics are closed once the listeners exit
+		time.Sleep(100 * time.Millisecond)
+		n.psTopicsLock.RLock()
+		defer n.psTopicsLock.RUnlock()
+		require.Len(t, n.cfg.Topics, 0)
+	})
+}
+
+func requireSubscribedTopics(t *testing.T, n *p2pNetwork, topics ...string) {
+	n.psTopicsLock.RLock()
+	defer n.psTopicsLock.RUnlock()
+
+	require.Len(t, n.psSubs, len(topics))
+	for _, topic := range topics {
+		require.Contains(t, n.psSubs, topic)
+	}
+}
+
+func testPubsubNetwork(ctx context.Context, t *testing.T, fork forks.Fork) *p2pNetwork {
+	host, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	ps, err := pubsub.NewGossipSub(ctx, host)
+	require.NoError(t, err)
+	return &p2pNetwork{
+		ctx:          ctx,
+		cfg:          &Config{Topics: make(map[string]*pubsub.Topic)},
+		logger:       zap.L(),
+		host:         host,
+		pubsub:       ps,
+		fork:         fork,
+		psSubs:       make(map[string]context.CancelFunc),
+		psValidators: make(map[string][]byte),
+		psTopicsLock: &sync.RWMutex{},
+	}
+}
@@ -33,3 +33,30 @@ func (n *p2pNetwork) ReceivedSignatureChan() (<-chan *proto.SignedMessage, func(
 
 	return ls.sigCh, n.registerListener(ls)
 }
+
+// BroadcastPreConsensusSignature broadcasts the given partial signature, required before consensus, for the given lambda
+func (n *p2pNetwork) BroadcastPreConsensusSignature(topicName []byte, msg *proto.SignedMessage) error {
+	msgBytes, err := n.fork.EncodeNetworkMsg(&network.Message{
+		SignedMessage: msg,
+		Type:          network.NetworkMsg_PreConsensusSignatureType,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal message")
+	}
+	topic, err := n.getTopic(topicName)
+	if err != nil {
+		return errors.Wrap(err, "failed to get topic")
+	}
+
+	n.logger.Debug("Broadcasting pre-consensus signature message", zap.String("lambda", string(msg.Message.Lambda)), zap.Any("topic", topic), zap.Any("peers", topic.ListPeers()))
+	return topic.Publish(n.ctx, msgBytes)
+}
+
+// ReceivedPreConsensusSignatureChan returns the channel with pre-consensus partial signatures
+func (n *p2pNetwork) ReceivedPreConsensusSignatureChan() (<-chan *proto.SignedMessage, func()) {
+	ls := listener{
+		preSigCh: make(chan *proto.SignedMessage, MsgChanSize),
+	}
+
+	return ls.preSigCh, n.registerListener(ls)
+}
@@ -30,6 +30,9 @@ func (n *p2pNetwork) setLegacyStreamHandler() {
 			n.logger.Error(" highest decided preStreamHandler failed", zap.Error(err))
 			return
 		}
+		if !n.allowSyncRequest(cm, s) {
+			return
+		}
 		n.propagateSyncMsg(cm, s)
 	})
 }
@@ -67,15 +67,18 @@ func (n *p2pNetwork) sendAndReadSyncResponse(peer peer.ID, protocol protocol.ID,
 
 	resByts, err := stream.ReadWithTimeout(n.cfg.RequestTimeout)
 	if err != nil {
+		n.reportOffense(peer, OffenseStreamTimeout)
 		return nil, errors.Wrap(err, "could not read sync msg")
 	}
 	resMsg, err := n.fork.DecodeNetworkMsg(resByts)
 	if err != nil {
+		n.reportOffense(peer, OffenseBadSyncResponse)
 		return nil, errors.Wrap(err, "could not decode stream sync msg")
 	}
 
 	//resMsg, ok := res.(network.Message)
 	if resMsg.SyncMessage == nil {
+		n.reportOffense(peer, OffenseBadSyncResponse)
 		return nil, errors.New("no response for sync request")
 	}
 	n.logger.Debug("got sync response",
@@ -25,6 +25,10 @@ func (v0 *testingFork) ValidatorTopicID(pkByts []byte) string {
 	return hex.EncodeToString(pkByts)
 }
 
+func (v0 *testingFork) ValidatorTopicIDs(pkByts []byte) []string {
+	return []string{v0.ValidatorTopicID(pkByts)}
+}
+
 func (v0 *testingFork) EncodeNetworkMsg(msg *network.Message) ([]byte, error) {
 	return json.Marshal(msg)
 }
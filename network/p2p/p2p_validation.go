@@ -0,0 +1,47 @@
+package p2p
+
+import (
+	"context"
+	"github.com/bloxapp/ssv/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"go.uber.org/zap"
+)
+
+// RegisterMsgValidator registers the validator of messages that are received on validator topics
+func (n *p2pNetwork) RegisterMsgValidator(validator network.MsgValidator) {
+	n.msgValidator.Store(validator)
+}
+
+// validateTopicMsg is the pubsub validator of validator topics, it decodes the message so it won't be decoded again
+// by the listener, and rejects invalid messages before they are propagated to other peers
+func (n *p2pNetwork) validateTopicMsg(ctx context.Context, pid peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	cm, err := n.fork.DecodeNetworkMsg(msg.Data)
+	if err != nil {
+		n.logger.Debug("rejecting message that could not be decoded", zap.String("peer", pid.String()), zap.Error(err))
+		n.reportOffense(pid, OffenseInvalidMessage)
+		return pubsub.ValidationReject
+	}
+	if cm == nil || cm.SignedMessage == nil || cm.SignedMessage.Message == nil {
+		n.logger.Debug("rejecting empty message", zap.String("peer", pid.String()))
+		n.reportOffense(pid, OffenseInvalidMessage)
+		return pubsub.ValidationReject
+	}
+	msg.ValidatorData = cm
+
+	validator, ok := n.msgValidator.Load().(network.MsgValidator)
+	if !ok || validator == nil {
+		return pubsub.ValidationAccept
+	}
+	switch validator(cm) {
+	case network.MsgValidationIgnore:
+		return pubsub.ValidationIgnore
+	case network.MsgValidationReject:
+		n.logger.Debug("rejecting invalid message", zap.String("peer", pid.String()),
+			zap.String("lambda", string(cm.SignedMessage.Message.Lambda)), zap.Int32("type", int32(cm.Type)))
+		n.reportOffense(pid, OffenseInvalidSignature)
+		return pubsub.ValidationReject
+	default:
+		return pubsub.ValidationAccept
+	}
+}
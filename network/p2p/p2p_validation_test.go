@@ -0,0 +1,63 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	networkForkV0 "github.com/bloxapp/ssv/network/forks/v0"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestP2pNetwork_ValidateTopicMsg(t *testing.T) {
+	n := &p2pNetwork{
+		logger: zap.L(),
+		fork:   networkForkV0.New(),
+	}
+	newMsg := func(t *testing.T, lambda string) *pubsub.Message {
+		data, err := json.Marshal(&network.Message{
+			SignedMessage: &proto.SignedMessage{Message: &proto.Message{Lambda: []byte(lambda)}},
+			Type:          network.NetworkMsg_IBFTType,
+		})
+		require.NoError(t, err)
+		return &pubsub.Message{Message: &pb.Message{Data: data}}
+	}
+
+	t.Run("invalid data", func(t *testing.T) {
+		msg := &pubsub.Message{Message: &pb.Message{Data: []byte("invalid")}}
+		require.Equal(t, pubsub.ValidationReject, n.validateTopicMsg(context.Background(), "", msg))
+	})
+
+	t.Run("empty message", func(t *testing.T) {
+		msg := &pubsub.Message{Message: &pb.Message{Data: []byte("{}")}}
+		require.Equal(t, pubsub.ValidationReject, n.validateTopicMsg(context.Background(), "", msg))
+	})
+
+	t.Run("no registered validator", func(t *testing.T) {
+		msg := newMsg(t, "xxx_ATTESTER")
+		require.Equal(t, pubsub.ValidationAccept, n.validateTopicMsg(context.Background(), "", msg))
+		cm, ok := msg.ValidatorData.(*network.Message)
+		require.True(t, ok)
+		require.Equal(t, []byte("xxx_ATTESTER"), cm.SignedMessage.Message.Lambda)
+	})
+
+	t.Run("registered validator", func(t *testing.T) {
+		n.RegisterMsgValidator(func(msg *network.Message) network.MsgValidationResult {
+			switch string(msg.SignedMessage.Message.Lambda) {
+			case "ignored":
+				return network.MsgValidationIgnore
+			case "rejected":
+				return network.MsgValidationReject
+			default:
+				return network.MsgValidationAccept
+			}
+		})
+		require.Equal(t, pubsub.ValidationAccept, n.validateTopicMsg(context.Background(), "", newMsg(t, "accepted")))
+		require.Equal(t, pubsub.ValidationIgnore, n.validateTopicMsg(context.Background(), "", newMsg(t, "ignored")))
+		require.Equal(t, pubsub.ValidationReject, n.validateTopicMsg(context.Background(), "", newMsg(t, "rejected")))
+	})
+}
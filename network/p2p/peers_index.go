@@ -23,6 +23,7 @@ type IndexData map[string]string
 type PeersIndex interface {
 	Run()
 	GetPeerData(pid, key string) string
+	SetPeerData(pid, key, value string)
 	IndexPeer(conn network.Conn)
 }
 
@@ -82,6 +83,19 @@ func (pi *peersIndex) GetPeerData(pid, key string) string {
 	return ""
 }
 
+// SetPeerData stores the given data of the peer, used to index data that is not reported by identify (e.g. handshake)
+func (pi *peersIndex) SetPeerData(pid, key, value string) {
+	pi.lock.Lock()
+	defer pi.lock.Unlock()
+
+	data, found := pi.index[pid]
+	if !found {
+		data = IndexData{}
+	}
+	data[key] = value
+	pi.index[pid] = data
+}
+
 // IndexPeer indexes the given peer / connection
 func (pi *peersIndex) IndexPeer(conn network.Conn) {
 	pi.lock.RLock()
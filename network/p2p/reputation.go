@@ -0,0 +1,302 @@
+package p2p
+
+import (
+	"encoding/json"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	reputationPrefix = []byte("p2p-reputation-")
+)
+
+const (
+	// reputationHalfLife is the time it takes for the offenses score of a peer to decay by half
+	reputationHalfLife = time.Hour
+	// banThreshold is the offenses score that causes a temporary ban of the peer
+	banThreshold = 100.0
+	// tempBanDuration is the duration of a temporary ban
+	tempBanDuration = time.Hour
+	// maxTempBans is the number of temporary bans after which the peer is banned permanently,
+	// only bans caused by offenses that escalate are counted (see Offense.escalates)
+	maxTempBans = 3
+)
+
+// Offense represents a misbehavior of a peer
+type Offense int32
+
+// Offenses that are recorded for peers
+const (
+	// OffenseInvalidMessage is a pubsub message that could not be decoded
+	OffenseInvalidMessage Offense = iota
+	// OffenseInvalidSignature is a pubsub message that was rejected by the message validator
+	OffenseInvalidSignature
+	// OffenseBadSyncResponse is a sync response that could not be decoded or is empty
+	OffenseBadSyncResponse
+	// OffenseStreamTimeout is a sync stream that failed or timed out
+	OffenseStreamTimeout
+	// OffenseRateLimitExceeded is a sync request that exceeded the rate limit
+	OffenseRateLimitExceeded
+)
+
+func (o Offense) String() string {
+	switch o {
+	case OffenseInvalidMessage:
+		return "invalid_message"
+	case OffenseInvalidSignature:
+		return "invalid_signature"
+	case OffenseBadSyncResponse:
+		return "bad_sync_response"
+	case OffenseStreamTimeout:
+		return "stream_timeout"
+	case OffenseRateLimitExceeded:
+		return "rate_limit_exceeded"
+	}
+	return "unknown"
+}
+
+// weight returns the score that is added to the peer for the offense
+func (o Offense) weight() float64 {
+	switch o {
+	case OffenseInvalidMessage:
+		return 20
+	case OffenseInvalidSignature:
+		return 25
+	case OffenseBadSyncResponse:
+		return 20
+	case OffenseStreamTimeout:
+		return 5
+	case OffenseRateLimitExceeded:
+		return 10
+	}
+	return 0
+}
+
+// escalates returns whether a ban caused by the offense counts towards a permanent ban,
+// stream timeouts might be caused by honest but slow peers therefore cause temporary bans only
+func (o Offense) escalates() bool {
+	return o != OffenseStreamTimeout
+}
+
+// PeerReputation holds the offenses record and ban status of a peer
+type PeerReputation struct {
+	PeerID string `json:"peer_id"`
+	// Score is the accumulated weight of the offenses, decays over time
+	Score     float64 `json:"score"`
+	UpdatedAt int64   `json:"updated_at"`
+	Offenses  uint64  `json:"offenses"`
+	TempBans  uint64  `json:"temp_bans"`
+	// BannedUntil is the unix time of the end of a temporary ban
+	BannedUntil  int64 `json:"banned_until"`
+	PermanentBan bool  `json:"permanent_ban"`
+}
+
+// IsBanned returns whether the peer is banned at the given time
+func (r *PeerReputation) IsBanned(now time.Time) bool {
+	return r.PermanentBan || r.BannedUntil > now.Unix()
+}
+
+// decay reduces the score according to the time that passed since the last update
+func (r *PeerReputation) decay(now time.Time) {
+	elapsed := now.Sub(time.Unix(r.UpdatedAt, 0))
+	if elapsed > 0 {
+		r.Score *= math.Pow(0.5, float64(elapsed)/float64(reputationHalfLife))
+	}
+	r.UpdatedAt = now.Unix()
+}
+
+// ReputationStore records offenses of peers and manages bans, the records are persisted across restarts
+type ReputationStore interface {
+	// ReportOffense records an offense of the given peer and bans it once the score reaches the threshold,
+	// protected peers (e.g. committee members) are never banned
+	ReportOffense(pid peer.ID, offense Offense, protected bool) (*PeerReputation, error)
+	// Ban bans the given peer for the given duration, a zero duration bans the peer permanently
+	Ban(pid peer.ID, duration time.Duration) error
+	// Unban removes the ban of the given peer and resets its score
+	Unban(pid peer.ID) error
+	// IsBanned returns whether the given peer is banned
+	IsBanned(pid peer.ID) bool
+	// GetAll returns the reputation of all the known peers
+	GetAll() ([]*PeerReputation, error)
+}
+
+type reputationStore struct {
+	db     basedb.IDb
+	logger *zap.Logger
+
+	lock  sync.RWMutex
+	cache map[peer.ID]*PeerReputation
+	now   func() time.Time
+}
+
+// NewReputationStore creates a new instance of ReputationStore, existing records are loaded from db
+func NewReputationStore(db basedb.IDb, logger *zap.Logger) (ReputationStore, error) {
+	rs := &reputationStore{
+		db:     db,
+		logger: logger.With(zap.String("who", "reputationStore")),
+		cache:  make(map[peer.ID]*PeerReputation),
+		now:    time.Now,
+	}
+	objs, err := db.GetAllByCollection(reputationPrefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read peers reputation")
+	}
+	for _, obj := range objs {
+		rep := &PeerReputation{}
+		if err := json.Unmarshal(obj.Value, rep); err != nil {
+			rs.logger.Warn("could not decode peer reputation", zap.ByteString("key", obj.Key), zap.Error(err))
+			continue
+		}
+		pid, err := peerFromString(rep.PeerID)
+		if err != nil {
+			rs.logger.Warn("could not decode peer id of reputation", zap.String("peer", rep.PeerID), zap.Error(err))
+			continue
+		}
+		rs.cache[pid] = rep
+	}
+	return rs, nil
+}
+
+func (rs *reputationStore) ReportOffense(pid peer.ID, offense Offense, protected bool) (*PeerReputation, error) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	now := rs.now()
+	rep := rs.get(pid)
+	rep.decay(now)
+	rep.Offenses++
+	if !protected && !rep.IsBanned(now) {
+		rep.Score += offense.weight()
+		if rep.Score >= banThreshold {
+			rep.Score = 0
+			if offense.escalates() {
+				rep.TempBans++
+			}
+			if rep.TempBans >= maxTempBans {
+				rep.PermanentBan = true
+			} else {
+				rep.BannedUntil = now.Add(tempBanDuration).Unix()
+			}
+		}
+	}
+	if err := rs.save(pid, rep); err != nil {
+		return nil, err
+	}
+	copied := *rep
+	return &copied, nil
+}
+
+func (rs *reputationStore) Ban(pid peer.ID, duration time.Duration) error {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	now := rs.now()
+	rep := rs.get(pid)
+	rep.decay(now)
+	if duration == 0 {
+		rep.PermanentBan = true
+	} else {
+		rep.BannedUntil = now.Add(duration).Unix()
+	}
+	return rs.save(pid, rep)
+}
+
+func (rs *reputationStore) Unban(pid peer.ID) error {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rep := rs.get(pid)
+	rep.UpdatedAt = rs.now().Unix()
+	rep.Score = 0
+	rep.TempBans = 0
+	rep.BannedUntil = 0
+	rep.PermanentBan = false
+	return rs.save(pid, rep)
+}
+
+func (rs *reputationStore) IsBanned(pid peer.ID) bool {
+	rs.lock.RLock()
+	defer rs.lock.RUnlock()
+
+	rep, ok := rs.cache[pid]
+	return ok && rep.IsBanned(rs.now())
+}
+
+func (rs *reputationStore) GetAll() ([]*PeerReputation, error) {
+	rs.lock.RLock()
+	defer rs.lock.RUnlock()
+
+	now := rs.now()
+	res := make([]*PeerReputation, 0, len(rs.cache))
+	for _, rep := range rs.cache {
+		copied := *rep
+		copied.decay(now)
+		res = append(res, &copied)
+	}
+	return res, nil
+}
+
+// get returns the reputation of the given peer, or a new one if not exist
+// this method is not thread-safe - should be called after lock was acquired
+func (rs *reputationStore) get(pid peer.ID) *PeerReputation {
+	rep, ok := rs.cache[pid]
+	if !ok {
+		rep = &PeerReputation{PeerID: peerToString(pid), UpdatedAt: rs.now().Unix()}
+	}
+	return rep
+}
+
+// save persists the given reputation and updates the cache
+// this method is not thread-safe - should be called after lock was acquired
+func (rs *reputationStore) save(pid peer.ID, rep *PeerReputation) error {
+	raw, err := json.Marshal(rep)
+	if err != nil {
+		return errors.Wrap(err, "could not encode peer reputation")
+	}
+	if err := rs.db.Set(reputationPrefix, []byte(rep.PeerID), raw); err != nil {
+		return errors.Wrap(err, "could not save peer reputation")
+	}
+	rs.cache[pid] = rep
+	return nil
+}
+
+// reportOffense records an offense of the given peer, and disconnects the peer if it was banned as a result.
+// committee peers are not banned automatically
+func (n *p2pNetwork) reportOffense(pid peer.ID, offense Offense) {
+	if n.reputation == nil || len(pid) == 0 {
+		return
+	}
+	metricsPeersOffenses.WithLabelValues(offense.String()).Inc()
+	rep, err := n.reputation.ReportOffense(pid, offense, n.isCommitteePeer(pid))
+	if err != nil {
+		n.logger.Warn("could not report peer offense", zap.String("peer", pid.String()),
+			zap.String("offense", offense.String()), zap.Error(err))
+		return
+	}
+	if rep.IsBanned(time.Now()) {
+		n.logger.Info("banned peer", zap.String("peer", pid.String()), zap.String("offense", offense.String()),
+			zap.Bool("permanent", rep.PermanentBan), zap.Uint64("offenses", rep.Offenses))
+		n.disconnectPeer(pid)
+	}
+}
+
+// isCommitteePeer returns true if the given peer proved its operator identity in handshake
+// and is a peer of one of the subscribed validator topics, i.e. a committee member of our validators
+func (n *p2pNetwork) isCommitteePeer(pid peer.ID) bool {
+	if !n.isVerifiedOperator(pid.String()) {
+		return false
+	}
+	for _, peers := range n.subscribedTopicsPeers() {
+		for _, p := range peers {
+			if p == pid {
+				return true
+			}
+		}
+	}
+	return false
+}
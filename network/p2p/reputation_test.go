@@ -0,0 +1,190 @@
+package p2p
+
+import (
+	"context"
+	"crypto/rand"
+	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func newTestReputationStore(t *testing.T, db basedb.IDb, now *time.Time) *reputationStore {
+	rs, err := NewReputationStore(db, zap.L())
+	require.NoError(t, err)
+	store := rs.(*reputationStore)
+	store.now = func() time.Time {
+		return *now
+	}
+	return store
+}
+
+func newTestPeerID(t *testing.T) peer.ID {
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(sk)
+	require.NoError(t, err)
+	return pid
+}
+
+func TestReputationStore_ReportOffense(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{Type: "badger-memory", Logger: zap.L(), Path: ""})
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1600000000, 0)
+	rs := newTestReputationStore(t, db, &now)
+	pid := newTestPeerID(t)
+
+	t.Run("offenses below threshold", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			rep, err := rs.ReportOffense(pid, OffenseInvalidSignature, false)
+			require.NoError(t, err)
+			require.False(t, rep.IsBanned(now))
+		}
+		require.False(t, rs.IsBanned(pid))
+	})
+
+	t.Run("score decays", func(t *testing.T) {
+		now = now.Add(reputationHalfLife)
+		reps, err := rs.GetAll()
+		require.NoError(t, err)
+		require.Len(t, reps, 1)
+		require.InDelta(t, 37.5, reps[0].Score, 0.01)
+		require.Equal(t, uint64(3), reps[0].Offenses)
+	})
+
+	t.Run("temporary ban", func(t *testing.T) {
+		var rep *PeerReputation
+		for i := 0; i < 3; i++ {
+			rep, err = rs.ReportOffense(pid, OffenseInvalidSignature, false)
+			require.NoError(t, err)
+		}
+		require.True(t, rep.IsBanned(now))
+		require.False(t, rep.PermanentBan)
+		require.Equal(t, uint64(1), rep.TempBans)
+		require.True(t, rs.IsBanned(pid))
+
+		now = now.Add(tempBanDuration + time.Second)
+		require.False(t, rs.IsBanned(pid))
+	})
+
+	t.Run("permanent ban after repeated temporary bans", func(t *testing.T) {
+		for ban := 1; ban < maxTempBans; ban++ {
+			for i := 0; i < 4; i++ {
+				_, err := rs.ReportOffense(pid, OffenseInvalidSignature, false)
+				require.NoError(t, err)
+			}
+			require.True(t, rs.IsBanned(pid))
+			now = now.Add(tempBanDuration + time.Second)
+		}
+		require.True(t, rs.IsBanned(pid))
+		reps, err := rs.GetAll()
+		require.NoError(t, err)
+		require.True(t, reps[0].PermanentBan)
+	})
+
+	t.Run("records are loaded from db", func(t *testing.T) {
+		loaded := newTestReputationStore(t, db, &now)
+		require.True(t, loaded.IsBanned(pid))
+		require.False(t, loaded.IsBanned(newTestPeerID(t)))
+	})
+
+	t.Run("unban", func(t *testing.T) {
+		require.NoError(t, rs.Unban(pid))
+		require.False(t, rs.IsBanned(pid))
+		reps, err := rs.GetAll()
+		require.NoError(t, err)
+		require.Equal(t, float64(0), reps[0].Score)
+		require.Equal(t, uint64(0), reps[0].TempBans)
+	})
+}
+
+func TestReputationStore_NonEscalatingOffenses(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{Type: "badger-memory", Logger: zap.L(), Path: ""})
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1600000000, 0)
+	rs := newTestReputationStore(t, db, &now)
+
+	t.Run("stream timeouts cause temporary bans only", func(t *testing.T) {
+		pid := newTestPeerID(t)
+		for ban := 0; ban < maxTempBans+1; ban++ {
+			var rep *PeerReputation
+			for !rs.IsBanned(pid) {
+				rep, err = rs.ReportOffense(pid, OffenseStreamTimeout, false)
+				require.NoError(t, err)
+			}
+			require.False(t, rep.PermanentBan)
+			require.Equal(t, uint64(0), rep.TempBans)
+			now = now.Add(tempBanDuration + time.Second)
+			require.False(t, rs.IsBanned(pid))
+		}
+	})
+
+	t.Run("protected peers are not banned", func(t *testing.T) {
+		pid := newTestPeerID(t)
+		for i := 0; i < 20; i++ {
+			rep, err := rs.ReportOffense(pid, OffenseInvalidSignature, true)
+			require.NoError(t, err)
+			require.False(t, rep.IsBanned(now))
+			require.Equal(t, uint64(i+1), rep.Offenses)
+		}
+		require.False(t, rs.IsBanned(pid))
+	})
+}
+
+func TestReputationStore_Ban(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{Type: "badger-memory", Logger: zap.L(), Path: ""})
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1600000000, 0)
+	rs := newTestReputationStore(t, db, &now)
+
+	pidA, pidB := newTestPeerID(t), newTestPeerID(t)
+	require.NoError(t, rs.Ban(pidA, time.Hour))
+	require.NoError(t, rs.Ban(pidB, 0))
+	require.True(t, rs.IsBanned(pidA))
+	require.True(t, rs.IsBanned(pidB))
+
+	now = now.Add(2 * time.Hour)
+	require.False(t, rs.IsBanned(pidA))
+	require.True(t, rs.IsBanned(pidB))
+}
+
+func TestConnectionGater(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{Type: "badger-memory", Logger: zap.L(), Path: ""})
+	require.NoError(t, err)
+	defer db.Close()
+	rs, err := NewReputationStore(db, zap.L())
+	require.NoError(t, err)
+
+	h1, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		libp2p.ConnectionGater(newConnectionGater(rs)))
+	require.NoError(t, err)
+	h2, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	h3, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+
+	require.NoError(t, rs.Ban(h2.ID(), 0))
+	// outbound connection with a banned peer
+	require.Error(t, h1.Connect(ctx, peer.AddrInfo{ID: h2.ID(), Addrs: h2.Addrs()}))
+	// inbound connection from a banned peer
+	_ = h2.Connect(ctx, peer.AddrInfo{ID: h1.ID(), Addrs: h1.Addrs()})
+	require.Eventually(t, func() bool {
+		return len(h1.Network().ConnsToPeer(h2.ID())) == 0
+	}, 2*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, h1.Connect(ctx, peer.AddrInfo{ID: h3.ID(), Addrs: h3.Addrs()}))
+}
@@ -0,0 +1,155 @@
+package p2p
+
+import (
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	mainTopicName = "main"
+
+	// slotDuration and epochDuration are used to define the decay periods of the scoring parameters
+	slotDuration  = 12 * time.Second
+	epochDuration = 32 * slotDuration
+
+	// scoreDecayInterval is the interval of score decaying
+	scoreDecayInterval = slotDuration
+	// decayToZero specifies the terminal value that is used when decaying a value
+	decayToZero = 0.01
+	// topicScoreCap caps the positive score that a peer can get from all topics
+	topicScoreCap = 32.72
+	// scoreInspectInterval is the interval of reporting peers scores
+	scoreInspectInterval = time.Minute
+
+	// maxInMeshScore is the max score a peer can get from being in the mesh of a topic
+	maxInMeshScore = 10
+	// firstMessageDeliveriesDecayPeriod is the period in which first deliveries score decays to zero
+	firstMessageDeliveriesDecayPeriod = 20 * epochDuration
+	// meshMessageDeliveriesDecayPeriod is the period in which mesh deliveries counter decays to zero
+	meshMessageDeliveriesDecayPeriod = 5 * epochDuration
+	// meshMessageDeliveriesActivation is the time a peer should be in the mesh before mesh deliveries are scored
+	meshMessageDeliveriesActivation = 3 * epochDuration
+	// meshMessageDeliveriesWindow is the time after the first delivery of a message, where a delivery of a mesh peer still counts
+	meshMessageDeliveriesWindow = 2 * time.Second
+	// invalidMessageDeliveriesDecayPeriod is the period in which invalid deliveries counter decays to zero
+	invalidMessageDeliveriesDecayPeriod = 50 * epochDuration
+	// behaviourPenaltyDecayPeriod is the period in which behaviour penalties (e.g. broken promises) decay to zero
+	behaviourPenaltyDecayPeriod = 10 * epochDuration
+	// retainScorePeriod is the time that the score of a disconnected peer is retained
+	retainScorePeriod = 100 * epochDuration
+)
+
+// ScoringConfig holds the configurable parameters of gossipsub peer scoring
+type ScoringConfig struct {
+	Enabled bool `yaml:"Enabled" env:"PEER_SCORING_ENABLED" env-default:"true" env-description:"enables gossipsub peer scoring"`
+
+	GossipThreshold             float64 `yaml:"GossipThreshold" env:"PEER_SCORE_GOSSIP_THRESHOLD" env-default:"-4000" env-description:"score below which gossip is not emitted to and accepted from the peer"`
+	PublishThreshold            float64 `yaml:"PublishThreshold" env:"PEER_SCORE_PUBLISH_THRESHOLD" env-default:"-8000" env-description:"score below which self published messages are not propagated to the peer"`
+	GraylistThreshold           float64 `yaml:"GraylistThreshold" env:"PEER_SCORE_GRAYLIST_THRESHOLD" env-default:"-16000" env-description:"score below which messages of the peer are ignored (graylisted)"`
+	AcceptPXThreshold           float64 `yaml:"AcceptPXThreshold" env:"PEER_SCORE_ACCEPT_PX_THRESHOLD" env-default:"100" env-description:"score above which peer exchange from the peer is accepted"`
+	OpportunisticGraftThreshold float64 `yaml:"OpportunisticGraftThreshold" env:"PEER_SCORE_OPPORTUNISTIC_GRAFT_THRESHOLD" env-default:"5" env-description:"median mesh score below which opportunistic grafting is triggered"`
+
+	IPColocationFactorWeight    float64 `yaml:"IPColocationFactorWeight" env:"PEER_SCORE_IP_COLOCATION_WEIGHT" env-default:"-35.11" env-description:"weight (negative) of the penalty of peers that share the same ip"`
+	IPColocationFactorThreshold int     `yaml:"IPColocationFactorThreshold" env:"PEER_SCORE_IP_COLOCATION_THRESHOLD" env-default:"10" env-description:"number of peers with the same ip that are not penalized"`
+
+	ValidatorTopicWeight float64 `yaml:"ValidatorTopicWeight" env:"PEER_SCORE_VALIDATOR_TOPIC_WEIGHT" env-default:"0.5" env-description:"weight of the score of validator topics"`
+	MainTopicWeight      float64 `yaml:"MainTopicWeight" env:"PEER_SCORE_MAIN_TOPIC_WEIGHT" env-default:"0.1" env-description:"weight of the score of the main topic"`
+
+	InvalidMessageDeliveriesWeight float64 `yaml:"InvalidMessageDeliveriesWeight" env:"PEER_SCORE_INVALID_MSG_WEIGHT" env-default:"-140" env-description:"weight (negative) of the penalty of invalid messages, squared by their count"`
+	FirstMessageDeliveriesWeight   float64 `yaml:"FirstMessageDeliveriesWeight" env:"PEER_SCORE_FIRST_MSG_WEIGHT" env-default:"1" env-description:"weight of messages that were first delivered by the peer"`
+	FirstMessageDeliveriesCap      float64 `yaml:"FirstMessageDeliveriesCap" env:"PEER_SCORE_FIRST_MSG_CAP" env-default:"40" env-description:"cap of the first deliveries counter"`
+	MeshMessageDeliveriesWeight    float64 `yaml:"MeshMessageDeliveriesWeight" env:"PEER_SCORE_MESH_MSG_WEIGHT" env-default:"0" env-description:"weight (negative) of the penalty of mesh peers that deliver less messages than the threshold, disabled by default as validator topics have low traffic"`
+	MeshMessageDeliveriesThreshold float64 `yaml:"MeshMessageDeliveriesThreshold" env:"PEER_SCORE_MESH_MSG_THRESHOLD" env-default:"4" env-description:"expected number of (decaying) messages that are delivered by a mesh peer"`
+	MeshMessageDeliveriesCapFactor float64 `yaml:"MeshMessageDeliveriesCapFactor" env:"PEER_SCORE_MESH_MSG_CAP_FACTOR" env-default:"4" env-description:"cap of mesh deliveries counter, as a factor of the threshold"`
+}
+
+// peerScoreParams returns the peer score params and thresholds of the given config,
+// topics params are set once topics are joined
+func peerScoreParams(cfg ScoringConfig) (*pubsub.PeerScoreParams, *pubsub.PeerScoreThresholds) {
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             cfg.GossipThreshold,
+		PublishThreshold:            cfg.PublishThreshold,
+		GraylistThreshold:           cfg.GraylistThreshold,
+		AcceptPXThreshold:           cfg.AcceptPXThreshold,
+		OpportunisticGraftThreshold: cfg.OpportunisticGraftThreshold,
+	}
+	params := &pubsub.PeerScoreParams{
+		Topics:        make(map[string]*pubsub.TopicScoreParams),
+		TopicScoreCap: topicScoreCap,
+		AppSpecificScore: func(p peer.ID) float64 {
+			return 0
+		},
+		AppSpecificWeight:           1,
+		IPColocationFactorWeight:    cfg.IPColocationFactorWeight,
+		IPColocationFactorThreshold: cfg.IPColocationFactorThreshold,
+		BehaviourPenaltyWeight:      -15.92,
+		BehaviourPenaltyThreshold:   6,
+		BehaviourPenaltyDecay:       scoreDecay(behaviourPenaltyDecayPeriod),
+		DecayInterval:               scoreDecayInterval,
+		DecayToZero:                 decayToZero,
+		RetainScore:                 retainScorePeriod,
+	}
+	return params, thresholds
+}
+
+// topicScoreParams returns the score params of the given topic
+func topicScoreParams(cfg ScoringConfig, topicID string) *pubsub.TopicScoreParams {
+	weight := cfg.ValidatorTopicWeight
+	if topicID == mainTopicName {
+		weight = cfg.MainTopicWeight
+	}
+	params := &pubsub.TopicScoreParams{
+		TopicWeight:                     weight,
+		TimeInMeshWeight:                maxInMeshScore / float64(epochDuration/slotDuration),
+		TimeInMeshQuantum:               slotDuration,
+		TimeInMeshCap:                   float64(epochDuration / slotDuration),
+		FirstMessageDeliveriesWeight:    cfg.FirstMessageDeliveriesWeight,
+		FirstMessageDeliveriesDecay:     scoreDecay(firstMessageDeliveriesDecayPeriod),
+		FirstMessageDeliveriesCap:       cfg.FirstMessageDeliveriesCap,
+		InvalidMessageDeliveriesWeight:  cfg.InvalidMessageDeliveriesWeight,
+		InvalidMessageDeliveriesDecay:   scoreDecay(invalidMessageDeliveriesDecayPeriod),
+		MeshMessageDeliveriesWindow:     meshMessageDeliveriesWindow,
+		MeshMessageDeliveriesActivation: meshMessageDeliveriesActivation,
+	}
+	if cfg.MeshMessageDeliveriesWeight != 0 {
+		params.MeshMessageDeliveriesWeight = cfg.MeshMessageDeliveriesWeight
+		params.MeshMessageDeliveriesDecay = scoreDecay(meshMessageDeliveriesDecayPeriod)
+		params.MeshMessageDeliveriesThreshold = cfg.MeshMessageDeliveriesThreshold
+		params.MeshMessageDeliveriesCap = cfg.MeshMessageDeliveriesThreshold * cfg.MeshMessageDeliveriesCapFactor
+		params.MeshFailurePenaltyWeight = cfg.MeshMessageDeliveriesWeight
+		params.MeshFailurePenaltyDecay = scoreDecay(meshMessageDeliveriesDecayPeriod)
+	}
+	return params
+}
+
+// setTopicScoreParams sets the score params of the given topic if peer scoring is enabled
+func (n *p2pNetwork) setTopicScoreParams(topicID string, topic *pubsub.Topic) error {
+	if !n.cfg.PeerScoring.Enabled {
+		return nil
+	}
+	if err := topic.SetScoreParams(topicScoreParams(n.cfg.PeerScoring, topicID)); err != nil {
+		return errors.Wrap(err, "could not set topic score params")
+	}
+	return nil
+}
+
+// inspectPeersScores reports the scores of the known peers, and keeps them for connections management
+func (n *p2pNetwork) inspectPeersScores(scores map[peer.ID]float64) {
+	n.peersScoresLock.Lock()
+	n.peersScores = scores
+	n.peersScoresLock.Unlock()
+
+	metricsPeersScores.Reset()
+	for pid, score := range scores {
+		metricsPeersScores.WithLabelValues(pid.String()).Set(score)
+	}
+	n.logger.Debug("peers scores were inspected", zap.Int("count", len(scores)))
+}
+
+// scoreDecay returns the decay factor that decays a counter to zero within the given period
+func scoreDecay(period time.Duration) float64 {
+	return pubsub.ScoreParameterDecayWithBase(period, scoreDecayInterval, decayToZero)
+}
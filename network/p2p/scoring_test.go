@@ -0,0 +1,70 @@
+package p2p
+
+import (
+	"context"
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"math"
+	"testing"
+)
+
+func TestPeerScoreParams(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var cfg ScoringConfig
+	require.NoError(t, cleanenv.ReadEnv(&cfg))
+	require.True(t, cfg.Enabled)
+	require.Equal(t, float64(-16000), cfg.GraylistThreshold)
+
+	host, err := libp2p.New(ctx, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	params, thresholds := peerScoreParams(cfg)
+	ps, err := pubsub.NewGossipSub(ctx, host, pubsub.WithPeerScore(params, thresholds))
+	require.NoError(t, err)
+
+	t.Run("validator topic", func(t *testing.T) {
+		topic, err := ps.Join(getTopicName("subnet.1"))
+		require.NoError(t, err)
+		require.NoError(t, topic.SetScoreParams(topicScoreParams(cfg, "subnet.1")))
+		require.Equal(t, cfg.ValidatorTopicWeight, topicScoreParams(cfg, "subnet.1").TopicWeight)
+	})
+
+	t.Run("main topic", func(t *testing.T) {
+		topic, err := ps.Join(getTopicName(mainTopicName))
+		require.NoError(t, err)
+		require.NoError(t, topic.SetScoreParams(topicScoreParams(cfg, mainTopicName)))
+		require.Equal(t, cfg.MainTopicWeight, topicScoreParams(cfg, mainTopicName).TopicWeight)
+	})
+
+	t.Run("mesh deliveries", func(t *testing.T) {
+		meshCfg := cfg
+		meshCfg.MeshMessageDeliveriesWeight = -1
+		topic, err := ps.Join(getTopicName("subnet.2"))
+		require.NoError(t, err)
+		params := topicScoreParams(meshCfg, "subnet.2")
+		require.NoError(t, topic.SetScoreParams(params))
+		require.Equal(t, float64(16), params.MeshMessageDeliveriesCap)
+	})
+}
+
+func TestScoreDecay(t *testing.T) {
+	decay := scoreDecay(epochDuration)
+	ticks := float64(epochDuration / scoreDecayInterval)
+	require.InDelta(t, decayToZero, math.Pow(decay, ticks), 0.0001)
+}
+
+func TestP2pNetwork_InspectPeersScores(t *testing.T) {
+	n := &p2pNetwork{logger: zap.L()}
+	n.inspectPeersScores(map[peer.ID]float64{"a": -100, "b": 5})
+	require.Equal(t, float64(-100), testutil.ToFloat64(metricsPeersScores.WithLabelValues(peer.ID("a").String())))
+	require.Equal(t, float64(5), testutil.ToFloat64(metricsPeersScores.WithLabelValues(peer.ID("b").String())))
+
+	n.inspectPeersScores(map[peer.ID]float64{"b": 6})
+	require.Equal(t, 1, testutil.CollectAndCount(metricsPeersScores))
+}
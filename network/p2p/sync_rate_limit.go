@@ -0,0 +1,145 @@
+package p2p
+
+import (
+	"github.com/bloxapp/ssv/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/async"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"sync"
+	"time"
+)
+
+const (
+	// syncRateLimitExceededError is the error that is returned to peers that exceeded the rate limit
+	syncRateLimitExceededError = "rate limit exceeded"
+	// syncLimitersCleanupInterval is the interval for removing limiters of inactive peers
+	syncLimitersCleanupInterval = 5 * time.Minute
+	// syncLimitersIdleTimeout is the time after which the limiters of an inactive peer are removed
+	syncLimitersIdleTimeout = 10 * time.Minute
+)
+
+// SyncRateLimitConfig holds the configurable parameters of sync requests rate limiting,
+// limits are applied per peer and per request type
+type SyncRateLimitConfig struct {
+	Enabled bool `yaml:"Enabled" env:"SYNC_RATE_LIMIT_ENABLED" env-default:"true" env-description:"enables rate limiting of sync requests"`
+
+	HighestDecidedRate  float64 `yaml:"HighestDecidedRate" env:"SYNC_RATE_LIMIT_HIGHEST_DECIDED" env-default:"10" env-description:"allowed highest decided requests per second of a single peer"`
+	HighestDecidedBurst int     `yaml:"HighestDecidedBurst" env:"SYNC_RATE_LIMIT_HIGHEST_DECIDED_BURST" env-default:"200" env-description:"max burst of highest decided requests of a single peer"`
+	DecidedByRangeRate  float64 `yaml:"DecidedByRangeRate" env:"SYNC_RATE_LIMIT_DECIDED_BY_RANGE" env-default:"5" env-description:"allowed decided by range requests per second of a single peer"`
+	DecidedByRangeBurst int     `yaml:"DecidedByRangeBurst" env:"SYNC_RATE_LIMIT_DECIDED_BY_RANGE_BURST" env-default:"100" env-description:"max burst of decided by range requests of a single peer"`
+	ChangeRoundRate     float64 `yaml:"ChangeRoundRate" env:"SYNC_RATE_LIMIT_CHANGE_ROUND" env-default:"10" env-description:"allowed last change round requests per second of a single peer"`
+	ChangeRoundBurst    int     `yaml:"ChangeRoundBurst" env:"SYNC_RATE_LIMIT_CHANGE_ROUND_BURST" env-default:"200" env-description:"max burst of last change round requests of a single peer"`
+}
+
+// peerLimiters holds the token buckets of a single peer
+type peerLimiters struct {
+	limiters map[network.Sync]*rate.Limiter
+	lastSeen time.Time
+}
+
+// syncRateLimiter limits the sync requests of peers using token buckets per peer and per request type
+type syncRateLimiter struct {
+	cfg   SyncRateLimitConfig
+	lock  sync.Mutex
+	peers map[peer.ID]*peerLimiters
+	now   func() time.Time
+}
+
+// newSyncRateLimiter creates a new instance of syncRateLimiter
+func newSyncRateLimiter(cfg SyncRateLimitConfig) *syncRateLimiter {
+	return &syncRateLimiter{
+		cfg:   cfg,
+		peers: make(map[peer.ID]*peerLimiters),
+		now:   time.Now,
+	}
+}
+
+// allow returns whether the given peer is allowed to make a request of the given type
+func (rl *syncRateLimiter) allow(pid peer.ID, t network.Sync) bool {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	now := rl.now()
+	pl, ok := rl.peers[pid]
+	if !ok {
+		pl = &peerLimiters{limiters: make(map[network.Sync]*rate.Limiter)}
+		rl.peers[pid] = pl
+	}
+	pl.lastSeen = now
+	limiter, ok := pl.limiters[t]
+	if !ok {
+		limiter = rl.newLimiter(t)
+		pl.limiters[t] = limiter
+	}
+	return limiter.AllowN(now, 1)
+}
+
+// newLimiter creates a token bucket for the given request type
+func (rl *syncRateLimiter) newLimiter(t network.Sync) *rate.Limiter {
+	switch t {
+	case network.Sync_GetHighestType:
+		return rate.NewLimiter(rate.Limit(rl.cfg.HighestDecidedRate), rl.cfg.HighestDecidedBurst)
+	case network.Sync_GetInstanceRange:
+		return rate.NewLimiter(rate.Limit(rl.cfg.DecidedByRangeRate), rl.cfg.DecidedByRangeBurst)
+	case network.Sync_GetLatestChangeRound:
+		return rate.NewLimiter(rate.Limit(rl.cfg.ChangeRoundRate), rl.cfg.ChangeRoundBurst)
+	}
+	// unknown request types are not served anyway
+	return rate.NewLimiter(rate.Inf, 0)
+}
+
+// cleanup removes the limiters of peers that were not seen recently
+func (rl *syncRateLimiter) cleanup() {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	now := rl.now()
+	for pid, pl := range rl.peers {
+		if now.Sub(pl.lastSeen) > syncLimitersIdleTimeout {
+			delete(rl.peers, pid)
+		}
+	}
+}
+
+// watchSyncRateLimiter cleans up the limiters of inactive peers periodically
+func (n *p2pNetwork) watchSyncRateLimiter() {
+	if n.syncLimiter == nil {
+		return
+	}
+	async.RunEvery(n.ctx, syncLimitersCleanupInterval, n.syncLimiter.cleanup)
+}
+
+// allowSyncRequest checks the rate limit of the given sync request, requests that exceeded the limit
+// are answered with an error and the peer is reported
+func (n *p2pNetwork) allowSyncRequest(cm *network.Message, s network.SyncStream) bool {
+	if n.syncLimiter == nil || cm.SyncMessage == nil {
+		return true
+	}
+	pid, err := peerFromString(s.RemotePeer())
+	if err != nil {
+		return true
+	}
+	t := cm.SyncMessage.Type
+	if n.syncLimiter.allow(pid, t) {
+		return true
+	}
+	metricsSyncRateLimited.WithLabelValues(t.String()).Inc()
+	n.logger.Debug("sync request exceeded rate limit", zap.String("peer", pid.String()),
+		zap.String("type", t.String()))
+	n.reportOffense(pid, OffenseRateLimitExceeded)
+
+	res := &network.SyncMessage{
+		Lambda:     cm.SyncMessage.Lambda,
+		Type:       t,
+		Error:      syncRateLimitExceededError,
+		FromPeerID: n.host.ID().Pretty(),
+	}
+	if _, err := n.sendSyncMessage(s, "", legacyMsgStream, res); err != nil {
+		n.logger.Debug("could not send rate limit response", zap.Error(err))
+	}
+	if err := s.Close(); err != nil {
+		n.logger.Debug("could not close stream", zap.Error(err))
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package p2p
+
+import (
+	"context"
+	"github.com/bloxapp/ssv/network"
+	v0 "github.com/bloxapp/ssv/network/forks/v0"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testSyncRateLimitConfig() SyncRateLimitConfig {
+	return SyncRateLimitConfig{
+		Enabled:             true,
+		HighestDecidedRate:  1,
+		HighestDecidedBurst: 3,
+		DecidedByRangeRate:  1,
+		DecidedByRangeBurst: 1,
+		ChangeRoundRate:     1,
+		ChangeRoundBurst:    2,
+	}
+}
+
+func TestSyncRateLimiter_Allow(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	rl := newSyncRateLimiter(testSyncRateLimitConfig())
+	rl.now = func() time.Time {
+		return now
+	}
+	pidA, pidB := peer.ID("peer-a"), peer.ID("peer-b")
+
+	for i := 0; i < 3; i++ {
+		require.True(t, rl.allow(pidA, network.Sync_GetHighestType))
+	}
+	require.False(t, rl.allow(pidA, network.Sync_GetHighestType))
+	// other request types and other peers have their own buckets
+	require.True(t, rl.allow(pidA, network.Sync_GetInstanceRange))
+	require.False(t, rl.allow(pidA, network.Sync_GetInstanceRange))
+	require.True(t, rl.allow(pidB, network.Sync_GetHighestType))
+
+	// tokens are refilled over time
+	now = now.Add(2 * time.Second)
+	require.True(t, rl.allow(pidA, network.Sync_GetHighestType))
+	require.True(t, rl.allow(pidA, network.Sync_GetHighestType))
+	require.False(t, rl.allow(pidA, network.Sync_GetHighestType))
+
+	// limiters of inactive peers are removed
+	now = now.Add(syncLimitersIdleTimeout - time.Second)
+	require.True(t, rl.allow(pidB, network.Sync_GetLatestChangeRound))
+	now = now.Add(2 * time.Second)
+	rl.cleanup()
+	require.Len(t, rl.peers, 1)
+	_, ok := rl.peers[pidB]
+	require.True(t, ok)
+}
+
+func TestP2pNetwork_SyncRateLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := testHandshakeNetwork(ctx, t, Operator)
+	server.fork = v0.New()
+	server.listeners = map[string]listener{}
+	server.listenersLock = &sync.RWMutex{}
+	server.syncLimiter = newSyncRateLimiter(testSyncRateLimitConfig())
+	server.setLegacyStreamHandler()
+
+	syncCh, done := server.ReceivedSyncMsgChan()
+	defer done()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-syncCh:
+				res := &network.SyncMessage{Lambda: msg.Msg.Lambda, Type: msg.Msg.Type, Params: msg.Msg.Params}
+				_ = server.RespondToHighestDecidedInstance(msg.Stream, res)
+			}
+		}
+	}()
+
+	client := testHandshakeNetwork(ctx, t, Operator)
+	client.fork = v0.New()
+	require.NoError(t, client.host.Connect(ctx, peer.AddrInfo{ID: server.host.ID(), Addrs: server.host.Addrs()}))
+
+	req := &network.SyncMessage{Lambda: []byte("lambda"), Type: network.Sync_GetLatestChangeRound}
+	for i := 0; i < 2; i++ {
+		res, err := client.sendAndReadSyncResponse(server.host.ID(), legacyMsgStream, req)
+		require.NoError(t, err)
+		require.Empty(t, res.SyncMessage.Error)
+	}
+	res, err := client.sendAndReadSyncResponse(server.host.ID(), legacyMsgStream, req)
+	require.NoError(t, err)
+	require.Equal(t, syncRateLimitExceededError, res.SyncMessage.Error)
+	require.Equal(t, network.Sync_GetLatestChangeRound, res.SyncMessage.Type)
+}
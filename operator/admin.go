@@ -0,0 +1,115 @@
+package operator
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/validator"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// VoluntaryExitsPath is the path of voluntary exits in the admin api
+const VoluntaryExitsPath = "/validators/exits"
+
+// AdminAuthHeader is the header that holds the token of admin requests, as "Bearer <token>"
+const AdminAuthHeader = "Authorization"
+
+// VoluntaryExitRequest is the body of a request to approve a voluntary exit,
+// the current epoch is used if the epoch was not provided
+type VoluntaryExitRequest struct {
+	PubKey string  `json:"public_key"`
+	Epoch  *uint64 `json:"epoch,omitempty"`
+}
+
+// adminHandler handles admin requests of the operator node
+type adminHandler struct {
+	logger         *zap.Logger
+	ethNetwork     core.Network
+	validatorsCtrl validator.IController
+	token          string
+}
+
+// NewAdminHandler creates a new handler for admin requests,
+// requests are authenticated with the given token which must not be empty
+func NewAdminHandler(logger *zap.Logger, ethNetwork core.Network, validatorsCtrl validator.IController, token string) (http.Handler, error) {
+	if len(token) == 0 {
+		return nil, errors.New("admin api token is missing")
+	}
+	ah := &adminHandler{
+		logger:         logger.With(zap.String("component", "operator/admin")),
+		ethNetwork:     ethNetwork,
+		validatorsCtrl: validatorsCtrl,
+		token:          token,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(VoluntaryExitsPath, ah.authenticated(ah.handleVoluntaryExits))
+	return mux, nil
+}
+
+// authenticated wraps the given handler, rejects requests without the token of the admin api
+func (ah *adminHandler) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		token := strings.TrimPrefix(req.Header.Get(AdminAuthHeader), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(ah.token)) != 1 {
+			ah.logger.Warn("unauthorized admin request", zap.String("remote", req.RemoteAddr), zap.String("path", req.URL.Path))
+			http.Error(res, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler(res, req)
+	}
+}
+
+// handleVoluntaryExits lists the voluntary exit requests (GET) or approves a voluntary exit (POST)
+func (ah *adminHandler) handleVoluntaryExits(res http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		ah.writeJSON(res, ah.validatorsCtrl.GetVoluntaryExits())
+	case http.MethodPost:
+		var exitReq VoluntaryExitRequest
+		if err := json.NewDecoder(req.Body).Decode(&exitReq); err != nil {
+			http.Error(res, "could not parse request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(exitReq.PubKey) == 0 {
+			http.Error(res, "public key is missing", http.StatusBadRequest)
+			return
+		}
+		currentEpoch := spec.Epoch(ah.ethNetwork.EstimatedCurrentEpoch())
+		epoch := currentEpoch
+		if exitReq.Epoch != nil {
+			epoch = spec.Epoch(*exitReq.Epoch)
+		}
+		if epoch > currentEpoch {
+			http.Error(res, "epoch is ahead of the current epoch", http.StatusBadRequest)
+			return
+		}
+		logger := ah.logger.With(zap.String("pubKey", exitReq.PubKey), zap.Uint64("epoch", uint64(epoch)))
+		logger.Info("approving voluntary exit")
+		if err := ah.validatorsCtrl.ApproveVoluntaryExit(exitReq.PubKey, epoch); err != nil {
+			logger.Warn("could not approve voluntary exit", zap.Error(err))
+			http.Error(res, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, exit := range ah.validatorsCtrl.GetVoluntaryExits() {
+			if exit.PubKey == exitReq.PubKey && exit.Epoch == uint64(epoch) {
+				ah.writeJSON(res, exit)
+				return
+			}
+		}
+		ah.writeJSON(res, nil)
+	default:
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (ah *adminHandler) writeJSON(res http.ResponseWriter, data interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(res).Encode(data); err != nil {
+		ah.logger.Error("could not write response", zap.Error(err))
+	}
+}
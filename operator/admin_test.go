@@ -0,0 +1,122 @@
+package operator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/validator"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type exitsCtrlMock struct {
+	validator.IController
+	exits []*validator.VoluntaryExit
+}
+
+func (c *exitsCtrlMock) ApproveVoluntaryExit(pubKey string, epoch spec.Epoch) error {
+	if pubKey != "aa" {
+		return errors.New("validator not found")
+	}
+	c.exits = append(c.exits, &validator.VoluntaryExit{PubKey: pubKey, Epoch: uint64(epoch), Signers: []uint64{1}, Approved: true})
+	return nil
+}
+
+func (c *exitsCtrlMock) GetVoluntaryExits() []*validator.VoluntaryExit {
+	return c.exits
+}
+
+func TestAdminHandler_VoluntaryExits(t *testing.T) {
+	ethNetwork := core.PraterNetwork
+	ctrl := &exitsCtrlMock{}
+	_, err := NewAdminHandler(zap.L(), ethNetwork, ctrl, "")
+	require.Error(t, err)
+	handler, err := NewAdminHandler(zap.L(), ethNetwork, ctrl, "secret")
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	send := func(method string, body string, token string) *http.Response {
+		req, err := http.NewRequest(method, server.URL+VoluntaryExitsPath, bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if len(token) > 0 {
+			req.Header.Set(AdminAuthHeader, "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	approve := func(body string) (*http.Response, *validator.VoluntaryExit) {
+		resp := send(http.MethodPost, body, "secret")
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusOK {
+			return resp, nil
+		}
+		exit := &validator.VoluntaryExit{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(exit))
+		return resp, exit
+	}
+
+	t.Run("approve", func(t *testing.T) {
+		resp, exit := approve(`{"public_key":"aa","epoch":100}`)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, uint64(100), exit.Epoch)
+		require.True(t, exit.Approved)
+	})
+
+	t.Run("approve at current epoch", func(t *testing.T) {
+		current := uint64(ethNetwork.EstimatedCurrentEpoch())
+		resp, exit := approve(`{"public_key":"aa"}`)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.GreaterOrEqual(t, exit.Epoch, current)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		resp, _ := approve(`{"epoch":100}`)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp, _ = approve(`{"public_key":"bb","epoch":100}`)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp, _ = approve(`not json`)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("future epoch", func(t *testing.T) {
+		future := uint64(ethNetwork.EstimatedCurrentEpoch()) + 10
+		resp, _ := approve(fmt.Sprintf(`{"public_key":"aa","epoch":%d}`, future))
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		for _, token := range []string{"", "wrong"} {
+			resp := send(http.MethodPost, `{"public_key":"aa","epoch":100}`, token)
+			_ = resp.Body.Close()
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			resp = send(http.MethodGet, "", token)
+			_ = resp.Body.Close()
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		resp := send(http.MethodGet, "", "secret")
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var exits []*validator.VoluntaryExit
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&exits))
+		require.Len(t, exits, 2)
+		require.Equal(t, uint64(100), exits[0].Epoch)
+	})
+}
@@ -124,6 +124,10 @@ func (dc *dutyController) listenToTicker(slots <-chan types.Slot) {
 		for i := range duties {
 			go dc.onDuty(&duties[i])
 		}
+		// fetch the duties of the next epoch in advance, so subnets are subscribed early
+		if err := dc.fetcher.LookaheadDuties(uint64(currentSlot)); err != nil {
+			dc.logger.Warn("failed to fetch duties of next epoch", zap.Error(err))
+		}
 	}
 }
 
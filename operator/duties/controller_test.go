@@ -99,3 +99,7 @@ type fetcherMock struct {
 func (f *fetcherMock) GetDuties(slot uint64) ([]beacon.Duty, error) {
 	return f.results[types.Slot(slot)], nil
 }
+
+func (f *fetcherMock) LookaheadDuties(slot uint64) error {
+	return nil
+}
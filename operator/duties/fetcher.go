@@ -11,14 +11,55 @@ import (
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"go.uber.org/zap"
+	"sync"
 	"time"
 )
 
+const (
+	// fetchReasonMiss is the reason of fetching duties that were not found in cache
+	fetchReasonMiss = "miss"
+	// fetchReasonLookahead is the reason of fetching the duties of the next epoch ahead of time
+	fetchReasonLookahead = "lookahead"
+	// fetchReasonReorg is the reason of fetching duties that were evicted due to a change of dependent root
+	fetchReasonReorg = "reorg"
+	// fetchReasonProposers is the reason of fetching the proposer duties of an epoch that was fetched ahead of time
+	fetchReasonProposers = "proposers"
+
+	// lookaheadRetrySlots is the number of slots to wait before retrying a failed lookahead
+	lookaheadRetrySlots = 4
+)
+
 // cacheEntry
 type cacheEntry struct {
 	Duties []beacon.Duty
 }
 
+// dependentRoots holds the roots that the duties of an epoch depend on,
+// a change of those roots (reorg) means that the duties might have changed
+type dependentRoots struct {
+	attester spec.Root
+	proposer spec.Root
+}
+
+// resolve compares the given known roots with the roots that the duties were fetched with, returns false if any of them has changed.
+// roots that were not known when the duties were fetched (e.g. the proposer root of a lookahead epoch) are pending,
+// they are set once known and compared from then on
+func (r *dependentRoots) resolve(known dependentRoots) bool {
+	return resolveRoot(&r.attester, known.attester) && resolveRoot(&r.proposer, known.proposer)
+}
+
+// resolveRoot sets the fetched root if it is pending, returns false if it has changed
+func resolveRoot(fetched *spec.Root, known spec.Root) bool {
+	if known == (spec.Root{}) {
+		return true
+	}
+	if *fetched == (spec.Root{}) {
+		*fetched = known
+		return true
+	}
+	return *fetched == known
+}
+
 // validatorsIndicesFetcher represents the interface for retrieving indices.
 // It have a minimal interface instead of working with the complete validator.IController interface
 type validatorsIndicesFetcher interface {
@@ -29,24 +70,40 @@ type validatorsIndicesFetcher interface {
 type beaconDutiesClient interface {
 	// GetDuties returns duties for the passed validators indices
 	GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error)
+	// GetLookaheadDuties returns the attester, aggregator and sync committee duties for the passed validators indices
+	GetLookaheadDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error)
+	// GetProposerDuties returns the proposer duties for the passed validators indices
+	GetProposerDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error)
 	// SubscribeToCommitteeSubnet subscribe committee to subnet (p2p topic)
 	SubscribeToCommitteeSubnet(subscription []*eth2apiv1.BeaconCommitteeSubscription) error
+	// SubscribeToSyncCommitteeSubnet subscribe sync committee members to their subnets (p2p topics)
+	SubscribeToSyncCommitteeSubnet(subscriptions []*eth2apiv1.SyncCommitteeSubscription) error
+	// SubscribeToHeadEvents registers a handler for the head events of the beacon node
+	SubscribeToHeadEvents(handler func(event *eth2apiv1.HeadEvent))
 }
 
 // DutyFetcher represents the component that manages duties
 type DutyFetcher interface {
 	GetDuties(slot uint64) ([]beacon.Duty, error)
+	// LookaheadDuties fetches the duties of the epoch after the given slot's epoch, if not fetched yet
+	LookaheadDuties(slot uint64) error
 }
 
 // newDutyFetcher creates a new instance
 func newDutyFetcher(logger *zap.Logger, beaconClient beaconDutiesClient, indicesFetcher validatorsIndicesFetcher, network core.Network) DutyFetcher {
+	// duties of the next epoch are fetched in advance, therefore the cache keeps duties of 2 epochs (~13 minutes)
 	df := dutyFetcher{
-		logger:         logger.With(zap.String("component", "operator/dutyFetcher")),
-		ethNetwork:     network,
-		beaconClient:   beaconClient,
-		indicesFetcher: indicesFetcher,
-		cache:          cache.New(time.Minute*12, time.Minute*13),
+		logger:            logger.With(zap.String("component", "operator/dutyFetcher")),
+		ethNetwork:        network,
+		beaconClient:      beaconClient,
+		indicesFetcher:    indicesFetcher,
+		cache:             cache.New(time.Minute*15, time.Minute*16),
+		knownRoots:        make(map[spec.Epoch]dependentRoots),
+		fetchedEpochs:     make(map[spec.Epoch]dependentRoots),
+		proposersPending:  make(map[spec.Epoch]bool),
+		lookaheadAttempts: make(map[spec.Epoch]uint64),
 	}
+	beaconClient.SubscribeToHeadEvents(df.onHeadEvent)
 	return &df
 }
 
@@ -58,6 +115,16 @@ type dutyFetcher struct {
 	indicesFetcher validatorsIndicesFetcher
 
 	cache *cache.Cache
+
+	rootsLock sync.Mutex
+	// knownRoots holds the latest dependent roots of epochs, as reported in head events
+	knownRoots map[spec.Epoch]dependentRoots
+	// fetchedEpochs holds the epochs that their duties were fetched, with the dependent roots known at that time
+	fetchedEpochs map[spec.Epoch]dependentRoots
+	// proposersPending holds the epochs that were fetched ahead of time, their proposer duties are fetched once the epoch starts
+	proposersPending map[spec.Epoch]bool
+	// lookaheadAttempts holds the slot of the last lookahead attempt of epochs
+	lookaheadAttempts map[spec.Epoch]uint64
 }
 
 // GetDuties tries to get slot's duties from cache, if not available in cache it fetches them from beacon
@@ -69,12 +136,18 @@ func (df *dutyFetcher) GetDuties(slot uint64) ([]beacon.Duty, error) {
 	epoch := spec.Epoch(esEpoch)
 	logger := df.logger.With(zap.Uint64("slot", slot), zap.Uint64("epoch", uint64(epoch)))
 	start := time.Now()
+	if df.isProposersPending(epoch) {
+		if err := df.updateProposerDutiesFromBeacon(epoch); err != nil {
+			logger.Warn("failed to get proposer duties", zap.Error(err))
+		}
+	}
 	cacheKey := getDutyCacheKey(slot)
 	if raw, exist := df.cache.Get(cacheKey); exist {
 		duties = raw.(cacheEntry).Duties
 	} else {
 		// epoch's duties does not exist in cache -> fetch
-		if err := df.updateDutiesFromBeacon(slot); err != nil {
+		metricsDutiesCacheMisses.Inc()
+		if err := df.updateDutiesFromBeacon(slot, false, fetchReasonMiss); err != nil {
 			logger.Error("failed to get duties", zap.Error(err))
 			return nil, err
 		}
@@ -91,9 +164,61 @@ func (df *dutyFetcher) GetDuties(slot uint64) ([]beacon.Duty, error) {
 	return duties, nil
 }
 
-// updateDutiesFromBeacon will be called once in an epoch to update the cache with all the epoch's slots
-func (df *dutyFetcher) updateDutiesFromBeacon(slot uint64) error {
-	duties, err := df.fetchDuties(slot)
+// LookaheadDuties fetches the duties of the next epoch, so the relevant subnets are subscribed ahead of time.
+// proposer duties are not fetched ahead of time as beacon nodes might reject such requests, they are fetched once the epoch starts.
+// a failed lookahead is retried after lookaheadRetrySlots slots
+func (df *dutyFetcher) LookaheadDuties(slot uint64) error {
+	nextEpoch := spec.Epoch(df.ethNetwork.EstimatedEpochAtSlot(types.Slot(slot))) + 1
+	df.rootsLock.Lock()
+	_, fetched := df.fetchedEpochs[nextEpoch]
+	lastAttempt, attempted := df.lookaheadAttempts[nextEpoch]
+	shouldFetch := !fetched && (!attempted || slot >= lastAttempt+lookaheadRetrySlots)
+	if shouldFetch {
+		df.lookaheadAttempts[nextEpoch] = slot
+	}
+	df.rootsLock.Unlock()
+	if !shouldFetch {
+		return nil
+	}
+	return df.updateDutiesFromBeacon(uint64(nextEpoch)*df.ethNetwork.SlotsPerEpoch(), true, fetchReasonLookahead)
+}
+
+// isProposersPending returns whether the given epoch was fetched ahead of time, and its proposer duties were not fetched yet
+func (df *dutyFetcher) isProposersPending(epoch spec.Epoch) bool {
+	df.rootsLock.Lock()
+	defer df.rootsLock.Unlock()
+
+	return df.proposersPending[epoch]
+}
+
+// updateProposerDutiesFromBeacon fetches the proposer duties of an epoch that was fetched ahead of time, and adds them to the cache
+func (df *dutyFetcher) updateProposerDutiesFromBeacon(epoch spec.Epoch) error {
+	indices := df.indicesFetcher.GetValidatorsIndices()
+	if len(indices) == 0 {
+		return nil
+	}
+	start := time.Now()
+	duties, err := df.beaconClient.GetProposerDuties(epoch, indices)
+	metricsDutiesFetchDuration.WithLabelValues(fetchReasonProposers).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return errors.Wrap(err, "failed to get proposer duties from beacon")
+	}
+	df.rootsLock.Lock()
+	delete(df.proposersPending, epoch)
+	df.rootsLock.Unlock()
+	if len(duties) == 0 {
+		return nil
+	}
+	df.logger.Debug("got proposer duties", zap.Uint64("epoch", uint64(epoch)), zap.Int("count", len(duties)))
+	return df.processFetchedDuties(duties)
+}
+
+// updateDutiesFromBeacon will be called once in an epoch to update the cache with all the epoch's slots,
+// lookahead means that the epoch didn't start yet, therefore its proposer duties are not fetched
+func (df *dutyFetcher) updateDutiesFromBeacon(slot uint64, lookahead bool, reason string) error {
+	start := time.Now()
+	duties, err := df.fetchDuties(slot, lookahead)
+	metricsDutiesFetchDuration.WithLabelValues(reason).Observe(time.Since(start).Seconds())
 	if err != nil {
 		return errors.Wrap(err, "failed to get duties from beacon")
 	}
@@ -114,33 +239,151 @@ func (df *dutyFetcher) updateDutiesFromBeacon(slot uint64) error {
 	return nil
 }
 
-// fetchDuties fetches duties for the epoch of the given slot
-func (df *dutyFetcher) fetchDuties(slot uint64) ([]*beacon.Duty, error) {
+// fetchDuties fetches duties for the epoch of the given slot, without proposer duties in case of lookahead
+func (df *dutyFetcher) fetchDuties(slot uint64, lookahead bool) ([]*beacon.Duty, error) {
 	if indices := df.indicesFetcher.GetValidatorsIndices(); len(indices) > 0 {
 		df.logger.Debug("got indices for existing validators",
 			zap.Int("count", len(indices)), zap.Any("indices", indices))
 		esEpoch := df.ethNetwork.EstimatedEpochAtSlot(types.Slot(slot))
 		epoch := spec.Epoch(esEpoch)
-		results, err := df.beaconClient.GetDuties(epoch, indices)
-		return results, err
+		// the roots are taken before fetching, so a change during the fetch will be detected later
+		df.rootsLock.Lock()
+		roots := df.knownRoots[epoch]
+		df.rootsLock.Unlock()
+		var results []*beacon.Duty
+		var err error
+		if lookahead {
+			results, err = df.beaconClient.GetLookaheadDuties(epoch, indices)
+		} else {
+			results, err = df.beaconClient.GetDuties(epoch, indices)
+		}
+		if err != nil {
+			return nil, err
+		}
+		df.rootsLock.Lock()
+		df.fetchedEpochs[epoch] = roots
+		if lookahead {
+			df.proposersPending[epoch] = true
+		} else {
+			delete(df.proposersPending, epoch)
+		}
+		df.rootsLock.Unlock()
+		return results, nil
 	}
 	df.logger.Debug("no indices, duties won't be fetched")
 	return []*beacon.Duty{}, nil
 }
 
+// onHeadEvent tracks the dependent roots of duties, and re-fetches the duties of epochs that their dependent root has changed.
+// the previous duty dependent root of an epoch is the root of attester duties in that epoch,
+// while the current duty dependent root is the root of proposer duties in that epoch and attester duties in the next epoch
+func (df *dutyFetcher) onHeadEvent(event *eth2apiv1.HeadEvent) {
+	epoch := spec.Epoch(df.ethNetwork.EstimatedEpochAtSlot(types.Slot(event.Slot)))
+
+	df.rootsLock.Lock()
+	df.knownRoots[epoch] = dependentRoots{
+		attester: event.PreviousDutyDependentRoot,
+		proposer: event.CurrentDutyDependentRoot,
+	}
+	next := df.knownRoots[epoch+1]
+	next.attester = event.CurrentDutyDependentRoot
+	df.knownRoots[epoch+1] = next
+
+	var stale []spec.Epoch
+	for _, e := range []spec.Epoch{epoch, epoch + 1} {
+		fetchedRoots, ok := df.fetchedEpochs[e]
+		if !ok {
+			continue
+		}
+		if !fetchedRoots.resolve(df.knownRoots[e]) {
+			delete(df.fetchedEpochs, e)
+			stale = append(stale, e)
+			continue
+		}
+		df.fetchedEpochs[e] = fetchedRoots
+	}
+	var old []spec.Epoch
+	for e := range df.fetchedEpochs {
+		if e+1 < epoch {
+			delete(df.fetchedEpochs, e)
+			old = append(old, e)
+		}
+	}
+	for e := range df.knownRoots {
+		if e+1 < epoch {
+			delete(df.knownRoots, e)
+		}
+	}
+	for e := range df.lookaheadAttempts {
+		if e+1 < epoch {
+			delete(df.lookaheadAttempts, e)
+		}
+	}
+	for e := range df.proposersPending {
+		if e+1 < epoch {
+			delete(df.proposersPending, e)
+		}
+	}
+	df.rootsLock.Unlock()
+
+	for _, e := range old {
+		df.evictEpoch(e)
+	}
+	for _, e := range stale {
+		metricsDutiesReorgs.Inc()
+		df.logger.Debug("duties dependent root has changed, re-fetching duties",
+			zap.Uint64("epoch", uint64(e)), zap.Uint64("head_slot", uint64(event.Slot)))
+		df.evictEpoch(e)
+		go func(e spec.Epoch) {
+			// the duties of the next epoch are re-fetched without proposer duties, as in lookahead
+			if err := df.updateDutiesFromBeacon(uint64(e)*df.ethNetwork.SlotsPerEpoch(), e > epoch, fetchReasonReorg); err != nil {
+				df.logger.Warn("failed to re-fetch duties", zap.Uint64("epoch", uint64(e)), zap.Error(err))
+			}
+		}(e)
+	}
+}
+
+// evictEpoch removes the duties of the given epoch from cache
+func (df *dutyFetcher) evictEpoch(epoch spec.Epoch) {
+	firstSlot := uint64(epoch) * df.ethNetwork.SlotsPerEpoch()
+	for i := uint64(0); i < df.ethNetwork.SlotsPerEpoch(); i++ {
+		df.cache.Delete(getDutyCacheKey(firstSlot + i))
+	}
+}
+
 // processFetchedDuties loop over fetched duties and process them
 func (df *dutyFetcher) processFetchedDuties(fetchedDuties []*beacon.Duty) error {
 	if len(fetchedDuties) > 0 {
 		var subscriptions []*eth2apiv1.BeaconCommitteeSubscription
+		// sync committee membership is the same for all the slots of the epoch, subscribing once per validator
+		syncSubscriptions := map[spec.ValidatorIndex]*eth2apiv1.SyncCommitteeSubscription{}
 		// entries holds all the new duties to add
 		entries := map[spec.Slot]cacheEntry{}
 		for _, duty := range fetchedDuties {
 			df.fillEntry(entries, duty)
-			subscriptions = append(subscriptions, toSubscription(duty))
+			switch duty.Type {
+			case beacon.RoleTypeAttester:
+				subscriptions = append(subscriptions, toSubscription(duty))
+			case beacon.RoleTypeSyncCommittee:
+				if _, exist := syncSubscriptions[duty.ValidatorIndex]; !exist {
+					syncSubscriptions[duty.ValidatorIndex] = df.toSyncCommitteeSubscription(duty)
+				}
+			}
 		}
 		df.populateCache(entries)
-		if err := df.beaconClient.SubscribeToCommitteeSubnet(subscriptions); err != nil {
-			df.logger.Warn("failed to subscribe committee to subnet", zap.Error(err))
+		if len(subscriptions) > 0 {
+			if err := df.beaconClient.SubscribeToCommitteeSubnet(subscriptions); err != nil {
+				df.logger.Warn("failed to subscribe committee to subnet", zap.Error(err))
+			}
+		}
+		if len(syncSubscriptions) > 0 {
+			var toSubscribe []*eth2apiv1.SyncCommitteeSubscription
+			for _, subscription := range syncSubscriptions {
+				toSubscribe = append(toSubscribe, subscription)
+			}
+			if err := df.beaconClient.SubscribeToSyncCommitteeSubnet(toSubscribe); err != nil {
+				df.logger.Warn("failed to subscribe sync committee to subnet", zap.Error(err))
+			}
 		}
 	}
 	return nil
@@ -167,7 +410,7 @@ func (df *dutyFetcher) populateCache(entriesToAdd map[spec.Slot]cacheEntry) {
 			for _, newDuty := range e.Duties {
 				exist := false
 				for _, existDuty := range existingEntry.Duties {
-					if newDuty.ValidatorIndex == existDuty.ValidatorIndex {
+					if newDuty.ValidatorIndex == existDuty.ValidatorIndex && newDuty.Type == existDuty.Type {
 						exist = true
 						break // already exist, pass
 					}
@@ -224,6 +467,17 @@ func toSubscription(duty *beacon.Duty) *eth2apiv1.BeaconCommitteeSubscription {
 	}
 }
 
+// toSyncCommitteeSubscription returns a subscription to the sync committee subnets of the duty, until the end of its sync committee period
+func (df *dutyFetcher) toSyncCommitteeSubscription(duty *beacon.Duty) *eth2apiv1.SyncCommitteeSubscription {
+	epoch := df.ethNetwork.EstimatedEpochAtSlot(types.Slot(duty.Slot))
+	period := beacon.SyncCommitteePeriod(spec.Epoch(epoch))
+	return &eth2apiv1.SyncCommitteeSubscription{
+		ValidatorIndex:       duty.ValidatorIndex,
+		SyncCommitteeIndices: duty.ValidatorSyncCommitteeIndices,
+		UntilEpoch:           spec.Epoch((period + 1) * beacon.EpochsPerSyncCommitteePeriod),
+	}
+}
+
 type serializedDuty struct {
 	PubKey                  string
 	Type                    string
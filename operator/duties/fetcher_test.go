@@ -8,7 +8,9 @@ import (
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestDutyFetcher_GetDuties(t *testing.T) {
@@ -64,6 +66,60 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 		require.Len(t, duties, 1)
 	})
 
+	t.Run("serves attester and proposer duties of the same validator", func(t *testing.T) {
+		fetchedDuties := []*beacon.Duty{
+			{
+				Type:           beacon.RoleTypeAttester,
+				Slot:           893108,
+				ValidatorIndex: 205238,
+				PubKey:         spec.BLSPubKey{},
+			},
+			{
+				Type:           beacon.RoleTypeProposer,
+				Slot:           893108,
+				ValidatorIndex: 205238,
+				PubKey:         spec.BLSPubKey{},
+			},
+		}
+		bcMock := beaconDutiesClientMock{duties: fetchedDuties}
+		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}},
+			core.PraterNetwork)
+		duties, err := dm.GetDuties(893108)
+		require.NoError(t, err)
+		require.Len(t, duties, 2)
+		// fetching again should not duplicate any of the duties
+		require.NoError(t, dm.(*dutyFetcher).processFetchedDuties(fetchedDuties))
+		duties, err = dm.GetDuties(893108)
+		require.NoError(t, err)
+		require.Len(t, duties, 2)
+	})
+
+	t.Run("subscribes sync committee members once per validator", func(t *testing.T) {
+		var fetchedDuties []*beacon.Duty
+		for _, slot := range []spec.Slot{893108, 893109} {
+			for _, role := range []beacon.RoleType{beacon.RoleTypeSyncCommittee, beacon.RoleTypeSyncCommitteeContribution} {
+				fetchedDuties = append(fetchedDuties, &beacon.Duty{
+					Type:                          role,
+					Slot:                          slot,
+					ValidatorIndex:                205238,
+					ValidatorSyncCommitteeIndices: []spec.CommitteeIndex{3, 300},
+				})
+			}
+		}
+		bcMock := beaconDutiesClientMock{duties: fetchedDuties}
+		dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}},
+			core.PraterNetwork)
+		duties, err := dm.GetDuties(893108)
+		require.NoError(t, err)
+		require.Len(t, duties, 2)
+		require.False(t, bcMock.subscribed)
+		require.Len(t, bcMock.syncSubscriptions, 1)
+		require.EqualValues(t, 205238, bcMock.syncSubscriptions[0].ValidatorIndex)
+		require.Equal(t, []spec.CommitteeIndex{3, 300}, bcMock.syncSubscriptions[0].SyncCommitteeIndices)
+		// slot 893108 is in epoch 27909, the end of sync committee period 109
+		require.EqualValues(t, 110*beacon.EpochsPerSyncCommitteePeriod, bcMock.syncSubscriptions[0].UntilEpoch)
+	})
+
 	t.Run("handles no indices", func(t *testing.T) {
 		fetchedDuties := []*beacon.Duty{
 			{
@@ -80,6 +136,200 @@ func TestDutyFetcher_GetDuties(t *testing.T) {
 	})
 }
 
+func TestDutyFetcher_LookaheadDuties(t *testing.T) {
+	// slot 893108 is in epoch 27909, the next epoch starts at slot 893120
+	bcMock := beaconDutiesClientMock{duties: []*beacon.Duty{
+		{
+			Type:           beacon.RoleTypeAttester,
+			Slot:           893125,
+			ValidatorIndex: 205238,
+			PubKey:         spec.BLSPubKey{},
+		},
+	}}
+	dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}}, core.PraterNetwork)
+
+	require.NoError(t, dm.LookaheadDuties(893108))
+	require.Equal(t, []spec.Epoch{27910}, bcMock.getFetchedEpochs())
+	require.Equal(t, []spec.Epoch{27910}, bcMock.getLookaheadEpochs())
+	require.True(t, bcMock.subscribed)
+
+	// already fetched
+	require.NoError(t, dm.LookaheadDuties(893110))
+	require.Len(t, bcMock.getFetchedEpochs(), 1)
+
+	// duties of the next epoch are served from cache
+	duties, err := dm.GetDuties(893125)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	duties, err = dm.GetDuties(893120)
+	require.NoError(t, err)
+	require.Len(t, duties, 0)
+	require.Len(t, bcMock.getFetchedEpochs(), 1)
+
+	// failures are not considered as fetched, but are retried only after a few slots
+	bcMock.getDutiesErr = errors.New("test duties")
+	require.Error(t, dm.LookaheadDuties(893120))
+	require.NoError(t, dm.LookaheadDuties(893121))
+	require.Equal(t, []spec.Epoch{27910, 27911}, bcMock.getFetchedEpochs())
+	require.Error(t, dm.LookaheadDuties(893124))
+	require.Equal(t, []spec.Epoch{27910, 27911, 27911}, bcMock.getFetchedEpochs())
+}
+
+func TestDutyFetcher_LookaheadProposerDuties(t *testing.T) {
+	// slot 893108 is in epoch 27909, the next epoch starts at slot 893120
+	bcMock := beaconDutiesClientMock{duties: []*beacon.Duty{
+		{
+			Type:           beacon.RoleTypeAttester,
+			Slot:           893125,
+			ValidatorIndex: 205238,
+			PubKey:         spec.BLSPubKey{},
+		},
+		{
+			Type:           beacon.RoleTypeProposer,
+			Slot:           893125,
+			ValidatorIndex: 205238,
+			PubKey:         spec.BLSPubKey{},
+		},
+	}}
+	dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}}, core.PraterNetwork)
+
+	// proposer duties are not fetched ahead of time
+	require.NoError(t, dm.LookaheadDuties(893108))
+	require.Equal(t, []spec.Epoch{27910}, bcMock.getLookaheadEpochs())
+	require.Len(t, bcMock.getProposerEpochs(), 0)
+
+	// proposer duties are fetched once the epoch starts, failures are retried on the next slot
+	bcMock.getProposersErr = errors.New("test proposer duties")
+	duties, err := dm.GetDuties(893120)
+	require.NoError(t, err)
+	require.Len(t, duties, 0)
+	require.Equal(t, []spec.Epoch{27910}, bcMock.getProposerEpochs())
+
+	bcMock.getProposersErr = nil
+	duties, err = dm.GetDuties(893121)
+	require.NoError(t, err)
+	require.Len(t, duties, 0)
+	require.Equal(t, []spec.Epoch{27910, 27910}, bcMock.getProposerEpochs())
+
+	duties, err = dm.GetDuties(893125)
+	require.NoError(t, err)
+	require.Len(t, duties, 2)
+	require.Len(t, bcMock.getProposerEpochs(), 2)
+	require.Len(t, bcMock.getFetchedEpochs(), 1)
+
+	// epochs that were not fetched ahead of time are fetched with their proposer duties
+	duties, err = dm.GetDuties(893130 + 32)
+	require.NoError(t, err)
+	require.Len(t, duties, 0)
+	require.Equal(t, []spec.Epoch{27910, 27911}, bcMock.getFetchedEpochs())
+	require.Equal(t, []spec.Epoch{27910}, bcMock.getLookaheadEpochs())
+	require.Len(t, bcMock.getProposerEpochs(), 2)
+}
+
+func TestDutyFetcher_DependentRootChange(t *testing.T) {
+	rootA, rootB, rootC := spec.Root{0xa}, spec.Root{0xb}, spec.Root{0xc}
+	attesterDuty := func(slot spec.Slot, committeeIndex spec.CommitteeIndex) *beacon.Duty {
+		return &beacon.Duty{
+			Type:           beacon.RoleTypeAttester,
+			Slot:           slot,
+			ValidatorIndex: 205238,
+			CommitteeIndex: committeeIndex,
+			PubKey:         spec.BLSPubKey{},
+		}
+	}
+	bcMock := beaconDutiesClientMock{duties: []*beacon.Duty{attesterDuty(893108, 1)}}
+	dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}}, core.PraterNetwork)
+	require.NotNil(t, bcMock.headHandler)
+
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893104, PreviousDutyDependentRoot: rootA, CurrentDutyDependentRoot: rootB})
+	duties, err := dm.GetDuties(893108)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.EqualValues(t, 1, duties[0].CommitteeIndex)
+
+	// same roots, nothing to re-fetch
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893105, PreviousDutyDependentRoot: rootA, CurrentDutyDependentRoot: rootB})
+	require.Len(t, bcMock.getFetchedEpochs(), 1)
+
+	// the dependent root of attester duties has changed -> duties are evicted and re-fetched
+	bcMock.setDuties([]*beacon.Duty{attesterDuty(893110, 2)})
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893106, PreviousDutyDependentRoot: rootC, CurrentDutyDependentRoot: rootB})
+	require.Eventually(t, func() bool {
+		return len(bcMock.getFetchedEpochs()) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		duties, err := dm.GetDuties(893110)
+		return err == nil && len(duties) == 1 && duties[0].CommitteeIndex == 2
+	}, time.Second, 10*time.Millisecond)
+	duties, err = dm.GetDuties(893108)
+	require.NoError(t, err)
+	require.Len(t, duties, 0)
+	require.Equal(t, []spec.Epoch{27909, 27909}, bcMock.getFetchedEpochs())
+
+	// the current dependent root is the root of proposer duties and next epoch's attester duties
+	bcMock.setDuties([]*beacon.Duty{attesterDuty(893125, 3)})
+	require.NoError(t, dm.LookaheadDuties(893108))
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893107, PreviousDutyDependentRoot: rootC, CurrentDutyDependentRoot: rootA})
+	require.Eventually(t, func() bool {
+		return len(bcMock.getFetchedEpochs()) == 5
+	}, time.Second, 10*time.Millisecond)
+	require.ElementsMatch(t, []spec.Epoch{27909, 27910}, bcMock.getFetchedEpochs()[3:])
+	df := dm.(*dutyFetcher)
+	require.Eventually(t, func() bool {
+		df.rootsLock.Lock()
+		defer df.rootsLock.Unlock()
+		return len(df.fetchedEpochs) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// duties of old epochs are evicted
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893184, PreviousDutyDependentRoot: rootA, CurrentDutyDependentRoot: rootB})
+	df.rootsLock.Lock()
+	require.Len(t, df.fetchedEpochs, 0)
+	df.rootsLock.Unlock()
+	_, exist := df.cache.Get(getDutyCacheKey(893110))
+	require.False(t, exist)
+}
+
+func TestDutyFetcher_LookaheadEpochBoundary(t *testing.T) {
+	rootA, rootB, rootC, rootD := spec.Root{0xa}, spec.Root{0xb}, spec.Root{0xc}, spec.Root{0xd}
+	bcMock := beaconDutiesClientMock{duties: []*beacon.Duty{
+		{
+			Type:           beacon.RoleTypeAttester,
+			Slot:           893125,
+			ValidatorIndex: 205238,
+			PubKey:         spec.BLSPubKey{},
+		},
+	}}
+	dm := newDutyFetcher(zap.L(), &bcMock, &indicesFetcher{[]spec.ValidatorIndex{205238}}, core.PraterNetwork)
+	df := dm.(*dutyFetcher)
+
+	// the proposer root of the next epoch is not known when its duties are fetched ahead of time
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893108, PreviousDutyDependentRoot: rootA, CurrentDutyDependentRoot: rootB})
+	require.NoError(t, dm.LookaheadDuties(893108))
+	require.Equal(t, []spec.Epoch{27910}, bcMock.getFetchedEpochs())
+
+	// crossing the epoch boundary without a reorg
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893120, PreviousDutyDependentRoot: rootB, CurrentDutyDependentRoot: rootC})
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893121, PreviousDutyDependentRoot: rootB, CurrentDutyDependentRoot: rootC})
+	require.Never(t, func() bool {
+		return len(bcMock.getFetchedEpochs()) > 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+	duties, err := dm.GetDuties(893125)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.Len(t, bcMock.getFetchedEpochs(), 1)
+	df.rootsLock.Lock()
+	require.Equal(t, dependentRoots{attester: rootB, proposer: rootC}, df.fetchedEpochs[27910])
+	df.rootsLock.Unlock()
+
+	// the proposer root is compared once known
+	bcMock.headHandler(&eth2apiv1.HeadEvent{Slot: 893122, PreviousDutyDependentRoot: rootB, CurrentDutyDependentRoot: rootD})
+	require.Eventually(t, func() bool {
+		return len(bcMock.getFetchedEpochs()) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, spec.Epoch(27910), bcMock.getFetchedEpochs()[1])
+}
+
 func TestDutyFetcher_AddMissingSlots(t *testing.T) {
 	df := dutyFetcher{
 		logger:     zap.L(),
@@ -118,19 +368,103 @@ func (f *indicesFetcher) GetValidatorsIndices() []spec.ValidatorIndex {
 }
 
 type beaconDutiesClientMock struct {
+	lock              sync.Mutex
 	duties            []*beacon.Duty
 	getDutiesErr      error
+	fetchedEpochs     []spec.Epoch
+	lookaheadEpochs   []spec.Epoch
+	proposerEpochs    []spec.Epoch
+	getProposersErr   error
 	subToCommitteeErr error
 	subscribed        bool
+	syncSubscriptions []*eth2apiv1.SyncCommitteeSubscription
+	headHandler       func(event *eth2apiv1.HeadEvent)
 }
 
 // GetDuties returns duties for the passed validators indices
 func (bc *beaconDutiesClientMock) GetDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	bc.fetchedEpochs = append(bc.fetchedEpochs, epoch)
 	return bc.duties, bc.getDutiesErr
 }
 
+// GetLookaheadDuties returns the duties of the mock except for proposer duties
+func (bc *beaconDutiesClientMock) GetLookaheadDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	bc.fetchedEpochs = append(bc.fetchedEpochs, epoch)
+	bc.lookaheadEpochs = append(bc.lookaheadEpochs, epoch)
+	var duties []*beacon.Duty
+	for _, duty := range bc.duties {
+		if duty.Type != beacon.RoleTypeProposer {
+			duties = append(duties, duty)
+		}
+	}
+	return duties, bc.getDutiesErr
+}
+
+// GetProposerDuties returns the proposer duties of the mock
+func (bc *beaconDutiesClientMock) GetProposerDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	bc.proposerEpochs = append(bc.proposerEpochs, epoch)
+	var duties []*beacon.Duty
+	for _, duty := range bc.duties {
+		if duty.Type == beacon.RoleTypeProposer {
+			duties = append(duties, duty)
+		}
+	}
+	return duties, bc.getProposersErr
+}
+
+func (bc *beaconDutiesClientMock) getLookaheadEpochs() []spec.Epoch {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	return append([]spec.Epoch{}, bc.lookaheadEpochs...)
+}
+
+func (bc *beaconDutiesClientMock) getProposerEpochs() []spec.Epoch {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	return append([]spec.Epoch{}, bc.proposerEpochs...)
+}
+
+func (bc *beaconDutiesClientMock) setDuties(duties []*beacon.Duty) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	bc.duties = duties
+}
+
+func (bc *beaconDutiesClientMock) getFetchedEpochs() []spec.Epoch {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	return append([]spec.Epoch{}, bc.fetchedEpochs...)
+}
+
+// SubscribeToHeadEvents registers a handler for the head events of the beacon node
+func (bc *beaconDutiesClientMock) SubscribeToHeadEvents(handler func(event *eth2apiv1.HeadEvent)) {
+	bc.headHandler = handler
+}
+
 // SubscribeToCommitteeSubnet subscribe committee to subnet (p2p topic)
 func (bc *beaconDutiesClientMock) SubscribeToCommitteeSubnet(subscription []*eth2apiv1.BeaconCommitteeSubscription) error {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
 	bc.subscribed = true
 	return bc.subToCommitteeErr
 }
+
+// SubscribeToSyncCommitteeSubnet subscribe sync committee members to their subnets (p2p topics)
+func (bc *beaconDutiesClientMock) SubscribeToSyncCommitteeSubnet(subscriptions []*eth2apiv1.SyncCommitteeSubscription) error {
+	bc.syncSubscriptions = append(bc.syncSubscriptions, subscriptions...)
+	return nil
+}
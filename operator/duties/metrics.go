@@ -0,0 +1,35 @@
+package duties
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"log"
+)
+
+var (
+	metricsDutiesFetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ssv:operator:duties:fetch_duration_seconds",
+		Help:    "Duration of fetching duties from beacon node by the reason of fetching (miss / lookahead / reorg / proposers)",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"reason"})
+	metricsDutiesCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:operator:duties:cache_misses",
+		Help: "Count slots that their duties were not found in cache",
+	})
+	metricsDutiesReorgs = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:operator:duties:dependent_root_changes",
+		Help: "Count epochs that their duties were evicted due to a change of dependent root",
+	})
+)
+
+func init() {
+	if err := prometheus.Register(metricsDutiesFetchDuration); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsDutiesCacheMisses); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsDutiesReorgs); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
@@ -150,5 +150,8 @@ func (n *operatorNode) healthAgents() []metrics.HealthCheckAgent {
 	if agent, ok := n.beacon.(metrics.HealthCheckAgent); ok {
 		agents = append(agents, agent)
 	}
+	if agent, ok := n.validatorsCtrl.(metrics.HealthCheckAgent); ok {
+		agents = append(agents, agent)
+	}
 	return agents
 }
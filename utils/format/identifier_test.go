@@ -9,6 +9,18 @@ func TestIdentifierUnformat(t *testing.T) {
 	pk, role := IdentifierUnformat("xxx_ATTESTER")
 	require.Equal(t, "xxx", pk)
 	require.Equal(t, "ATTESTER", role)
+
+	pk, role = IdentifierUnformat("xxx_PROPOSER")
+	require.Equal(t, "xxx", pk)
+	require.Equal(t, "PROPOSER", role)
+
+	pk, role = IdentifierUnformat("xxx_SYNC_COMMITTEE")
+	require.Equal(t, "xxx", pk)
+	require.Equal(t, "SYNC_COMMITTEE", role)
+
+	pk, role = IdentifierUnformat("xxx_SYNC_COMMITTEE_CONTRIBUTION")
+	require.Equal(t, "xxx", pk)
+	require.Equal(t, "SYNC_COMMITTEE_CONTRIBUTION", role)
 }
 
 func TestIdentifierFormat(t *testing.T) {
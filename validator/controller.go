@@ -9,12 +9,15 @@ import (
 	"github.com/bloxapp/ssv/network"
 	"github.com/bloxapp/ssv/operator/forks"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
 	"github.com/bloxapp/ssv/utils/tasks"
 	validatorstorage "github.com/bloxapp/ssv/validator/storage"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
+	"strings"
 	"time"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -30,6 +33,7 @@ type ControllerOptions struct {
 	DB                         basedb.IDb
 	Logger                     *zap.Logger
 	SignatureCollectionTimeout time.Duration `yaml:"SignatureCollectionTimeout" env:"SIGNATURE_COLLECTION_TIMEOUT" env-default:"5s" env-description:"Timeout for signature collection after consensus"`
+	PreConsensusSigTimeout     time.Duration `yaml:"PreConsensusSigTimeout" env:"PRE_CONSENSUS_SIG_TIMEOUT" env-default:"4s" env-description:"Timeout for signature collection before consensus (randao reveal, selection proof)"`
 	MetadataUpdateInterval     time.Duration `yaml:"MetadataUpdateInterval" env:"METADATA_UPDATE_INTERVAL" env-default:"12m" env-description:"Interval for updating metadata"`
 	ETHNetwork                 *core.Network
 	Network                    network.Network
@@ -39,6 +43,8 @@ type ControllerOptions struct {
 	CleanRegistryData          bool
 	Fork                       forks.Fork
 	KeyManager                 beacon.KeyManager
+	// DoppelgangerProtectionEpochs is the number of epochs to watch the messages of validators committees before starting them
+	DoppelgangerProtectionEpochs uint64 `yaml:"DoppelgangerProtectionEpochs" env:"DOPPELGANGER_PROTECTION_EPOCHS" env-default:"0" env-description:"Number of epochs to check that validators are not running elsewhere before starting them, 0 disables doppelganger protection"`
 }
 
 // IController represent the validators controller,
@@ -50,6 +56,10 @@ type IController interface {
 	GetValidatorsIndices() []spec.ValidatorIndex
 	GetValidator(pubKey string) (*Validator, bool)
 	UpdateValidatorMetaDataLoop()
+	// ApproveVoluntaryExit signs the voluntary exit of the given validator at the given epoch
+	ApproveVoluntaryExit(pubKey string, epoch spec.Epoch) error
+	// GetVoluntaryExits returns the voluntary exit requests of all validators
+	GetVoluntaryExits() []*VoluntaryExit
 }
 
 // controller implements IController
@@ -66,6 +76,13 @@ type controller struct {
 
 	metadataUpdateQueue    tasks.Queue
 	metadataUpdateInterval time.Duration
+
+	// doppelganger is nil when doppelganger protection is disabled
+	doppelganger *doppelgangerProtection
+
+	eventsHistory *eventsHistory
+
+	ethNetwork *core.Network
 }
 
 // NewController creates a new validator controller instance
@@ -82,10 +99,12 @@ func NewController(options ControllerOptions) IController {
 		beacon:                     options.Beacon,
 		shareEncryptionKeyProvider: options.ShareEncryptionKeyProvider,
 		keyManager:                 options.KeyManager,
+		ethNetwork:                 options.ETHNetwork,
 
 		validatorsMap: newValidatorsMap(options.Context, options.Logger, &Options{
 			Context:                    options.Context,
 			SignatureCollectionTimeout: options.SignatureCollectionTimeout,
+			PreConsensusSigTimeout:     options.PreConsensusSigTimeout,
 			Logger:                     options.Logger,
 			Network:                    options.Network,
 			ETHNetwork:                 options.ETHNetwork,
@@ -97,12 +116,25 @@ func NewController(options ControllerOptions) IController {
 
 		metadataUpdateQueue:    tasks.NewExecutionQueue(10 * time.Millisecond),
 		metadataUpdateInterval: options.MetadataUpdateInterval,
+
+		eventsHistory: newEventsHistory(),
 	}
 
 	if err := ctrl.initShares(options); err != nil {
 		ctrl.logger.Panic("could not initialize shares", zap.Error(err))
 	}
 
+	if options.DoppelgangerProtectionEpochs > 0 {
+		ethNetwork := options.ETHNetwork
+		ctrl.doppelganger = newDoppelgangerProtection(ctrl.logger, options.DoppelgangerProtectionEpochs,
+			func() spec.Epoch {
+				return spec.Epoch(ethNetwork.EstimatedCurrentEpoch())
+			}, ctrl.onDoppelgangerSafe)
+		async.RunEvery(options.Context, ethNetwork.SlotDurationSec(), ctrl.doppelganger.check)
+	}
+
+	options.Network.RegisterMsgValidator(ctrl.validateMsg)
+
 	return &ctrl
 }
 
@@ -125,6 +157,10 @@ func (c *controller) ListenToEth1Events(feed *event.Feed) {
 
 // ProcessEth1Event handles a single event, will be called in both sync and stream events from registry contract
 func (c *controller) ProcessEth1Event(e eth1.Event) error {
+	if e.Log.Removed {
+		return c.rollbackEth1Event(e)
+	}
+	id := eventID(e.Log)
 	if validatorAddedEvent, ok := e.Data.(eth1.ValidatorAddedEvent); ok {
 		pubKey := hex.EncodeToString(validatorAddedEvent.PublicKey)
 		if err := c.handleValidatorAddedEvent(validatorAddedEvent); err != nil {
@@ -132,10 +168,81 @@ func (c *controller) ProcessEth1Event(e eth1.Event) error {
 				zap.String("pubkey", pubKey), zap.Error(err))
 			return err
 		}
+		// the validator didn't exist before it was added, even if it was loaded already
+		if err := c.recordEvent(id, validatorAddedEvent.PublicKey, false, &validatorAddedEvent); err != nil {
+			return err
+		}
+	}
+	if validatorUpdatedEvent, ok := e.Data.(eth1.ValidatorUpdatedEvent); ok {
+		pubKey := hex.EncodeToString(validatorUpdatedEvent.PublicKey)
+		_, existed, err := c.collection.GetValidatorShare(validatorUpdatedEvent.PublicKey)
+		if err != nil {
+			return errors.Wrap(err, "could not check if validator share exits")
+		}
+		if err := c.handleValidatorUpdatedEvent(validatorUpdatedEvent); err != nil {
+			c.logger.Error("could not update validator",
+				zap.String("pubkey", pubKey), zap.Error(err))
+			return err
+		}
+		validatorAddedEvent := eth1.ValidatorAddedEvent(validatorUpdatedEvent)
+		if err := c.recordEvent(id, validatorUpdatedEvent.PublicKey, existed, &validatorAddedEvent); err != nil {
+			return err
+		}
+	}
+	if validatorRemovedEvent, ok := e.Data.(eth1.ValidatorRemovedEvent); ok {
+		pubKey := hex.EncodeToString(validatorRemovedEvent.PublicKey)
+		_, existed, err := c.collection.GetValidatorShare(validatorRemovedEvent.PublicKey)
+		if err != nil {
+			return errors.Wrap(err, "could not check if validator share exits")
+		}
+		if err := c.handleValidatorRemovedEvent(validatorRemovedEvent); err != nil {
+			c.logger.Error("could not remove validator",
+				zap.String("pubkey", pubKey), zap.Error(err))
+			return err
+		}
+		if err := c.recordEvent(id, validatorRemovedEvent.PublicKey, existed, nil); err != nil {
+			return err
+		}
+	}
+	if operatorRemovedEvent, ok := e.Data.(eth1.OperatorRemovedEvent); ok {
+		shares, err := c.collection.GetAllValidatorsShare()
+		if err != nil {
+			return errors.Wrap(err, "could not get validators shares")
+		}
+		if err := c.handleOperatorRemovedEvent(operatorRemovedEvent); err != nil {
+			c.logger.Error("could not remove operator validators",
+				zap.String("operatorPubKey", string(operatorRemovedEvent.PublicKey)), zap.Error(err))
+			return err
+		}
+		for _, share := range shares {
+			if err := c.recordEvent(id, share.PublicKey.Serialize(), true, nil); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
+// recordEvent records the share of the given validator after the event was applied so the event can be reverted,
+// nothing is recorded if the validator doesn't belong to this operator before and after the event
+func (c *controller) recordEvent(id string, validatorPubKey []byte, existed bool, share *eth1.ValidatorAddedEvent) error {
+	_, exists, err := c.collection.GetValidatorShare(validatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not check if validator share exits")
+	}
+	if !existed && !exists {
+		return nil
+	}
+	if !exists {
+		share = nil
+	} else if share == nil {
+		// the validator was not removed by the event
+		return nil
+	}
+	c.eventsHistory.push(hex.EncodeToString(validatorPubKey), id, share, !existed)
+	return nil
+}
+
 // StartValidators loads all persisted shares and setup the corresponding validators
 func (c *controller) StartValidators() {
 	shares, err := c.collection.GetAllValidatorsShare()
@@ -153,28 +260,32 @@ func (c *controller) StartValidators() {
 // shares w/o validator's metadata won't start, but the metadata will be fetched and the validator will start afterwards
 func (c *controller) setupValidators(shares []*validatorstorage.Share) {
 	c.logger.Info("starting validators setup...", zap.Int("shares count", len(shares)))
-	var started int
+	var started, pending int
 	var errs []error
 	var fetchMetadata [][]byte
 	for _, validatorShare := range shares {
 		v := c.validatorsMap.GetOrCreateValidator(validatorShare)
-		pk := v.Share.PublicKey.SerializeToHexStr()
+		share := v.getShare()
+		pk := share.PublicKey.SerializeToHexStr()
 		logger := c.logger.With(zap.String("pubkey", pk))
-		if !v.Share.HasMetadata() { // fetching index and status in case not exist
-			fetchMetadata = append(fetchMetadata, v.Share.PublicKey.Serialize())
+		if !share.HasMetadata() { // fetching index and status in case not exist
+			fetchMetadata = append(fetchMetadata, share.PublicKey.Serialize())
 			logger.Warn("could not start validator as metadata not found")
 			continue
 		}
-		if err := c.startValidator(v); err != nil {
+		if ok, err := c.startValidator(v); err != nil {
 			logger.Warn("could not start validator", zap.Error(err))
 			errs = append(errs, err)
-		} else {
+		} else if ok {
 			started++
+		} else {
+			pending++
 		}
 	}
 	c.logger.Info("setup validators done", zap.Int("map size", c.validatorsMap.Size()),
 		zap.Int("failures", len(errs)), zap.Int("missing metadata", len(fetchMetadata)),
-		zap.Int("shares count", len(shares)), zap.Int("started", started))
+		zap.Int("shares count", len(shares)), zap.Int("started", started),
+		zap.Int("pending doppelganger protection", pending))
 
 	go c.updateValidatorsMetadata(fetchMetadata)
 }
@@ -197,11 +308,11 @@ func (c *controller) UpdateValidatorMetadata(pk string, metadata *beacon.Validat
 		return errors.New("could not update empty metadata")
 	}
 	if v, found := c.validatorsMap.GetValidator(pk); found {
-		v.Share.Metadata = metadata
+		v.getShare().Metadata = metadata
 		if err := c.collection.(beacon.ValidatorMetadataStorage).UpdateValidatorMetadata(pk, metadata); err != nil {
 			return err
 		}
-		if err := c.startValidator(v); err != nil {
+		if _, err := c.startValidator(v); err != nil {
 			c.logger.Error("could not start validator", zap.Error(err))
 		}
 	}
@@ -220,10 +331,11 @@ func (c *controller) GetValidatorsIndices() []spec.ValidatorIndex {
 	var indices []spec.ValidatorIndex
 
 	err := c.validatorsMap.ForEach(func(v *Validator) error {
-		if !v.Share.HasMetadata() {
-			toFetch = append(toFetch, v.Share.PublicKey.Serialize())
-		} else if v.Share.Metadata.IsActive() { // eth-client throws error once trying to fetch duties for existed validator
-			indices = append(indices, v.Share.Metadata.Index)
+		share := v.getShare()
+		if !share.HasMetadata() {
+			toFetch = append(toFetch, share.PublicKey.Serialize())
+		} else if share.Metadata.IsActive() { // eth-client throws error once trying to fetch duties for existed validator
+			indices = append(indices, share.Metadata.Index)
 		}
 		return nil
 	})
@@ -266,13 +378,216 @@ func (c *controller) handleValidatorAddedEvent(validatorAddedEvent eth1.Validato
 	}
 
 	v := c.validatorsMap.GetOrCreateValidator(validatorShare)
-	if err := c.startValidator(v); err != nil {
+	if _, err := c.startValidator(v); err != nil {
 		logger.Warn("could not start validator", zap.Error(err))
 	}
 
 	return nil
 }
 
+// rollbackEth1Event reverts an event that was dropped by a chain reorg after it was applied,
+// the validators that were changed by the event are restored to the share of the previous event in their history
+func (c *controller) rollbackEth1Event(e eth1.Event) error {
+	results := c.eventsHistory.revert(eventID(e.Log))
+	if len(results) == 0 {
+		if validatorAddedEvent, ok := e.Data.(eth1.ValidatorAddedEvent); ok {
+			// the validator was added before the node started
+			results[hex.EncodeToString(validatorAddedEvent.PublicKey)] = &revertResult{changed: true, known: true}
+		} else {
+			c.logger.Debug("rolled back contract event didn't change validators of this operator",
+				zap.String("txHash", e.Log.TxHash.Hex()), zap.Uint64("blockNumber", e.Log.BlockNumber))
+			return nil
+		}
+	}
+	var errs []error
+	for pubKey, res := range results {
+		logger := c.logger.With(zap.String("pubkey", pubKey), zap.String("txHash", e.Log.TxHash.Hex()),
+			zap.Uint64("blockNumber", e.Log.BlockNumber))
+		if !res.changed {
+			logger.Debug("rolled back contract event was followed by another event of the validator")
+			continue
+		}
+		if !res.known {
+			logger.Error("could not roll back contract event as the previous share of the validator is unknown, " +
+				"registry data should be re-synced")
+			errs = append(errs, errors.Errorf("previous share of validator %s is unknown", pubKey))
+			continue
+		}
+		if err := c.restoreShare(pubKey, res.share); err != nil {
+			logger.Error("could not roll back contract event", zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		logger.Info("rolled back contract event")
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("could not roll back contract event for %d out of %d validators", len(errs), len(results))
+	}
+	return nil
+}
+
+// restoreShare sets the share of the given validator from the given event, the validator is removed if the event is nil
+func (c *controller) restoreShare(pubKey string, share *eth1.ValidatorAddedEvent) error {
+	validatorPubKey, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not decode validator public key")
+	}
+	if share == nil {
+		return c.removeValidator(validatorPubKey)
+	}
+	return c.handleValidatorUpdatedEvent(eth1.ValidatorUpdatedEvent(*share))
+}
+
+// handleValidatorUpdatedEvent handles registry contract event for validator updated (reshare):
+// the validator is removed if this operator is no longer part of the committee,
+// added if this operator joined the committee or its share is updated otherwise
+func (c *controller) handleValidatorUpdatedEvent(validatorUpdatedEvent eth1.ValidatorUpdatedEvent) error {
+	pubKey := hex.EncodeToString(validatorUpdatedEvent.PublicKey)
+	logger := c.logger.With(zap.String("pubKey", pubKey))
+
+	operatorPubKey, err := c.operatorPubKey()
+	if err != nil {
+		return err
+	}
+	var isOperatorInCommittee bool
+	for _, oess := range validatorUpdatedEvent.OessList {
+		if len(operatorPubKey) > 0 && strings.EqualFold(string(oess.OperatorPublicKey), operatorPubKey) {
+			isOperatorInCommittee = true
+		}
+	}
+	if !isOperatorInCommittee {
+		logger.Debug("operator is not part of the updated committee")
+		return c.removeValidator(validatorUpdatedEvent.PublicKey)
+	}
+
+	validatorAddedEvent := eth1.ValidatorAddedEvent(validatorUpdatedEvent)
+	oldShare, found, err := c.collection.GetValidatorShare(validatorUpdatedEvent.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "could not check if validator share exits")
+	}
+	if !found {
+		logger.Debug("operator joined the committee of the validator")
+		return c.handleValidatorAddedEvent(validatorAddedEvent)
+	}
+
+	newShare, shareSecret, err := createShareWithOperatorKey(validatorAddedEvent, c.shareEncryptionKeyProvider)
+	if err != nil {
+		return errors.Wrap(err, "failed to create share")
+	}
+	newShare.Metadata = oldShare.Metadata
+	if err := c.keyManager.AddShare(shareSecret); err != nil {
+		return errors.Wrap(err, "failed to save new share secret to key manager")
+	}
+	if v, exist := c.validatorsMap.GetValidator(pubKey); exist {
+		if err := v.UpdateShare(newShare); err != nil {
+			return errors.Wrap(err, "could not update validator share")
+		}
+	}
+	if err := c.collection.SaveValidatorShare(newShare); err != nil {
+		return errors.Wrap(err, "failed to save updated share")
+	}
+
+	oldSharePubKey, err := oldShare.OperatorPubKey()
+	if err != nil {
+		return errors.Wrap(err, "could not get old share public key")
+	}
+	newSharePubKey, err := newShare.OperatorPubKey()
+	if err != nil {
+		return errors.Wrap(err, "could not get new share public key")
+	}
+	if !oldSharePubKey.IsEqual(newSharePubKey) {
+		if err := c.keyManager.RemoveShare(oldSharePubKey.SerializeToHexStr()); err != nil {
+			return errors.Wrap(err, "failed to remove old share secret from key manager")
+		}
+	}
+	logger.Info("validator share was updated")
+	return nil
+}
+
+// handleValidatorRemovedEvent handles registry contract event for validator removed
+func (c *controller) handleValidatorRemovedEvent(validatorRemovedEvent eth1.ValidatorRemovedEvent) error {
+	return c.removeValidator(validatorRemovedEvent.PublicKey)
+}
+
+// handleOperatorRemovedEvent handles registry contract event for operator removed,
+// all the validators of this operator are removed once the operator was removed from the registry
+func (c *controller) handleOperatorRemovedEvent(operatorRemovedEvent eth1.OperatorRemovedEvent) error {
+	operatorPubKey, err := c.operatorPubKey()
+	if err != nil {
+		return err
+	}
+	if len(operatorPubKey) == 0 || !strings.EqualFold(operatorPubKey, string(operatorRemovedEvent.PublicKey)) {
+		return nil
+	}
+	c.logger.Warn("operator was removed from the registry, removing all validators")
+
+	shares, err := c.collection.GetAllValidatorsShare()
+	if err != nil {
+		return errors.Wrap(err, "could not get validators shares")
+	}
+	var errs []error
+	for _, share := range shares {
+		if err := c.removeValidator(share.PublicKey.Serialize()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("could not remove %d out of %d validators", len(errs), len(shares))
+	}
+	return nil
+}
+
+// removeValidator stops the validator (if running) and deletes its share from the key manager and from storage,
+// does nothing if the validator doesn't belong to this operator
+func (c *controller) removeValidator(validatorPubKey []byte) error {
+	pubKey := hex.EncodeToString(validatorPubKey)
+	logger := c.logger.With(zap.String("pubKey", pubKey))
+
+	if v := c.validatorsMap.RemoveValidator(pubKey); v != nil {
+		if err := v.Stop(); err != nil {
+			logger.Warn("could not stop validator", zap.Error(err))
+		}
+	}
+
+	share, found, err := c.collection.GetValidatorShare(validatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not check if validator share exits")
+	}
+	if !found {
+		logger.Debug("validator share not found, ignoring removed validator")
+		return nil
+	}
+	sharePubKey, err := share.OperatorPubKey()
+	if err != nil {
+		return errors.Wrap(err, "could not get share public key")
+	}
+	if err := c.keyManager.RemoveShare(sharePubKey.SerializeToHexStr()); err != nil {
+		return errors.Wrap(err, "failed to remove share secret from key manager")
+	}
+	if err := c.collection.DeleteValidatorShare(validatorPubKey); err != nil {
+		return errors.Wrap(err, "failed to delete share")
+	}
+	metricsValidatorStatus.DeleteLabelValues(pubKey)
+	logger.Info("validator was removed")
+	return nil
+}
+
+// operatorPubKey returns the public key of this operator, an empty string is returned if the operator has no key
+func (c *controller) operatorPubKey() (string, error) {
+	sk, found, err := c.shareEncryptionKeyProvider()
+	if err != nil {
+		return "", errors.Wrap(err, "could not get operator private key")
+	}
+	if !found || sk == nil {
+		return "", nil
+	}
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(sk)
+	if err != nil {
+		return "", errors.Wrap(err, "could not extract operator public key")
+	}
+	return operatorPubKey, nil
+}
+
 // onMetadataUpdated is called when validator's metadata was updated
 func (c *controller) onMetadataUpdated(pk string, meta *beacon.ValidatorMetadata) {
 	if meta == nil {
@@ -281,15 +596,16 @@ func (c *controller) onMetadataUpdated(pk string, meta *beacon.ValidatorMetadata
 	if v, exist := c.GetValidator(pk); exist {
 		// update share object owned by the validator
 		// TODO: check if this updates running validators
-		if !v.Share.HasMetadata() {
-			v.Share.Metadata = meta
+		share := v.getShare()
+		if !share.HasMetadata() {
+			share.Metadata = meta
 			c.logger.Debug("metadata was updated", zap.String("pk", pk))
-		} else if !v.Share.Metadata.Equals(meta) {
-			v.Share.Metadata.Status = meta.Status
-			v.Share.Metadata.Balance = meta.Balance
+		} else if !share.Metadata.Equals(meta) {
+			share.Metadata.Status = meta.Status
+			share.Metadata.Balance = meta.Balance
 			c.logger.Debug("metadata was updated", zap.String("pk", pk))
 		}
-		if err := c.startValidator(v); err != nil {
+		if _, err := c.startValidator(v); err != nil {
 			c.logger.Error("could not start validator after metadata update",
 				zap.String("pk", pk), zap.Error(err), zap.Any("metadata", meta))
 		}
@@ -320,20 +636,84 @@ func (c *controller) onNewShare(share *validatorstorage.Share, shareSecret *bls.
 	return nil
 }
 
-// startValidator will start the given validator if applicable
-func (c *controller) startValidator(v *Validator) error {
-	ReportValidatorStatus(v.Share.PublicKey.SerializeToHexStr(), v.Share.Metadata, c.logger)
-	if !v.Share.HasMetadata() {
-		return errors.New("could not start validator: metadata not found")
+// startValidator will start the given validator if applicable,
+// returns false if the validator is held by doppelganger protection
+func (c *controller) startValidator(v *Validator) (bool, error) {
+	share := v.getShare()
+	pk := share.PublicKey.SerializeToHexStr()
+	ReportValidatorStatus(pk, share.Metadata, c.logger)
+	if !share.HasMetadata() {
+		return false, errors.New("could not start validator: metadata not found")
 	}
-	if v.Share.Metadata.Index == 0 {
-		return errors.New("could not start validator: index not found")
+	if share.Metadata.Index == 0 {
+		return false, errors.New("could not start validator: index not found")
+	}
+	if c.doppelganger != nil && !c.doppelganger.canStart(pk) {
+		if status, _ := c.doppelganger.status(pk); status == doppelgangerDetected {
+			metricsValidatorStatus.WithLabelValues(pk).Set(float64(validatorStatusDoppelganger))
+			return false, nil
+		}
+		metricsValidatorStatus.WithLabelValues(pk).Set(float64(validatorStatusDoppelgangerCheck))
+		// the validator's topic is watched for messages of this operator, see doppelgangerProtection
+		if err := v.network.SubscribeToValidatorNetwork(share.PublicKey); err != nil {
+			return false, errors.Wrap(err, "failed to subscribe topic for doppelganger protection")
+		}
+		return false, nil
 	}
 	if err := v.Start(); err != nil {
-		metricsValidatorStatus.WithLabelValues(v.Share.PublicKey.SerializeToHexStr()).Set(float64(validatorStatusError))
-		return errors.Wrap(err, "could not start validator")
+		metricsValidatorStatus.WithLabelValues(pk).Set(float64(validatorStatusError))
+		return false, errors.Wrap(err, "could not start validator")
 	}
-	return nil
+	return true, nil
+}
+
+// onDoppelgangerSafe is called once a validator passed doppelganger protection
+func (c *controller) onDoppelgangerSafe(pk string) {
+	v, found := c.validatorsMap.GetValidator(pk)
+	if !found {
+		return
+	}
+	if _, err := c.startValidator(v); err != nil {
+		c.logger.Error("could not start validator after doppelganger protection",
+			zap.String("pk", pk), zap.Error(err))
+	}
+}
+
+// HealthCheck returns a list of issues regards the state of the validators
+func (c *controller) HealthCheck() []string {
+	if c.doppelganger == nil {
+		return []string{}
+	}
+	return c.doppelganger.detected()
+}
+
+// ApproveVoluntaryExit signs the voluntary exit of the given validator at the given epoch,
+// the validator must be running in order to collect the signatures of the other operators
+func (c *controller) ApproveVoluntaryExit(pubKey string, epoch spec.Epoch) error {
+	v, found := c.validatorsMap.GetValidator(pubKey)
+	if !found {
+		return errors.New("validator not found")
+	}
+	if !v.isStarted() {
+		return errors.New("validator is not running")
+	}
+	if currentEpoch := spec.Epoch(c.ethNetwork.EstimatedCurrentEpoch()); epoch > currentEpoch {
+		return errors.Errorf("exit epoch %d is in the future, current epoch is %d", epoch, currentEpoch)
+	}
+	return v.ApproveVoluntaryExit(epoch)
+}
+
+// GetVoluntaryExits returns the voluntary exit requests of all validators
+func (c *controller) GetVoluntaryExits() []*VoluntaryExit {
+	var exits []*VoluntaryExit
+	err := c.validatorsMap.ForEach(func(v *Validator) error {
+		exits = append(exits, v.VoluntaryExits()...)
+		return nil
+	})
+	if err != nil {
+		c.logger.Error("failed to get voluntary exits", zap.Error(err))
+	}
+	return exits
 }
 
 // UpdateValidatorMetaDataLoop updates metadata of validators in an interval
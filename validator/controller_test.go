@@ -2,13 +2,26 @@ package validator
 
 import (
 	"context"
+	"crypto/rsa"
+	"fmt"
+	"github.com/bloxapp/ssv/network/local"
+	"github.com/bloxapp/ssv/operator/forks/v0"
 	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	testingspace "github.com/bloxapp/ssv/utils/rsaencryption/testingspace"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"math/big"
 	"sync"
 	"testing"
 
 	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
 	validatorstorage "github.com/bloxapp/ssv/validator/storage"
 )
 
@@ -28,6 +41,7 @@ func setupController(logger *zap.Logger, validators map[string]*Validator) contr
 		},
 		metadataUpdateQueue:    nil,
 		metadataUpdateInterval: 0,
+		eventsHistory:          newEventsHistory(),
 	}
 }
 
@@ -35,7 +49,7 @@ func TestGetIndices(t *testing.T) {
 	logger := logex.Build("test", zap.InfoLevel, nil)
 	validators := map[string]*Validator{
 		"0": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -47,7 +61,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"1": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -59,7 +73,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"2": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -71,7 +85,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"3": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -83,7 +97,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"4": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -95,7 +109,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"5": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -107,7 +121,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"6": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -119,7 +133,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"7": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -131,7 +145,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"8": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -143,7 +157,7 @@ func TestGetIndices(t *testing.T) {
 			},
 		},
 		"9": {
-			Share: &validatorstorage.Share{
+			share: &validatorstorage.Share{
 				NodeID:    0,
 				PublicKey: nil,
 				Committee: nil,
@@ -161,3 +175,248 @@ func TestGetIndices(t *testing.T) {
 	logger.Info("result", zap.Any("indices", indices))
 	require.Equal(t, 1, len(indices)) // should return only active indices
 }
+
+func TestRemoveValidator(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	v := testingValidator(t, true, 4, []byte{1, 2, 3, 4})
+	pubKey := v.share.PublicKey.SerializeToHexStr()
+	ctr := setupController(logger, map[string]*Validator{pubKey: v})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{
+		DB:     db,
+		Logger: logger,
+	})
+	ctr.keyManager = newTestBeacon(t)
+	require.NoError(t, ctr.collection.SaveValidatorShare(v.share))
+
+	t.Run("unknown validator", func(t *testing.T) {
+		require.NoError(t, ctr.ProcessEth1Event(eth1.Event{
+			Data: eth1.ValidatorRemovedEvent{PublicKey: refSplitSharesPubKeys[0]},
+		}))
+		require.Equal(t, 1, ctr.validatorsMap.Size())
+	})
+
+	t.Run("remove validator", func(t *testing.T) {
+		require.NoError(t, ctr.ProcessEth1Event(eth1.Event{
+			Data: eth1.ValidatorRemovedEvent{PublicKey: v.share.PublicKey.Serialize()},
+		}))
+		_, found := ctr.GetValidator(pubKey)
+		require.False(t, found)
+		_, found, err := ctr.collection.GetValidatorShare(v.share.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.False(t, found)
+		select {
+		case <-v.stopCh:
+		default:
+			require.Fail(t, "validator was not stopped")
+		}
+	})
+}
+
+func TestValidatorUpdatedEvent(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	v := testingValidator(t, true, 4, []byte{1, 2, 3, 4})
+	pubKey := v.share.PublicKey.SerializeToHexStr()
+	ctr := setupController(logger, map[string]*Validator{pubKey: v})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{
+		DB:     db,
+		Logger: logger,
+	})
+	ctr.keyManager = newTestBeacon(t)
+	sk, err := rsaencryption.ConvertPemToPrivateKey(testingspace.SkPem)
+	require.NoError(t, err)
+	ctr.shareEncryptionKeyProvider = func() (*rsa.PrivateKey, bool, error) {
+		return sk, true, nil
+	}
+	require.NoError(t, ctr.collection.SaveValidatorShare(v.share))
+
+	t.Run("operator left the committee", func(t *testing.T) {
+		require.NoError(t, ctr.ProcessEth1Event(eth1.Event{
+			Data: eth1.ValidatorUpdatedEvent{
+				PublicKey: v.share.PublicKey.Serialize(),
+				OessList:  []eth1.Oess{{Index: big.NewInt(0), OperatorPublicKey: []byte("other operator")}},
+			},
+		}))
+		_, found := ctr.GetValidator(pubKey)
+		require.False(t, found)
+		_, found, err := ctr.collection.GetValidatorShare(v.share.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+}
+
+func TestRollbackEth1Event(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	v := testingValidator(t, true, 4, []byte{1, 2, 3, 4})
+	pubKey := v.share.PublicKey.SerializeToHexStr()
+	ctr := setupController(logger, map[string]*Validator{pubKey: v})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{
+		DB:     db,
+		Logger: logger,
+	})
+	ctr.keyManager = newTestBeacon(t)
+	require.NoError(t, ctr.collection.SaveValidatorShare(v.share))
+
+	t.Run("not reverted event", func(t *testing.T) {
+		e := eth1.Event{Data: eth1.ValidatorRemovedEvent{PublicKey: v.share.PublicKey.Serialize()}}
+		e.Log.Removed = true
+		require.NoError(t, ctr.ProcessEth1Event(e))
+		_, found := ctr.GetValidator(pubKey)
+		require.True(t, found)
+	})
+
+	t.Run("added validator", func(t *testing.T) {
+		e := eth1.Event{Data: eth1.ValidatorAddedEvent{PublicKey: v.share.PublicKey.Serialize()}}
+		e.Log.Removed = true
+		require.NoError(t, ctr.ProcessEth1Event(e))
+		_, found := ctr.GetValidator(pubKey)
+		require.False(t, found)
+		_, found, err := ctr.collection.GetValidatorShare(v.share.PublicKey.Serialize())
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+}
+
+func TestRollbackEth1EventHistory(t *testing.T) {
+	logger := logex.Build("test", zap.InfoLevel, nil)
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logger,
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctr := setupController(logger, map[string]*Validator{})
+	ctr.collection = validatorstorage.NewCollection(validatorstorage.CollectionOptions{
+		DB:     db,
+		Logger: logger,
+	})
+	b := newTestBeacon(t)
+	ctr.beacon = b
+	ctr.keyManager = b
+	ctr.validatorsMap.optsTemplate = &Options{
+		Context: context.Background(),
+		Logger:  logger,
+		Network: local.NewLocalNetwork(),
+		Beacon:  b,
+		DB:      db,
+		Fork:    v0.New(),
+		Signer:  b,
+	}
+	sk, err := rsaencryption.ConvertPemToPrivateKey(testingspace.SkPem)
+	require.NoError(t, err)
+	ctr.shareEncryptionKeyProvider = func() (*rsa.PrivateKey, bool, error) {
+		return sk, true, nil
+	}
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(sk)
+	require.NoError(t, err)
+
+	// oessList returns the committee of the test validator in which this operator has the given node id
+	oessList := func(nodeID uint64) []eth1.Oess {
+		var oess []eth1.Oess
+		for i := range refSplitShares {
+			o := eth1.Oess{
+				Index:             big.NewInt(int64(i)),
+				OperatorPublicKey: []byte(fmt.Sprintf("operator %d", i)),
+				SharedPublicKey:   refSplitSharesPubKeys[i],
+			}
+			if uint64(i+1) == nodeID {
+				shareKey := &bls.SecretKey{}
+				require.NoError(t, shareKey.Deserialize(refSplitShares[i]))
+				o.OperatorPublicKey = []byte(operatorPubKey)
+				o.EncryptedKey = []byte(shareKey.SerializeToHexStr())
+			}
+			oess = append(oess, o)
+		}
+		return oess
+	}
+	event := func(tx string, data interface{}) eth1.Event {
+		return eth1.Event{Log: types.Log{TxHash: common.HexToHash(tx)}, Data: data}
+	}
+	reorged := func(e eth1.Event) eth1.Event {
+		e.Log.Removed = true
+		return e
+	}
+	requireNodeID := func(t *testing.T, nodeID uint64) {
+		share, found, err := ctr.collection.GetValidatorShare(refPk)
+		require.NoError(t, err)
+		if nodeID == 0 {
+			require.False(t, found)
+			require.Equal(t, 0, ctr.validatorsMap.Size())
+			return
+		}
+		require.True(t, found)
+		require.Equal(t, nodeID, share.NodeID)
+		v, found := ctr.GetValidator(share.PublicKey.SerializeToHexStr())
+		require.True(t, found)
+		require.Equal(t, nodeID, v.share.NodeID)
+	}
+
+	added := event("0x1", eth1.ValidatorAddedEvent{PublicKey: refPk, OessList: oessList(1)})
+	updated := event("0x2", eth1.ValidatorUpdatedEvent{PublicKey: refPk, OessList: oessList(2)})
+	removed := event("0x3", eth1.ValidatorRemovedEvent{PublicKey: refPk})
+	operatorRemoved := event("0x4", eth1.OperatorRemovedEvent{PublicKey: []byte(operatorPubKey)})
+
+	t.Run("removed and updated validator", func(t *testing.T) {
+		require.NoError(t, ctr.ProcessEth1Event(added))
+		require.NoError(t, ctr.ProcessEth1Event(updated))
+		require.NoError(t, ctr.ProcessEth1Event(removed))
+		requireNodeID(t, 0)
+
+		require.NoError(t, ctr.ProcessEth1Event(reorged(removed)))
+		requireNodeID(t, 2)
+		require.NoError(t, ctr.ProcessEth1Event(reorged(updated)))
+		requireNodeID(t, 1)
+		require.NoError(t, ctr.ProcessEth1Event(reorged(added)))
+		requireNodeID(t, 0)
+	})
+
+	t.Run("removed operator", func(t *testing.T) {
+		require.NoError(t, ctr.ProcessEth1Event(added))
+		require.NoError(t, ctr.ProcessEth1Event(operatorRemoved))
+		requireNodeID(t, 0)
+
+		require.NoError(t, ctr.ProcessEth1Event(reorged(operatorRemoved)))
+		requireNodeID(t, 1)
+	})
+
+	t.Run("event followed by another event", func(t *testing.T) {
+		require.NoError(t, ctr.ProcessEth1Event(updated))
+		require.NoError(t, ctr.ProcessEth1Event(removed))
+		require.NoError(t, ctr.ProcessEth1Event(reorged(updated)))
+		requireNodeID(t, 0)
+		require.NoError(t, ctr.ProcessEth1Event(reorged(removed)))
+		requireNodeID(t, 1)
+	})
+
+	t.Run("previous share is unknown", func(t *testing.T) {
+		ctr.eventsHistory = newEventsHistory()
+		require.NoError(t, ctr.ProcessEth1Event(removed))
+		requireNodeID(t, 0)
+		require.Error(t, ctr.ProcessEth1Event(reorged(removed)))
+	})
+}
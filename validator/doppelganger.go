@@ -0,0 +1,165 @@
+package validator
+
+import (
+	"fmt"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/network"
+	"go.uber.org/zap"
+	"sort"
+	"sync"
+)
+
+// doppelgangerStatus is the status of a validator in doppelganger protection
+type doppelgangerStatus int32
+
+const (
+	// doppelgangerChecking means that the messages of the validator's committee are watched
+	doppelgangerChecking doppelgangerStatus = iota
+	// doppelgangerSafe means that no messages of this operator were seen and the validator can be started
+	doppelgangerSafe
+	// doppelgangerDetected means that messages of this operator were seen, probably running elsewhere
+	doppelgangerDetected
+)
+
+// doppelgangerState holds the state of a single validator in doppelganger protection
+type doppelgangerState struct {
+	status     doppelgangerStatus
+	startEpoch spec.Epoch
+	// detectedEpoch is the epoch that messages of this operator were seen in
+	detectedEpoch spec.Epoch
+}
+
+// doppelgangerProtection delays the start of validators for a number of epochs while watching the messages of their committees,
+// iBFT messages that are signed by this operator in that time were sent by another instance that runs with the same share,
+// therefore the validator won't be started.
+// the liveness of the validator can't be used as the rest of the committee keeps it live without this operator.
+// messages of the epoch in which a validator was registered are not checked, as they might have been sent by this node before restart
+type doppelgangerProtection struct {
+	logger       *zap.Logger
+	epochs       uint64
+	currentEpoch func() spec.Epoch
+	// onSafe is called once a validator is safe to start
+	onSafe func(pubKey string)
+
+	lock       sync.Mutex
+	validators map[string]*doppelgangerState
+}
+
+// newDoppelgangerProtection creates a new instance of doppelgangerProtection
+func newDoppelgangerProtection(logger *zap.Logger, epochs uint64, currentEpoch func() spec.Epoch,
+	onSafe func(pubKey string)) *doppelgangerProtection {
+	return &doppelgangerProtection{
+		logger:       logger.With(zap.String("who", "doppelgangerProtection")),
+		epochs:       epochs,
+		currentEpoch: currentEpoch,
+		onSafe:       onSafe,
+		validators:   make(map[string]*doppelgangerState),
+	}
+}
+
+// canStart returns whether the given validator is safe to start,
+// validators that were not seen yet are registered for watching
+func (dp *doppelgangerProtection) canStart(pubKey string) bool {
+	dp.lock.Lock()
+	defer dp.lock.Unlock()
+
+	state, ok := dp.validators[pubKey]
+	if !ok {
+		epoch := dp.currentEpoch()
+		dp.validators[pubKey] = &doppelgangerState{
+			status:     doppelgangerChecking,
+			startEpoch: epoch,
+		}
+		dp.logger.Info("validator will start after doppelganger protection",
+			zap.String("pubKey", pubKey), zap.Uint64("until_epoch", uint64(epoch)+dp.epochs))
+		return false
+	}
+	return state.status == doppelgangerSafe
+}
+
+// status returns the doppelganger status of the given validator
+func (dp *doppelgangerProtection) status(pubKey string) (doppelgangerStatus, bool) {
+	dp.lock.Lock()
+	defer dp.lock.Unlock()
+
+	state, ok := dp.validators[pubKey]
+	if !ok {
+		return doppelgangerChecking, false
+	}
+	return state.status, true
+}
+
+// onMessage is called with valid messages of the validator's topic,
+// an iBFT message that is signed by this operator while the validator is watched means that it runs elsewhere.
+// partial signatures are not considered as they can't be verified without the signed beacon root
+func (dp *doppelgangerProtection) onMessage(pubKey string, operatorID uint64, msg *network.Message) {
+	if msg.Type != network.NetworkMsg_IBFTType || !hasSigner(msg.SignedMessage.SignerIds, operatorID) {
+		return
+	}
+	epoch := dp.currentEpoch()
+
+	dp.lock.Lock()
+	defer dp.lock.Unlock()
+
+	state, ok := dp.validators[pubKey]
+	if !ok || state.status != doppelgangerChecking || epoch <= state.startEpoch {
+		return
+	}
+	state.status = doppelgangerDetected
+	state.detectedEpoch = epoch
+	dp.logger.Error("doppelganger detected, messages of this operator were seen and the validator won't be started. "+
+		"make sure it is not running elsewhere and restart the node",
+		zap.String("pubKey", pubKey), zap.Uint64("operator_id", operatorID), zap.Uint64("epoch", uint64(epoch)))
+}
+
+// check starts the validators that completed the detection window without being detected
+func (dp *doppelgangerProtection) check() {
+	current := dp.currentEpoch()
+
+	var safe []string
+	dp.lock.Lock()
+	for pk, state := range dp.validators {
+		if state.status != doppelgangerChecking {
+			continue
+		}
+		// the current epoch is not complete yet
+		if uint64(current) > uint64(state.startEpoch)+dp.epochs {
+			state.status = doppelgangerSafe
+			safe = append(safe, pk)
+		}
+	}
+	dp.lock.Unlock()
+
+	for _, pk := range safe {
+		dp.logger.Info("doppelganger protection passed, starting validator", zap.String("pubKey", pk))
+		if dp.onSafe != nil {
+			dp.onSafe(pk)
+		}
+	}
+}
+
+// detected returns the issues of validators that were detected as doppelgangers
+func (dp *doppelgangerProtection) detected() []string {
+	dp.lock.Lock()
+	defer dp.lock.Unlock()
+
+	var issues []string
+	for pk, state := range dp.validators {
+		if state.status == doppelgangerDetected {
+			issues = append(issues, fmt.Sprintf("doppelganger detected for validator %s in epoch %d",
+				pk, state.detectedEpoch))
+		}
+	}
+	sort.Strings(issues)
+	return issues
+}
+
+// hasSigner returns whether the given signer is one of the signers
+func hasSigner(signerIds []uint64, signer uint64) bool {
+	for _, id := range signerIds {
+		if id == signer {
+			return true
+		}
+	}
+	return false
+}
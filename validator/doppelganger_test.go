@@ -0,0 +1,101 @@
+package validator
+
+import (
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func doppelgangerIBFTMsg(t *testing.T, identifier []byte, signer uint64, signWith uint64) *network.Message {
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.Deserialize(refSplitShares[signWith-1]))
+	msg := &proto.Message{
+		Type:      proto.RoundState_Prepare,
+		Lambda:    identifier,
+		SeqNumber: 0,
+		Value:     []byte("value"),
+	}
+	sig, err := msg.Sign(sk)
+	require.NoError(t, err)
+	return &network.Message{
+		SignedMessage: &proto.SignedMessage{Message: msg, Signature: sig.Serialize(), SignerIds: []uint64{signer}},
+		Type:          network.NetworkMsg_IBFTType,
+	}
+}
+
+func TestDoppelgangerProtection(t *testing.T) {
+	identifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeAttester.String()))
+	pk := pubKeyFromIdentifier(identifier)
+
+	setup := func(t *testing.T) (*controller, *spec.Epoch, *[]string) {
+		v := testingValidator(t, true, 4, identifier)
+		ctrl := setupController(zap.L(), map[string]*Validator{v.share.PublicKey.SerializeToHexStr(): v})
+		epoch := spec.Epoch(10)
+		var safe []string
+		ctrl.doppelganger = newDoppelgangerProtection(zap.L(), 2, func() spec.Epoch {
+			return epoch
+		}, func(pubKey string) {
+			safe = append(safe, pubKey)
+		})
+		require.False(t, ctrl.doppelganger.canStart(pk))
+		return &ctrl, &epoch, &safe
+	}
+	t.Run("committee keeps the validator live", func(t *testing.T) {
+		ctrl, epoch, safe := setup(t)
+
+		for e := spec.Epoch(11); e <= 12; e++ {
+			*epoch = e
+			for signer := uint64(2); signer <= 4; signer++ {
+				require.Equal(t, network.MsgValidationAccept, ctrl.validateMsg(doppelgangerIBFTMsg(t, identifier, signer, signer)))
+			}
+			ctrl.doppelganger.check()
+			require.Len(t, *safe, 0)
+		}
+
+		*epoch = 13
+		ctrl.doppelganger.check()
+		require.Equal(t, []string{pk}, *safe)
+		require.True(t, ctrl.doppelganger.canStart(pk))
+		require.Len(t, ctrl.HealthCheck(), 0)
+	})
+
+	t.Run("messages of this operator in the start epoch are ignored", func(t *testing.T) {
+		ctrl, epoch, safe := setup(t)
+
+		require.Equal(t, network.MsgValidationAccept, ctrl.validateMsg(doppelgangerIBFTMsg(t, identifier, 1, 1)))
+		*epoch = 13
+		ctrl.doppelganger.check()
+		require.Equal(t, []string{pk}, *safe)
+	})
+
+	t.Run("forged messages of this operator are ignored", func(t *testing.T) {
+		ctrl, epoch, _ := setup(t)
+
+		*epoch = 11
+		require.Equal(t, network.MsgValidationReject, ctrl.validateMsg(doppelgangerIBFTMsg(t, identifier, 1, 2)))
+		status, _ := ctrl.doppelganger.status(pk)
+		require.Equal(t, doppelgangerChecking, status)
+	})
+
+	t.Run("messages of this operator are detected", func(t *testing.T) {
+		ctrl, epoch, safe := setup(t)
+
+		*epoch = 11
+		require.Equal(t, network.MsgValidationAccept, ctrl.validateMsg(doppelgangerIBFTMsg(t, identifier, 1, 1)))
+		status, found := ctrl.doppelganger.status(pk)
+		require.True(t, found)
+		require.Equal(t, doppelgangerDetected, status)
+
+		*epoch = 13
+		ctrl.doppelganger.check()
+		require.Len(t, *safe, 0)
+		require.False(t, ctrl.doppelganger.canStart(pk))
+		require.Equal(t, []string{"doppelganger detected for validator " + pk + " in epoch 11"}, ctrl.HealthCheck())
+	})
+}
@@ -3,9 +3,13 @@ package validator
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	ibftvalcheck "github.com/bloxapp/ssv/ibft/valcheck"
 	"github.com/bloxapp/ssv/network/msgqueue"
 	"github.com/pkg/errors"
+	"sync"
 	"time"
 
 	"github.com/bloxapp/ssv/beacon"
@@ -14,23 +18,32 @@ import (
 	"go.uber.org/zap"
 )
 
+// errNotAggregator is returned when the validator was not selected to aggregate
+var errNotAggregator = errors.New("validator is not an aggregator")
+
 // waitForSignatureCollection waits for inbound signatures, collects them or times out if not.
 func (v *Validator) waitForSignatureCollection(logger *zap.Logger, identifier []byte, seqNumber uint64, sigRoot []byte, signaturesCount int, committiee map[uint64]*proto.Node) (map[uint64][]byte, error) {
+	return v.collectSignatures(logger, "post consensus", msgqueue.SigRoundIndexKey(identifier, seqNumber), sigRoot, signaturesCount, committiee, v.signatureCollectionTimeout)
+}
+
+// collectSignatures pops the signatures of the given index from the queue and verifies them,
+// returns once enough signatures were collected or times out if not.
+func (v *Validator) collectSignatures(logger *zap.Logger, phase string, indexKey string, sigRoot []byte, signaturesCount int, committiee map[uint64]*proto.Node, timeout time.Duration) (map[uint64][]byte, error) {
 	// Collect signatures from other nodes
 	// TODO - change signature count to min threshold
 	signatures := make(map[uint64][]byte, signaturesCount)
 	signedIndxes := make([]uint64, 0)
 	var err error
-	timer := time.NewTimer(v.signatureCollectionTimeout)
+	timer := time.NewTimer(timeout)
 	// loop through messages until timeout
 SigCollectionLoop:
 	for {
 		select {
 		case <-timer.C:
-			err = errors.Errorf("timed out waiting for post consensus signatures, received %d", len(signedIndxes))
+			err = errors.Errorf("timed out waiting for %s signatures, received %d", phase, len(signedIndxes))
 			break SigCollectionLoop
 		default:
-			if msg := v.msgQueue.PopMessage(msgqueue.SigRoundIndexKey(identifier, seqNumber)); msg != nil {
+			if msg := v.msgQueue.PopMessage(indexKey); msg != nil {
 				if len(msg.SignedMessage.SignerIds) == 0 { // no KeyManager, empty sig
 					v.logger.Error("missing KeyManager id", zap.Any("msg", msg.SignedMessage))
 					continue SigCollectionLoop
@@ -84,23 +97,27 @@ func (v *Validator) postConsensusDutyExecution(
 	}
 
 	identifier := v.ibfts[duty.Type].GetIdentifier()
+	share := v.getShare()
+	indexKey := msgqueue.SigRoundIndexKey(identifier, seqNumber)
+	v.setPostConsensusRoot(indexKey, root)
+	defer v.setPostConsensusRoot(indexKey, nil)
 	// TODO - should we construct it better?
-	if err := v.network.BroadcastSignature(v.Share.PublicKey.Serialize(), &proto.SignedMessage{
+	if err := v.network.BroadcastSignature(share.PublicKey.Serialize(), &proto.SignedMessage{
 		Message: &proto.Message{
 			Lambda:    identifier,
 			SeqNumber: seqNumber,
 		},
 		Signature: sig,
-		SignerIds: []uint64{v.Share.NodeID},
+		SignerIds: []uint64{share.NodeID},
 	}); err != nil {
 		return errors.Wrap(err, "failed to broadcast signature")
 	}
 	logger.Info("broadcasting partial signature post consensus")
 
-	signatures, err := v.waitForSignatureCollection(logger, identifier, seqNumber, root, signaturesCount, v.Share.Committee)
+	signatures, err := v.waitForSignatureCollection(logger, identifier, seqNumber, root, signaturesCount, share.Committee)
 
 	// clean queue for messages, we don't need them anymore.
-	v.msgQueue.PurgeIndexedMessages(msgqueue.SigRoundIndexKey(identifier, seqNumber))
+	v.msgQueue.PurgeIndexedMessages(indexKey)
 
 	if err != nil {
 		return err
@@ -115,9 +132,155 @@ func (v *Validator) postConsensusDutyExecution(
 	return nil
 }
 
+// setPostConsensusRoot sets the signing root of the post consensus signature round of the given index key, nil removes it
+func (v *Validator) setPostConsensusRoot(indexKey string, root []byte) {
+	v.postConsensusRootsLock.Lock()
+	defer v.postConsensusRootsLock.Unlock()
+
+	if root == nil {
+		delete(v.postConsensusRoots, indexKey)
+		return
+	}
+	if v.postConsensusRoots == nil {
+		v.postConsensusRoots = make(map[string][]byte)
+	}
+	v.postConsensusRoots[indexKey] = root
+}
+
+// postConsensusRoot returns the signing root of the post consensus signature round of the given index key,
+// returns nil if this operator didn't sign the decided value of the round
+func (v *Validator) postConsensusRoot(indexKey string) []byte {
+	v.postConsensusRootsLock.RLock()
+	defer v.postConsensusRootsLock.RUnlock()
+
+	return v.postConsensusRoots[indexKey]
+}
+
+// setDecidedAttestation records the attestation data that was decided by the committee
+func (v *Validator) setDecidedAttestation(data *spec.AttestationData) {
+	v.decidedAttestationLock.Lock()
+	defer v.decidedAttestationLock.Unlock()
+
+	v.decidedAttestation = data
+}
+
+// decidedAttestationRoot returns the root of the attestation data that was decided for the given slot and committee,
+// nil is returned if the attestation was not decided (e.g. the node was restarted since)
+func (v *Validator) decidedAttestationRoot(slot spec.Slot, committeeIndex spec.CommitteeIndex) (*spec.Root, error) {
+	v.decidedAttestationLock.Lock()
+	data := v.decidedAttestation
+	v.decidedAttestationLock.Unlock()
+
+	if data == nil || data.Slot != slot || data.Index != committeeIndex {
+		return nil, nil
+	}
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get decided attestation data root")
+	}
+	ret := spec.Root(root)
+	return &ret, nil
+}
+
+// waitToSlotTwoThirds waits until two-third of the slot has transpired or the validator was stopped
+func (v *Validator) waitToSlotTwoThirds(slot spec.Slot) {
+	twoThirds := v.getSlotStartTime(uint64(slot)).Add(v.ethNetwork.SlotDurationSec() * 2 / 3)
+	timer := time.NewTimer(time.Until(twoThirds))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-v.stopCh:
+	}
+}
+
+// syncCommitteeContributionsAndProofs runs the selection of all the subcommittees of the validator in parallel and builds
+// the contributions of the subcommittees it was selected to aggregate, a failed subcommittee doesn't affect the others.
+// returns errNotAggregator if it was not selected in any of its subcommittees
+func (v *Validator) syncCommitteeContributionsAndProofs(logger *zap.Logger, duty *beacon.Duty) ([]*altair.ContributionAndProof, error) {
+	subcommitteeIndices := beacon.SyncSubcommitteeIndices(duty.ValidatorSyncCommitteeIndices)
+	selectionProofs := make([]*spec.BLSSignature, len(subcommitteeIndices))
+	var wg sync.WaitGroup
+	for i, subcommitteeIndex := range subcommitteeIndices {
+		wg.Add(1)
+		go func(i int, subcommitteeIndex uint64) {
+			defer wg.Done()
+			selectionProof, err := v.syncCommitteeSelectionProof(logger, duty, subcommitteeIndex)
+			if err != nil {
+				logger.Error("failed to get sync committee selection proof", zap.Uint64("subcommittee_index", subcommitteeIndex), zap.Error(err))
+				return
+			}
+			if beacon.IsSyncCommitteeAggregator(selectionProof[:]) {
+				selectionProofs[i] = &selectionProof
+			}
+		}(i, subcommitteeIndex)
+	}
+	wg.Wait()
+
+	var blockRoot *spec.Root
+	var contributionsAndProofs []*altair.ContributionAndProof
+	for i, subcommitteeIndex := range subcommitteeIndices {
+		if selectionProofs[i] == nil {
+			continue
+		}
+		if blockRoot == nil {
+			root, err := v.beacon.GetSyncCommitteeBlockRoot(duty.Slot)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to get sync committee block root")
+			}
+			blockRoot = root
+		}
+		contribution, err := v.beacon.GetSyncCommitteeContribution(duty.Slot, subcommitteeIndex, *blockRoot)
+		if err != nil {
+			logger.Error("failed to get sync committee contribution", zap.Uint64("subcommittee_index", subcommitteeIndex), zap.Error(err))
+			continue
+		}
+		contributionsAndProofs = append(contributionsAndProofs, &altair.ContributionAndProof{
+			AggregatorIndex: duty.ValidatorIndex,
+			Contribution:    contribution,
+			SelectionProof:  *selectionProofs[i],
+		})
+	}
+	if len(contributionsAndProofs) == 0 {
+		return nil, errNotAggregator
+	}
+	return contributionsAndProofs, nil
+}
+
+// executeSyncCommitteeContributions comes to consensus on the contribution of every subcommittee the validator
+// was selected to aggregate and submits it, the contributions are decided one after the other by the same iBFT
+func (v *Validator) executeSyncCommitteeContributions(ctx context.Context, logger *zap.Logger, duty *beacon.Duty) {
+	contributionsAndProofs, err := v.syncCommitteeContributionsAndProofs(logger, duty)
+	if err == errNotAggregator {
+		logger.Debug("validator was not selected as sync committee aggregator")
+		return
+	}
+	if err != nil {
+		logger.Error("could not get sync committee contributions", zap.Error(err))
+		return
+	}
+
+	for _, contributionAndProof := range contributionsAndProofs {
+		logger := logger.With(zap.Uint64("subcommittee_index", contributionAndProof.Contribution.SubcommitteeIndex))
+		inputByts, err := contributionAndProof.MarshalSSZ()
+		if err != nil {
+			logger.Error("failed to marshal contribution and proof", zap.Error(err))
+			continue
+		}
+		signaturesCount, decidedValue, seqNumber, err := v.decideInputValue(logger, duty, inputByts, v.valueCheck.SyncCommitteeContributionValidation())
+		if err != nil {
+			logger.Error("could not come to consensus", zap.Error(err))
+			continue
+		}
+		logger.Info("GOT CONSENSUS", zap.Any("inputValueHex", hex.EncodeToString(decidedValue)))
+
+		if err := v.postConsensusDutyExecution(ctx, logger, seqNumber, decidedValue, signaturesCount, duty); err != nil {
+			logger.Error("could not execute duty", zap.Error(err))
+		}
+	}
+}
+
 func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beacon.Duty) (int, []byte, uint64, error) {
 	var inputByts []byte
-	var err error
 	var valCheckInstance ibftvalcheck.ValueCheck
 
 	if _, ok := v.ibfts[duty.Type]; !ok {
@@ -135,39 +298,77 @@ func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beacon
 		if err != nil {
 			return 0, nil, 0, errors.Errorf("failed to marshal on attestation role: %s", duty.Type.String())
 		}
-		valCheckInstance = v.valueCheck.AttestationSlashingProtector()
-	//case beacon.RoleTypeAggregator:
-	//	aggData, err := v.beacon.GetAggregationData(ctx, duty, v.Share.PublicKey, v.Share.ShareKey)
-	//	if err != nil {
-	//		return 0, nil, 0, errors.Wrap(err, "failed to get aggregation data")
-	//	}
-	//
-	//	d := &proto.InputValue_AggregationData{
-	//		AggregationData: aggData,
-	//	}
-	//	inputByts, err = json.Marshal(d)
-	//	if err != nil {
-	//		return 0, nil, 0, errors.Errorf("failed to marshal on aggregation role: %s", role.String())
-	//	}
-	//	valueCheck = &valcheck.AggregatorValueCheck{}
-	//case beacon.RoleTypeProposer:
-	//	block, err := v.beacon.GetProposalData(ctx, slot, v.Share.ShareKey)
-	//	if err != nil {
-	//		return 0, nil, 0, errors.Wrap(err, "failed to get proposal block")
-	//	}
-	//
-	//	d := &proto.InputValue_BeaconBlock{
-	//		BeaconBlock: block,
-	//	}
-	//	inputByts, err = json.Marshal(d)
-	//	if err != nil {
-	//		return 0, nil, 0, errors.Errorf("failed to marshal on proposer role: %s", role.String())
-	//	}
-	//	valueCheck = &valcheck.ProposerValueCheck{}
+		pk, err := v.getShare().OperatorPubKey()
+		if err != nil {
+			return 0, nil, 0, errors.Wrap(err, "could not find operator pk for attestation slashing protection")
+		}
+		valCheckInstance = v.valueCheck.AttestationSlashingProtector(pk.Serialize())
+	case beacon.RoleTypeAggregator:
+		selectionProof, err := v.selectionProof(logger, duty)
+		if err != nil {
+			return 0, nil, 0, errors.Wrap(err, "failed to get selection proof")
+		}
+		if !beacon.IsAggregator(duty.CommitteeLength, selectionProof[:]) {
+			return 0, nil, 0, errNotAggregator
+		}
+
+		// the aggregate is fetched once the attestation of the slot was decided
+		v.waitToSlotTwoThirds(duty.Slot)
+		attestationDataRoot, err := v.decidedAttestationRoot(duty.Slot, duty.CommitteeIndex)
+		if err != nil {
+			return 0, nil, 0, err
+		}
+		if attestationDataRoot == nil {
+			logger.Warn("attestation was not decided, aggregating the attestation data of the beacon node")
+		}
+		aggregate, err := v.beacon.GetAggregateAttestation(duty.Slot, duty.CommitteeIndex, attestationDataRoot)
+		if err != nil {
+			return 0, nil, 0, errors.Wrap(err, "failed to get aggregate attestation")
+		}
+
+		aggregateAndProof := &spec.AggregateAndProof{
+			AggregatorIndex: duty.ValidatorIndex,
+			Aggregate:       aggregate,
+			SelectionProof:  selectionProof,
+		}
+		inputByts, err = aggregateAndProof.MarshalSSZ()
+		if err != nil {
+			return 0, nil, 0, errors.Errorf("failed to marshal on aggregator role: %s", duty.Type.String())
+		}
+		valCheckInstance = v.valueCheck.AggregationValidation()
+	case beacon.RoleTypeProposer:
+		randaoReveal, err := v.randaoReveal(logger, duty)
+		if err != nil {
+			return 0, nil, 0, errors.Wrap(err, "failed to get randao reveal")
+		}
+
+		block, err := v.beacon.GetBeaconBlock(duty.Slot, randaoReveal)
+		if err != nil {
+			return 0, nil, 0, errors.Wrap(err, "failed to get beacon block")
+		}
+
+		inputByts, err = json.Marshal(block)
+		if err != nil {
+			return 0, nil, 0, errors.Errorf("failed to marshal on proposer role: %s", duty.Type.String())
+		}
+		valCheckInstance = v.valueCheck.ProposalSlashingProtector()
+	case beacon.RoleTypeSyncCommittee:
+		blockRoot, err := v.beacon.GetSyncCommitteeBlockRoot(duty.Slot)
+		if err != nil {
+			return 0, nil, 0, errors.Wrap(err, "failed to get sync committee block root")
+		}
+
+		inputByts = blockRoot[:]
+		valCheckInstance = v.valueCheck.SyncCommitteeValidation()
 	default:
 		return 0, nil, 0, errors.Errorf("unknown role: %s", duty.Type.String())
 	}
 
+	return v.decideInputValue(logger, duty, inputByts, valCheckInstance)
+}
+
+// decideInputValue starts an iBFT instance of the duty's role with the given input value and waits for it to decide
+func (v *Validator) decideInputValue(logger *zap.Logger, duty *beacon.Duty, inputByts []byte, valCheckInstance ibftvalcheck.ValueCheck) (int, []byte, uint64, error) {
 	// do a value check before instance starts to prevent a dead lock if all SSV instances start
 	// an iBFT instance with values which are invalid which will result in them getting "stuck"
 	// in infinite round changes
@@ -182,7 +383,7 @@ func (v *Validator) comeToConsensusOnInputValue(logger *zap.Logger, duty *beacon
 	}
 
 	result, err := v.ibfts[duty.Type].StartInstance(ibft.ControllerStartInstanceOptions{
-		ValidatorShare:  v.Share,
+		ValidatorShare:  v.getShare(),
 		Logger:          logger,
 		ValueCheck:      valCheckInstance,
 		SeqNumber:       seqNumber,
@@ -209,10 +410,27 @@ func (v *Validator) ExecuteDuty(ctx context.Context, slot uint64, duty *beacon.D
 		zap.Uint64("slot", slot),
 		zap.String("duty_type", duty.Type.String()))
 
-	metricsCurrentSlot.WithLabelValues(v.Share.PublicKey.SerializeToHexStr()).Set(float64(duty.Slot))
+	// the share can't be updated while a duty is running
+	v.dutyLock.RLock()
+	defer v.dutyLock.RUnlock()
+
+	if !v.isStarted() {
+		logger.Warn("validator is not started, ignoring duty")
+		return
+	}
+
+	metricsCurrentSlot.WithLabelValues(v.getShare().PublicKey.SerializeToHexStr()).Set(float64(duty.Slot))
 
 	logger.Debug("executing duty...")
+	if duty.Type == beacon.RoleTypeSyncCommitteeContribution {
+		v.executeSyncCommitteeContributions(ctx, logger, duty)
+		return
+	}
 	signaturesCount, decidedValue, seqNumber, err := v.comeToConsensusOnInputValue(logger, duty)
+	if err == errNotAggregator {
+		logger.Debug("validator was not selected as aggregator")
+		return
+	}
 	if err != nil {
 		logger.Error("could not come to consensus", zap.Error(err))
 		return
@@ -2,10 +2,15 @@ package validator
 
 import (
 	"context"
+	"crypto/sha256"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/utils/threshold"
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -74,9 +79,11 @@ func TestConsensusOnInputValue(t *testing.T) {
 			beacon.RoleTypeAttester,
 			refAttestationDataByts,
 			&spec.AttestationData{
-				Slot: 100,
+				Slot:   100,
+				Source: &spec.Checkpoint{Epoch: 0},
+				Target: &spec.Checkpoint{Epoch: 0},
 			},
-			"input value failed pre-consensus check: TEST - failed on slot 100",
+			"input value failed pre-consensus check: slashable attestation: double vote on target epoch 0",
 		},
 	}
 
@@ -207,3 +214,350 @@ func TestPostConsensusSignatureAndAggregation(t *testing.T) {
 		})
 	}
 }
+
+func TestRandaoReveal(t *testing.T) {
+	tests := []struct {
+		name          string
+		sigs          map[uint64][]byte
+		expectedError string
+	}{
+		{
+			"valid 3/4",
+			map[uint64][]byte{
+				2: signByShare(t, 1, refSigRoot),
+				3: signByShare(t, 2, refSigRoot),
+			},
+			"",
+		},
+		{
+			"invalid 3/4",
+			map[uint64][]byte{
+				2: signByShare(t, 1, refSigRoot),
+				3: signByShare(t, 1, refSigRoot),
+			},
+			"timed out waiting for pre consensus signatures, received 2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+			validator := testingValidator(t, true, 3, identifier)
+			// wait for for listeners to spin up
+			time.Sleep(time.Millisecond * 100)
+
+			duty := &beacon.Duty{
+				Type: beacon.RoleTypeProposer,
+				Slot: 12,
+			}
+
+			// send sigs
+			for index, sig := range test.sigs {
+				err := validator.network.BroadcastPreConsensusSignature(nil, &proto.SignedMessage{
+					Message: &proto.Message{
+						Lambda:    validator.randaoIdentifier(),
+						SeqNumber: 12,
+					},
+					Signature: sig,
+					SignerIds: []uint64{index},
+				})
+				require.NoError(t, err)
+			}
+
+			randaoReveal, err := validator.randaoReveal(validator.logger, duty)
+			if len(test.expectedError) > 0 {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			sig := &bls.Sign{}
+			require.NoError(t, sig.Deserialize(randaoReveal[:]))
+			require.True(t, sig.VerifyByte(validator.share.PublicKey, refSigRoot))
+		})
+	}
+}
+
+func signByShare(t *testing.T, index int, root []byte) []byte {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+	sk := &bls.SecretKey{}
+	require.NoError(t, sk.Deserialize(refSplitShares[index]))
+	return sk.SignByte(root).Serialize()
+}
+
+func TestAggregatorConsensusOnInputValue(t *testing.T) {
+	tests := []struct {
+		name               string
+		committeeLength    uint64
+		decidedAttestation bool
+		expectedError      error
+	}{
+		{
+			"selected as aggregator",
+			10,
+			true,
+			nil,
+		},
+		{
+			"selected as aggregator without decided attestation",
+			10,
+			false,
+			nil,
+		},
+		{
+			"not selected as aggregator",
+			beacon.TargetAggregatorsPerCommittee * 1000003,
+			true,
+			errNotAggregator,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+			validator := testingValidator(t, true, 3, identifier)
+			validator.ibfts[beacon.RoleTypeAggregator] = &testIBFT{decided: true, signaturesCount: 3}
+			ethNetwork := core.PraterNetwork
+			validator.ethNetwork = &ethNetwork
+			testBeacon := validator.beacon.(*testBeacon)
+			testBeacon.refAggregateAttestation = &spec.Attestation{
+				AggregationBits: []byte{0x03},
+				Data:            testBeacon.refAttestationData,
+			}
+			if test.decidedAttestation {
+				validator.setDecidedAttestation(testBeacon.refAttestationData)
+			}
+			// wait for for listeners to spin up
+			time.Sleep(time.Millisecond * 100)
+
+			duty := &beacon.Duty{
+				Type:            beacon.RoleTypeAggregator,
+				Slot:            testBeacon.refAttestationData.Slot,
+				CommitteeIndex:  testBeacon.refAttestationData.Index,
+				ValidatorIndex:  5,
+				CommitteeLength: test.committeeLength,
+			}
+
+			// send partial selection proofs
+			for index, sig := range map[uint64][]byte{
+				2: signByShare(t, 1, refSigRoot),
+				3: signByShare(t, 2, refSigRoot),
+			} {
+				err := validator.network.BroadcastPreConsensusSignature(nil, &proto.SignedMessage{
+					Message: &proto.Message{
+						Lambda:    validator.selectionProofIdentifier(),
+						SeqNumber: uint64(duty.Slot),
+					},
+					Signature: sig,
+					SignerIds: []uint64{index},
+				})
+				require.NoError(t, err)
+			}
+
+			_, decidedByts, _, err := validator.comeToConsensusOnInputValue(validator.logger, duty)
+			if test.expectedError != nil {
+				require.Equal(t, test.expectedError, err)
+				return
+			}
+			require.NoError(t, err)
+
+			aggregateAndProof := &spec.AggregateAndProof{}
+			require.NoError(t, aggregateAndProof.UnmarshalSSZ(decidedByts))
+			require.EqualValues(t, 5, aggregateAndProof.AggregatorIndex)
+			require.EqualValues(t, testBeacon.refAttestationData, aggregateAndProof.Aggregate.Data)
+			if test.decidedAttestation {
+				root, err := testBeacon.refAttestationData.HashTreeRoot()
+				require.NoError(t, err)
+				require.NotNil(t, testBeacon.LastAggregateAttestationDataRoot)
+				require.EqualValues(t, root, *testBeacon.LastAggregateAttestationDataRoot)
+			} else {
+				require.Nil(t, testBeacon.LastAggregateAttestationDataRoot)
+			}
+
+			sig := &bls.Sign{}
+			require.NoError(t, sig.Deserialize(append([]byte{}, aggregateAndProof.SelectionProof[:]...)))
+			require.True(t, sig.VerifyByte(validator.share.PublicKey, refSigRoot))
+		})
+	}
+}
+
+func TestSyncCommitteeConsensusOnInputValue(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	validator := testingValidator(t, true, 3, identifier)
+	validator.ibfts[beacon.RoleTypeSyncCommittee] = &testIBFT{decided: true, signaturesCount: 3}
+	validator.beacon.(*testBeacon).refSyncCommitteeBlockRoot = spec.Root{1, 2, 3}
+
+	duty := &beacon.Duty{
+		Type:                          beacon.RoleTypeSyncCommittee,
+		Slot:                          12,
+		ValidatorIndex:                5,
+		ValidatorSyncCommitteeIndices: []spec.CommitteeIndex{1},
+	}
+
+	signaturesCount, decidedByts, _, err := validator.comeToConsensusOnInputValue(validator.logger, duty)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, signaturesCount)
+	root := spec.Root{1, 2, 3}
+	require.EqualValues(t, root[:], decidedByts)
+}
+
+// selectedSyncAggregatorRoot returns a root which its reconstructed signature selects the validator as a sync committee aggregator
+func selectedSyncAggregatorRoot(t *testing.T) []byte {
+	for i := byte(0); i < 255; i++ {
+		root := sha256.Sum256([]byte{i})
+		sig, err := threshold.ReconstructSignatures(map[uint64][]byte{
+			1: signByShare(t, 0, root[:]),
+			2: signByShare(t, 1, root[:]),
+			3: signByShare(t, 2, root[:]),
+		})
+		require.NoError(t, err)
+		if beacon.IsSyncCommitteeAggregator(sig.Serialize()) {
+			return root[:]
+		}
+	}
+	require.FailNow(t, "could not find a selected sync aggregator root")
+	return nil
+}
+
+func TestSyncCommitteeContributionsAndProofs(t *testing.T) {
+	tests := []struct {
+		name                  string
+		indices               []spec.CommitteeIndex
+		signedSubcommittees   []uint64
+		expectedSubcommittees []uint64
+		expectedError         error
+	}{
+		{
+			"selected as aggregator",
+			[]spec.CommitteeIndex{200},
+			[]uint64{1},
+			[]uint64{1},
+			nil,
+		},
+		{
+			"selected as aggregator of multiple subcommittees",
+			[]spec.CommitteeIndex{200, 300, 210},
+			[]uint64{1, 2},
+			[]uint64{1, 2},
+			nil,
+		},
+		{
+			"failed selection of a subcommittee",
+			[]spec.CommitteeIndex{10, 300},
+			[]uint64{2},
+			[]uint64{2},
+			nil,
+		},
+		{
+			"not a sync committee member",
+			nil,
+			nil,
+			nil,
+			errNotAggregator,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+			validator := testingValidator(t, true, 3, identifier)
+			testBeacon := validator.beacon.(*testBeacon)
+			testBeacon.refSyncSelectionProofRoot = selectedSyncAggregatorRoot(t)
+			testBeacon.refSyncCommitteeBlockRoot = spec.Root{1, 2, 3}
+			testBeacon.refSyncCommitteeContribution = &altair.SyncCommitteeContribution{
+				Slot:            12,
+				BeaconBlockRoot: spec.Root{1, 2, 3},
+				AggregationBits: bitfield.NewBitvector128(),
+			}
+			// wait for for listeners to spin up
+			time.Sleep(time.Millisecond * 100)
+
+			duty := &beacon.Duty{
+				Type:                          beacon.RoleTypeSyncCommitteeContribution,
+				Slot:                          12,
+				ValidatorIndex:                5,
+				ValidatorSyncCommitteeIndices: test.indices,
+			}
+
+			// send partial sync committee selection proofs of the signed subcommittees
+			sigs := map[uint64][]byte{
+				2: signByShare(t, 1, testBeacon.refSyncSelectionProofRoot),
+				3: signByShare(t, 2, testBeacon.refSyncSelectionProofRoot),
+			}
+			for _, subcommitteeIndex := range test.signedSubcommittees {
+				for index, sig := range sigs {
+					err := validator.network.BroadcastPreConsensusSignature(nil, &proto.SignedMessage{
+						Message: &proto.Message{
+							Lambda:    validator.syncCommitteeSelectionProofIdentifier(subcommitteeIndex),
+							SeqNumber: 12,
+						},
+						Signature: sig,
+						SignerIds: []uint64{index},
+					})
+					require.NoError(t, err)
+				}
+			}
+
+			contributionsAndProofs, err := validator.syncCommitteeContributionsAndProofs(validator.logger, duty)
+			if test.expectedError != nil {
+				require.Equal(t, test.expectedError, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, contributionsAndProofs, len(test.expectedSubcommittees))
+
+			for i, contributionAndProof := range contributionsAndProofs {
+				byts, err := contributionAndProof.MarshalSSZ()
+				require.NoError(t, err)
+				require.NoError(t, validator.valueCheck.SyncCommitteeContributionValidation().Check(byts))
+				require.EqualValues(t, 5, contributionAndProof.AggregatorIndex)
+				require.EqualValues(t, test.expectedSubcommittees[i], contributionAndProof.Contribution.SubcommitteeIndex)
+
+				sig := &bls.Sign{}
+				require.NoError(t, sig.Deserialize(append([]byte{}, contributionAndProof.SelectionProof[:]...)))
+				require.True(t, sig.VerifyByte(validator.share.PublicKey, testBeacon.refSyncSelectionProofRoot))
+			}
+		})
+	}
+}
+
+func TestPostConsensusSyncCommitteeMessage(t *testing.T) {
+	identifier := _byteArray("6139636633363061613135666231643164333065653262353738646335383834383233633139363631383836616538623839323737356363623362643936623764373334353536396132616130623134653464303135633534613661306335345f4154544553544552")
+	validator := testingValidator(t, true, 3, identifier)
+	validator.ibfts[beacon.RoleTypeSyncCommittee] = &testIBFT{decided: true, signaturesCount: 3, identifier: identifier}
+	// wait for for listeners to spin up
+	time.Sleep(time.Millisecond * 100)
+
+	duty := &beacon.Duty{
+		Type:           beacon.RoleTypeSyncCommittee,
+		Slot:           12,
+		ValidatorIndex: 5,
+	}
+
+	// send sigs
+	for index, sig := range map[uint64][]byte{
+		1: refAttestationSplitSigs[0],
+		2: refAttestationSplitSigs[1],
+		3: refAttestationSplitSigs[2],
+	} {
+		err := validator.network.BroadcastSignature(nil, &proto.SignedMessage{
+			Message: &proto.Message{
+				Lambda:    identifier,
+				SeqNumber: 0,
+			},
+			Signature: sig,
+			SignerIds: []uint64{index},
+		})
+		require.NoError(t, err)
+	}
+
+	root := spec.Root{1, 2, 3}
+	require.NoError(t, validator.postConsensusDutyExecution(context.Background(), validator.logger, 0, root[:], 3, duty))
+
+	submitted := validator.beacon.(*testBeacon).LastSubmittedSyncCommitteeMessage
+	require.NotNil(t, submitted)
+	require.EqualValues(t, 12, submitted.Slot)
+	require.EqualValues(t, 5, submitted.ValidatorIndex)
+	require.Equal(t, root, submitted.BeaconBlockRoot)
+	require.EqualValues(t, refAttestationSig, submitted.Signature[:])
+}
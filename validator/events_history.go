@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/ethereum/go-ethereum/core/types"
+	"sync"
+)
+
+const (
+	// maxEventsHistory is the number of applied events that are kept for each validator
+	maxEventsHistory = 16
+)
+
+// eventsHistoryEntry is the registry state of a validator after an event was applied
+type eventsHistoryEntry struct {
+	eventID string
+	// share is the event that defines the share of the validator, nil if the validator was removed
+	share *eth1.ValidatorAddedEvent
+}
+
+// validatorEventsHistory holds the registry states of a single validator, the last entry is the current state
+type validatorEventsHistory struct {
+	// initialKnown is true if the validator didn't exist before the first entry,
+	// otherwise the state before the first entry was loaded from storage and can't be restored
+	initialKnown bool
+	entries      []*eventsHistoryEntry
+}
+
+// eventsHistory keeps the registry states of validators that were changed by contract events,
+// so events that were dropped by a chain reorg can be reverted by restoring the previous state.
+// the history is kept in memory only as it holds the decrypted share keys,
+// therefore events that were applied before the node started can't be reverted
+type eventsHistory struct {
+	lock       sync.Mutex
+	validators map[string]*validatorEventsHistory
+}
+
+// newEventsHistory creates a new instance of eventsHistory
+func newEventsHistory() *eventsHistory {
+	return &eventsHistory{
+		validators: make(map[string]*validatorEventsHistory),
+	}
+}
+
+// push records the state of the given validator after the given event was applied,
+// initialKnown should be true if the validator didn't exist before the event
+func (eh *eventsHistory) push(pubKey string, eventID string, share *eth1.ValidatorAddedEvent, initialKnown bool) {
+	eh.lock.Lock()
+	defer eh.lock.Unlock()
+
+	h, ok := eh.validators[pubKey]
+	if !ok {
+		h = &validatorEventsHistory{initialKnown: initialKnown}
+		eh.validators[pubKey] = h
+	}
+	for _, entry := range h.entries {
+		if entry.eventID == eventID {
+			return
+		}
+	}
+	h.entries = append(h.entries, &eventsHistoryEntry{eventID: eventID, share: share})
+	if len(h.entries) > maxEventsHistory {
+		h.entries = h.entries[len(h.entries)-maxEventsHistory:]
+		h.initialKnown = false
+	}
+}
+
+// revertResult is the outcome of reverting an event for a single validator
+type revertResult struct {
+	// changed is false if a later event still defines the state of the validator
+	changed bool
+	// known is false if the previous state of the validator was not kept
+	known bool
+	// share is the event that defines the previous share, nil if the validator should be removed
+	share *eth1.ValidatorAddedEvent
+}
+
+// revert drops the given event from the history of all validators and returns their previous states
+func (eh *eventsHistory) revert(eventID string) map[string]*revertResult {
+	eh.lock.Lock()
+	defer eh.lock.Unlock()
+
+	results := make(map[string]*revertResult)
+	for pubKey, h := range eh.validators {
+		for i, entry := range h.entries {
+			if entry.eventID != eventID {
+				continue
+			}
+			isLast := i == len(h.entries)-1
+			h.entries = append(h.entries[:i], h.entries[i+1:]...)
+			res := &revertResult{changed: isLast, known: true}
+			if isLast {
+				if len(h.entries) > 0 {
+					res.share = h.entries[len(h.entries)-1].share
+				} else {
+					res.known = h.initialKnown
+				}
+			}
+			if len(h.entries) == 0 {
+				delete(eh.validators, pubKey)
+			}
+			results[pubKey] = res
+			break
+		}
+	}
+	return results
+}
+
+// eventID returns a unique id of the log of an event
+func eventID(log types.Log) string {
+	return fmt.Sprintf("%s:%s:%d", log.BlockHash.Hex(), log.TxHash.Hex(), log.Index)
+}
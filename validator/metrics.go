@@ -72,4 +72,8 @@ var (
 	validatorStatusNotFound     validatorStatus = 7
 	validatorStatusPending      validatorStatus = 8
 	validatorStatusUnknown      validatorStatus = 9
+	// validatorStatusDoppelgangerCheck means that the validator is held by doppelganger protection
+	validatorStatusDoppelgangerCheck validatorStatus = 10
+	// validatorStatusDoppelganger means that the validator was seen running elsewhere and won't be started
+	validatorStatusDoppelganger validatorStatus = 11
 )
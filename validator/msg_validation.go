@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"bytes"
+	"strings"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/ibft"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/msgqueue"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// validateMsg validates messages of validator topics before they are propagated,
+// messages of unknown validators are accepted as their committee is not known to this operator
+func (c *controller) validateMsg(msg *network.Message) network.MsgValidationResult {
+	pk := pubKeyFromIdentifier(msg.SignedMessage.Message.Lambda)
+	v, ok := c.validatorsMap.GetValidator(pk)
+	if !ok {
+		return network.MsgValidationAccept
+	}
+	res := v.validateMsg(msg)
+	if c.doppelganger != nil && res == network.MsgValidationAccept {
+		c.doppelganger.onMessage(pk, v.getShare().NodeID, msg)
+	}
+	return res
+}
+
+// validateMsg checks that the message was signed by a member of the validator's committee and is not stale,
+// partial signatures are verified once their signing root is known
+func (v *Validator) validateMsg(msg *network.Message) network.MsgValidationResult {
+	signedMsg := msg.SignedMessage
+	identifier := signedMsg.Message.Lambda
+
+	v.ibftsLock.RLock()
+	share := v.getShare()
+	ib := v.ibftByIdentifier(identifier)
+	v.ibftsLock.RUnlock()
+
+	switch msg.Type {
+	case network.NetworkMsg_IBFTType, network.NetworkMsg_DecidedType:
+		if ib == nil {
+			return network.MsgValidationReject
+		}
+		if err := share.VerifySignedMessage(signedMsg); err != nil {
+			return network.MsgValidationReject
+		}
+		// messages of instances older than the highest decided one are not relevant anymore
+		nextSeq, err := ib.NextSeqNumber()
+		if err == nil && signedMsg.Message.SeqNumber+1 < nextSeq {
+			return network.MsgValidationIgnore
+		}
+	case network.NetworkMsg_SignatureType, network.NetworkMsg_PreConsensusSignatureType:
+		if msg.Type == network.NetworkMsg_SignatureType && ib == nil {
+			return network.MsgValidationReject
+		}
+		isVoluntaryExit := msg.Type == network.NetworkMsg_PreConsensusSignatureType &&
+			bytes.Equal(identifier, v.voluntaryExitIdentifier())
+		if msg.Type == network.NetworkMsg_PreConsensusSignatureType && !isVoluntaryExit && !v.oneOfPreConsensusIdentifiers(identifier) {
+			return network.MsgValidationReject
+		}
+		if err := verifyPartialSignatureSigner(share.Committee, signedMsg.SignerIds, signedMsg.Signature); err != nil {
+			return network.MsgValidationReject
+		}
+		var root []byte
+		var err error
+		switch {
+		case isVoluntaryExit:
+			// the seq number of voluntary exit messages is the exit epoch, exits of future epochs are not valid yet
+			if signedMsg.Message.SeqNumber > uint64(v.ethNetwork.EstimatedCurrentEpoch()) {
+				return network.MsgValidationIgnore
+			}
+			root, err = v.voluntaryExitRoot(spec.Epoch(signedMsg.Message.SeqNumber))
+		case msg.Type == network.NetworkMsg_SignatureType:
+			// the seq number of post consensus signatures is the seq number of the decided instance,
+			// signatures of instances older than the highest decided one are not relevant anymore
+			nextSeq, seqErr := ib.NextSeqNumber()
+			if seqErr == nil && signedMsg.Message.SeqNumber+1 < nextSeq {
+				return network.MsgValidationIgnore
+			}
+			root = v.postConsensusRoot(msgqueue.SigRoundIndexKey(identifier, signedMsg.Message.SeqNumber))
+		default:
+			// the seq number of pre consensus signatures is the slot of the duty
+			slot := signedMsg.Message.SeqNumber
+			if slot+v.ethNetwork.SlotsPerEpoch() < uint64(v.ethNetwork.EstimatedCurrentSlot()) {
+				return network.MsgValidationIgnore
+			}
+			root, err = v.preConsensusSignatureRoot(identifier, spec.Slot(slot))
+		}
+		// partial signatures are signed over beacon roots, signatures which their root can't be computed are not propagated
+		if err != nil || root == nil {
+			return network.MsgValidationIgnore
+		}
+		if err := v.verifyPartialSignature(signedMsg.Signature, root, signedMsg.SignerIds[0], share.Committee); err != nil {
+			return network.MsgValidationReject
+		}
+	default:
+		return network.MsgValidationReject
+	}
+	return network.MsgValidationAccept
+}
+
+// ibftByIdentifier returns the ibft controller of the given identifier, this method is not thread-safe -
+// should be called after ibftsLock was acquired
+func (v *Validator) ibftByIdentifier(identifier []byte) ibft.Controller {
+	for _, ib := range v.ibfts {
+		if bytes.Equal(ib.GetIdentifier(), identifier) {
+			return ib
+		}
+	}
+	return nil
+}
+
+// verifyPartialSignatureSigner checks that a partial signature has a single signer that belongs to the committee
+func verifyPartialSignatureSigner(committee map[uint64]*proto.Node, signerIds []uint64, sig []byte) error {
+	if len(signerIds) != 1 {
+		return errors.New("partial signature must have a single signer")
+	}
+	if _, ok := committee[signerIds[0]]; !ok {
+		return errors.New("signer is not a member of the committee")
+	}
+	if err := (&bls.Sign{}).Deserialize(sig); err != nil {
+		return errors.Wrap(err, "could not deserialize partial signature")
+	}
+	return nil
+}
+
+// pubKeyFromIdentifier returns the hex encoded public key that the given identifier (lambda) was created for
+func pubKeyFromIdentifier(identifier []byte) string {
+	return strings.SplitN(string(identifier), "_", 2)[0]
+}
@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/msgqueue"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestValidator_ValidateMsg(t *testing.T) {
+	identifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeAttester.String()))
+	v := testingValidator(t, true, 4, identifier)
+	v.ibfts[beacon.RoleTypeAttester].(*testIBFT).nextSeqNumber = 5
+	ethNetwork := core.PraterNetwork
+	v.ethNetwork = &ethNetwork
+	currentSlot := uint64(ethNetwork.EstimatedCurrentSlot())
+	currentEpoch := uint64(ethNetwork.EstimatedCurrentEpoch())
+
+	sks := make(map[uint64]*bls.SecretKey)
+	for i, share := range refSplitShares {
+		sk := &bls.SecretKey{}
+		require.NoError(t, sk.Deserialize(share))
+		sks[uint64(i+1)] = sk
+	}
+	ibftMsg := func(seq uint64, signer uint64, sk *bls.SecretKey) *proto.SignedMessage {
+		msg := &proto.Message{
+			Type:      proto.RoundState_Commit,
+			Lambda:    identifier,
+			SeqNumber: seq,
+			Value:     []byte("value"),
+		}
+		sig, err := msg.Sign(sk)
+		require.NoError(t, err)
+		return &proto.SignedMessage{Message: msg, Signature: sig.Serialize(), SignerIds: []uint64{signer}}
+	}
+	sigMsg := func(lambda []byte, slot uint64, signers ...uint64) *proto.SignedMessage {
+		sk := sks[1]
+		if len(signers) == 1 && sks[signers[0]] != nil {
+			sk = sks[signers[0]]
+		}
+		return &proto.SignedMessage{
+			Message:   &proto.Message{Lambda: lambda, SeqNumber: slot},
+			Signature: sk.SignByte(refSigRoot).Serialize(),
+			SignerIds: signers,
+		}
+	}
+	invalidSigMsg := func(lambda []byte, slot uint64, signer uint64) *proto.SignedMessage {
+		msg := sigMsg(lambda, slot, signer)
+		msg.Signature = sks[signer].SignByte([]byte("invalid root")).Serialize()
+		return msg
+	}
+	unknownIdentifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeProposer.String()))
+	// the post consensus signatures of instance 4 were signed by this operator
+	v.setPostConsensusRoot(msgqueue.SigRoundIndexKey(identifier, 4), refSigRoot)
+	v.share.Metadata = &beacon.ValidatorMetadata{Index: 1}
+
+	tests := []struct {
+		name     string
+		msgType  network.NetworkMsg
+		msg      *proto.SignedMessage
+		expected network.MsgValidationResult
+	}{
+		{"valid ibft msg", network.NetworkMsg_IBFTType, ibftMsg(4, 1, sks[1]), network.MsgValidationAccept},
+		{"valid decided msg", network.NetworkMsg_DecidedType, ibftMsg(5, 2, sks[2]), network.MsgValidationAccept},
+		{"ibft msg with invalid signature", network.NetworkMsg_IBFTType, ibftMsg(5, 1, sks[2]), network.MsgValidationReject},
+		{"ibft msg of unknown signer", network.NetworkMsg_IBFTType, ibftMsg(5, 5, sks[1]), network.MsgValidationReject},
+		{"ibft msg of unknown identifier", network.NetworkMsg_IBFTType, &proto.SignedMessage{
+			Message:   &proto.Message{Lambda: unknownIdentifier},
+			SignerIds: []uint64{1},
+		}, network.MsgValidationReject},
+		{"stale ibft msg", network.NetworkMsg_IBFTType, ibftMsg(3, 1, sks[1]), network.MsgValidationIgnore},
+		// post consensus signatures are sent with the seq number of the decided instance (next seq number is 5)
+		{"valid signature msg", network.NetworkMsg_SignatureType, sigMsg(identifier, 4, 1), network.MsgValidationAccept},
+		{"signature msg of instance that was not signed", network.NetworkMsg_SignatureType, sigMsg(identifier, 5, 1), network.MsgValidationIgnore},
+		{"signature msg with invalid signature", network.NetworkMsg_SignatureType, invalidSigMsg(identifier, 4, 2), network.MsgValidationReject},
+		{"signature msg of unknown signer", network.NetworkMsg_SignatureType, sigMsg(identifier, 4, 5), network.MsgValidationReject},
+		{"signature msg with multiple signers", network.NetworkMsg_SignatureType, sigMsg(identifier, 4, 1, 2), network.MsgValidationReject},
+		{"signature msg of unknown identifier", network.NetworkMsg_SignatureType, sigMsg(unknownIdentifier, 4, 1), network.MsgValidationReject},
+		{"stale signature msg", network.NetworkMsg_SignatureType, sigMsg(identifier, 3, 1), network.MsgValidationIgnore},
+		{"valid pre consensus signature msg", network.NetworkMsg_PreConsensusSignatureType, sigMsg(v.randaoIdentifier(), currentSlot, 2), network.MsgValidationAccept},
+		{"valid selection proof signature msg", network.NetworkMsg_PreConsensusSignatureType, sigMsg(v.selectionProofIdentifier(), currentSlot, 3), network.MsgValidationAccept},
+		{"valid sync committee selection proof signature msg", network.NetworkMsg_PreConsensusSignatureType, sigMsg(v.syncCommitteeSelectionProofIdentifier(2), currentSlot, 4), network.MsgValidationAccept},
+		{"pre consensus signature msg with invalid signature", network.NetworkMsg_PreConsensusSignatureType, invalidSigMsg(v.randaoIdentifier(), currentSlot, 2), network.MsgValidationReject},
+		{"stale pre consensus signature msg", network.NetworkMsg_PreConsensusSignatureType, sigMsg(v.randaoIdentifier(), currentSlot-ethNetwork.SlotsPerEpoch()-1, 2), network.MsgValidationIgnore},
+		{"pre consensus signature msg of ibft identifier", network.NetworkMsg_PreConsensusSignatureType, sigMsg(identifier, currentSlot, 2), network.MsgValidationReject},
+		{"valid voluntary exit signature msg", network.NetworkMsg_PreConsensusSignatureType, sigMsg(v.voluntaryExitIdentifier(), currentEpoch, 2), network.MsgValidationAccept},
+		{"voluntary exit signature msg of past epoch", network.NetworkMsg_PreConsensusSignatureType, sigMsg(v.voluntaryExitIdentifier(), 1, 2), network.MsgValidationAccept},
+		{"voluntary exit signature msg of future epoch", network.NetworkMsg_PreConsensusSignatureType, sigMsg(v.voluntaryExitIdentifier(), currentEpoch+1, 2), network.MsgValidationIgnore},
+		{"voluntary exit signature msg with invalid signature", network.NetworkMsg_PreConsensusSignatureType, invalidSigMsg(v.voluntaryExitIdentifier(), currentEpoch, 2), network.MsgValidationReject},
+		{"voluntary exit signature msg of unknown signer", network.NetworkMsg_PreConsensusSignatureType, sigMsg(v.voluntaryExitIdentifier(), currentEpoch, 5), network.MsgValidationReject},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, v.validateMsg(&network.Message{SignedMessage: test.msg, Type: test.msgType}))
+		})
+	}
+
+	t.Run("voluntary exit signature msg of unknown validator index", func(t *testing.T) {
+		v.share.Metadata = nil
+		msg := sigMsg(v.voluntaryExitIdentifier(), currentEpoch, 2)
+		require.Equal(t, network.MsgValidationIgnore, v.validateMsg(&network.Message{SignedMessage: msg, Type: network.NetworkMsg_PreConsensusSignatureType}))
+	})
+}
+
+func TestController_ValidateMsg(t *testing.T) {
+	identifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeAttester.String()))
+	v := testingValidator(t, true, 4, identifier)
+	ctrl := setupController(zap.L(), map[string]*Validator{v.share.PublicKey.SerializeToHexStr(): v})
+
+	t.Run("known validator", func(t *testing.T) {
+		msg := &network.Message{
+			SignedMessage: &proto.SignedMessage{Message: &proto.Message{Lambda: identifier}, SignerIds: []uint64{1}},
+			Type:          network.NetworkMsg_IBFTType,
+		}
+		require.Equal(t, network.MsgValidationReject, ctrl.validateMsg(msg))
+	})
+
+	t.Run("unknown validator", func(t *testing.T) {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		msg := &network.Message{
+			SignedMessage: &proto.SignedMessage{
+				Message: &proto.Message{Lambda: []byte(format.IdentifierFormat(sk.GetPublicKey().Serialize(), beacon.RoleTypeAttester.String()))},
+			},
+			Type: network.NetworkMsg_IBFTType,
+		}
+		require.Equal(t, network.MsgValidationAccept, ctrl.validateMsg(msg))
+	})
+}
@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/network/msgqueue"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// randaoRole is the identifier suffix of the randao reveal signatures round, preceding block proposals
+	randaoRole = "RANDAO"
+	// selectionProofRole is the identifier suffix of the selection proof signatures round, preceding aggregations
+	selectionProofRole = "SELECTION_PROOF"
+	// syncCommitteeSelectionProofRole is the identifier suffix of the sync committee selection proof signatures round, preceding contributions
+	syncCommitteeSelectionProofRole = "SYNC_COMMITTEE_SELECTION_PROOF"
+)
+
+// randaoIdentifier returns the identifier used for collecting randao reveal partial signatures
+func (v *Validator) randaoIdentifier() []byte {
+	return []byte(format.IdentifierFormat(v.getShare().PublicKey.Serialize(), randaoRole))
+}
+
+// selectionProofIdentifier returns the identifier used for collecting selection proof partial signatures
+func (v *Validator) selectionProofIdentifier() []byte {
+	return []byte(format.IdentifierFormat(v.getShare().PublicKey.Serialize(), selectionProofRole))
+}
+
+// syncCommitteeSelectionProofIdentifier returns the identifier used for collecting sync committee selection proof partial signatures of the given subcommittee
+func (v *Validator) syncCommitteeSelectionProofIdentifier(subcommitteeIndex uint64) []byte {
+	return []byte(format.IdentifierFormat(v.getShare().PublicKey.Serialize(), fmt.Sprintf("%s_%d", syncCommitteeSelectionProofRole, subcommitteeIndex)))
+}
+
+// syncCommitteeSelectionProofSubcommittee returns the subcommittee of the given sync committee selection proof identifier,
+// returns false if the identifier doesn't belong to a sync committee selection proof
+func (v *Validator) syncCommitteeSelectionProofSubcommittee(identifier []byte) (uint64, bool) {
+	for subcommitteeIndex := uint64(0); subcommitteeIndex < beacon.SyncCommitteeSubnetCount; subcommitteeIndex++ {
+		if bytes.Equal(v.syncCommitteeSelectionProofIdentifier(subcommitteeIndex), identifier) {
+			return subcommitteeIndex, true
+		}
+	}
+	return 0, false
+}
+
+// oneOfPreConsensusIdentifiers will return true if provided identifier matches one of the signature rounds preceding consensus.
+func (v *Validator) oneOfPreConsensusIdentifiers(toMatch []byte) bool {
+	if bytes.Equal(v.randaoIdentifier(), toMatch) || bytes.Equal(v.selectionProofIdentifier(), toMatch) {
+		return true
+	}
+	_, ok := v.syncCommitteeSelectionProofSubcommittee(toMatch)
+	return ok
+}
+
+// preConsensusSignatureRoot computes the signing root of a pre consensus partial signature of the given identifier and slot,
+// it is the same root that the signer computes for the duty of the slot
+func (v *Validator) preConsensusSignatureRoot(identifier []byte, slot spec.Slot) ([]byte, error) {
+	epoch := spec.Epoch(uint64(slot) / v.ethNetwork.SlotsPerEpoch())
+	var domainType beacon.DomainType
+	var object interface{}
+	if bytes.Equal(v.randaoIdentifier(), identifier) {
+		domainType, object = beacon.DomainRandao, uint64(epoch)
+	} else if bytes.Equal(v.selectionProofIdentifier(), identifier) {
+		domainType, object = beacon.DomainSelectionProof, uint64(slot)
+	} else if subcommitteeIndex, ok := v.syncCommitteeSelectionProofSubcommittee(identifier); ok {
+		domainType, object = beacon.DomainSyncCommitteeSelectionProof, &altair.SyncAggregatorSelectionData{
+			Slot:              slot,
+			SubcommitteeIndex: subcommitteeIndex,
+		}
+	} else {
+		return nil, errors.New("unknown pre consensus identifier")
+	}
+	domain, err := v.beacon.GetEpochDomain(domainType, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get domain")
+	}
+	root, err := v.beacon.ComputeSigningRoot(object, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute signing root")
+	}
+	return root[:], nil
+}
+
+func (v *Validator) listenToPreConsensusSignatureMessages() {
+	sigChan, done := v.network.ReceivedPreConsensusSignatureChan()
+	defer done()
+	for {
+		select {
+		case <-v.stopCh:
+			return
+		case sigMsg, ok := <-sigChan:
+			if !ok {
+				return
+			}
+			if sigMsg == nil {
+				v.logger.Debug("got nil message")
+				continue
+			}
+
+			// voluntary exits are not signed as part of a duty, therefore their signatures are handled as they arrive
+			if sigMsg.Message != nil && bytes.Equal(sigMsg.Message.Lambda, v.voluntaryExitIdentifier()) {
+				v.onVoluntaryExitSignature(sigMsg)
+				continue
+			}
+			if sigMsg.Message != nil && v.oneOfPreConsensusIdentifiers(sigMsg.Message.Lambda) {
+				v.msgQueue.AddMessage(&network.Message{
+					SignedMessage: sigMsg,
+					Type:          network.NetworkMsg_PreConsensusSignatureType,
+				})
+			}
+		}
+	}
+}
+
+// preConsensusSignature broadcasts the given partial signature and reconstructs the full signature
+// once enough partial signatures of the other operators were collected for the duty's slot
+func (v *Validator) preConsensusSignature(logger *zap.Logger, identifier []byte, duty *beacon.Duty, sig []byte, root []byte) (spec.BLSSignature, error) {
+	slot := uint64(duty.Slot)
+	share := v.getShare()
+	if err := v.network.BroadcastPreConsensusSignature(share.PublicKey.Serialize(), &proto.SignedMessage{
+		Message: &proto.Message{
+			Lambda:    identifier,
+			SeqNumber: slot,
+		},
+		Signature: sig,
+		SignerIds: []uint64{share.NodeID},
+	}); err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to broadcast pre consensus signature")
+	}
+	logger.Info("broadcasting partial signature pre consensus", zap.String("identifier", string(identifier)))
+
+	indexKey := msgqueue.PreConsensusSigIndexKey(identifier, slot)
+	signatures, err := v.collectSignatures(logger, "pre consensus", indexKey, root, share.ThresholdSize(), share.Committee, v.preConsensusSigTimeout)
+
+	// clean queue for messages, we don't need them anymore.
+	v.msgQueue.PurgeIndexedMessages(indexKey)
+
+	if err != nil {
+		return spec.BLSSignature{}, err
+	}
+
+	signature, err := v.reconstructSignature(signatures, root)
+	if err != nil {
+		return spec.BLSSignature{}, err
+	}
+	ret := spec.BLSSignature{}
+	copy(ret[:], signature.Serialize())
+	return ret, nil
+}
+
+// randaoReveal signs the randao reveal of the duty's epoch and reconstructs it with the other operators
+func (v *Validator) randaoReveal(logger *zap.Logger, duty *beacon.Duty) (spec.BLSSignature, error) {
+	pk, err := v.getShare().OperatorPubKey()
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "could not find operator pk for signing randao reveal")
+	}
+	sig, root, err := v.signer.SignRandaoReveal(duty, pk.Serialize())
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to sign randao reveal")
+	}
+	return v.preConsensusSignature(logger, v.randaoIdentifier(), duty, sig, root)
+}
+
+// selectionProof signs the slot of the duty and reconstructs the aggregator selection proof with the other operators
+func (v *Validator) selectionProof(logger *zap.Logger, duty *beacon.Duty) (spec.BLSSignature, error) {
+	pk, err := v.getShare().OperatorPubKey()
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "could not find operator pk for signing selection proof")
+	}
+	sig, root, err := v.signer.SignSelectionProof(duty, pk.Serialize())
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to sign selection proof")
+	}
+	return v.preConsensusSignature(logger, v.selectionProofIdentifier(), duty, sig, root)
+}
+
+// syncCommitteeSelectionProof signs the slot and subcommittee of the duty and reconstructs the sync committee selection proof with the other operators
+func (v *Validator) syncCommitteeSelectionProof(logger *zap.Logger, duty *beacon.Duty, subcommitteeIndex uint64) (spec.BLSSignature, error) {
+	pk, err := v.getShare().OperatorPubKey()
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "could not find operator pk for signing sync committee selection proof")
+	}
+	sig, root, err := v.signer.SignSyncCommitteeSelectionProof(subcommitteeIndex, duty, pk.Serialize())
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to sign sync committee selection proof")
+	}
+	return v.preConsensusSignature(logger, v.syncCommitteeSelectionProofIdentifier(subcommitteeIndex), duty, sig, root)
+}
@@ -2,6 +2,9 @@ package validator
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/ibft/proto"
@@ -36,7 +39,7 @@ func (v *Validator) verifyPartialSignature(signature []byte, root []byte, ibftID
 // signDuty signs the duty after iBFT came to consensus
 func (v *Validator) signDuty(decidedValue []byte, duty *beacon.Duty) ([]byte, []byte, *beacon.DutyData, error) {
 	// get operator pk for sig
-	pk, err := v.Share.OperatorPubKey()
+	pk, err := v.getShare().OperatorPubKey()
 	if err != nil {
 		return nil, nil, nil, errors.Wrap(err, "could not find operator pk for signing duty")
 	}
@@ -51,6 +54,7 @@ func (v *Validator) signDuty(decidedValue []byte, duty *beacon.Duty) ([]byte, []
 		if err := s.UnmarshalSSZ(decidedValue); err != nil {
 			return nil, nil, nil, errors.Wrap(err, "failed to marshal attestation")
 		}
+		v.setDecidedAttestation(s)
 		signedAttestation, r, err := v.signer.SignAttestation(s, duty, pk.Serialize())
 		if err != nil {
 			return nil, nil, nil, errors.Wrap(err, "failed to sign attestation")
@@ -62,53 +66,92 @@ func (v *Validator) signDuty(decidedValue []byte, duty *beacon.Duty) ([]byte, []
 		retValueStruct.GetAttestation().AggregationBits = signedAttestation.AggregationBits
 		sig = signedAttestation.Signature[:]
 		root = ensureRoot(r)
-	//case beacon.RoleTypeAggregator:
-	//	s := &proto.InputValue_Aggregation{}
-	//	if err := json.Unmarshal(decidedValue, s); err != nil {
-	//		return nil, nil, nil, errors.Wrap(err, "failed to marshal aggregator")
-	//	}
-	//	signedAggregation, e := v.beacon.SignAggregation(ctx, s.Aggregation.Message, v.Share.ShareKey)
-	//	if e != nil{
-	//		return nil, nil, nil, errors.Wrap(err, "failed to sign attestation")
-	//	}
-	//	retValueStruct.SignedData = s
-	//	retValueStruct.GetAggregation().Signature = signedAggregation.Signature
-	//	retValueStruct.GetAggregation().Message = signedAggregation.Message
-	//	err = e
-	//	sig = signedAggregation.GetSignature()
-	//case beacon.RoleTypeProposer:
-	//	s := &proto.InputValue_Block{}
-	//	if err := json.Unmarshal(decidedValue, s); err != nil {
-	//		return nil, nil, nil, errors.Wrap(err, "failed to marshal aggregator")
-	//	}
-	//
-	//	signedProposal, e := v.beacon.SignProposal(ctx, nil, s.Block.Block, v.Share.ShareKey)
-	//	if e != nil{
-	//		return nil, nil, nil, errors.Wrap(err, "failed to sign attestation")
-	//	}
-	//
-	//	retValueStruct.SignedData = s
-	//	retValueStruct.GetBlock().Signature = signedProposal.Signature
-	//	retValueStruct.GetBlock().Block = signedProposal.Block
-	//	err = e
-	//	sig = signedProposal.GetSignature()
+	case beacon.RoleTypeAggregator:
+		s := &spec.AggregateAndProof{}
+		if err := s.UnmarshalSSZ(decidedValue); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to unmarshal aggregate and proof")
+		}
+		signedAggregateAndProof, r, err := v.signer.SignAggregateAndProof(s, duty, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign aggregate and proof")
+		}
+
+		retValueStruct.SignedData = &beacon.InputValueSignedAggregateAndProof{SignedAggregateAndProof: signedAggregateAndProof}
+		sig = signedAggregateAndProof.Signature[:]
+		root = ensureRoot(r)
+	case beacon.RoleTypeProposer:
+		s := &eth2spec.VersionedBeaconBlock{}
+		if err := json.Unmarshal(decidedValue, s); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to unmarshal beacon block")
+		}
+		signedBlock, r, err := v.signer.SignBeaconBlock(s, duty, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign beacon block")
+		}
+
+		retValueStruct.SignedData = &beacon.InputValueSignedBeaconBlock{SignedBeaconBlock: signedBlock}
+		switch signedBlock.Version {
+		case eth2spec.DataVersionPhase0:
+			sig = signedBlock.Phase0.Signature[:]
+		case eth2spec.DataVersionAltair:
+			sig = signedBlock.Altair.Signature[:]
+		default:
+			return nil, nil, nil, errors.Errorf("unsupported beacon block version %d", signedBlock.Version)
+		}
+		root = ensureRoot(r)
+	case beacon.RoleTypeSyncCommittee:
+		if len(decidedValue) != len(spec.Root{}) {
+			return nil, nil, nil, errors.New("failed to parse sync committee block root")
+		}
+		blockRoot := spec.Root{}
+		copy(blockRoot[:], decidedValue)
+		msg, r, err := v.signer.SignSyncCommitteeMessage(blockRoot, duty, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign sync committee message")
+		}
+
+		retValueStruct.SignedData = &beacon.InputValueSyncCommitteeMessage{SyncCommitteeMessage: msg}
+		sig = msg.Signature[:]
+		root = ensureRoot(r)
+	case beacon.RoleTypeSyncCommitteeContribution:
+		s := &altair.ContributionAndProof{}
+		if err := s.UnmarshalSSZ(decidedValue); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to unmarshal contribution and proof")
+		}
+		signedContributionAndProof, r, err := v.signer.SignContributionAndProof(s, duty, pk.Serialize())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "failed to sign contribution and proof")
+		}
+
+		retValueStruct.SignedData = &beacon.InputValueSignedContributionAndProof{SignedContributionAndProof: signedContributionAndProof}
+		sig = signedContributionAndProof.Signature[:]
+		root = ensureRoot(r)
 	default:
 		return nil, nil, nil, errors.New("unsupported role, can't sign")
 	}
 	return sig, root, retValueStruct, err
 }
 
+// reconstructSignature reconstructs the received signatures from other nodes and verifies the result against the validator's public key
+func (v *Validator) reconstructSignature(signatures map[uint64][]byte, root []byte) (*bls.Sign, error) {
+	signature, err := threshold.ReconstructSignatures(signatures)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reconstruct signatures")
+	}
+	// verify reconstructed sig
+	if res := signature.VerifyByte(v.getShare().PublicKey, root); !res {
+		return nil, errors.New("could not reconstruct a valid signature")
+	}
+	return signature, nil
+}
+
 // reconstructAndBroadcastSignature reconstructs the received signatures from other
 // nodes and broadcasts the reconstructed signature to the beacon-chain
 func (v *Validator) reconstructAndBroadcastSignature(logger *zap.Logger, signatures map[uint64][]byte, root []byte, inputValue *beacon.DutyData, duty *beacon.Duty) error {
 	// Reconstruct signatures
-	signature, err := threshold.ReconstructSignatures(signatures)
+	signature, err := v.reconstructSignature(signatures, root)
 	if err != nil {
-		return errors.Wrap(err, "failed to reconstruct signatures")
-	}
-	// verify reconstructed sig
-	if res := signature.VerifyByte(v.Share.PublicKey, root); !res {
-		return errors.New("could not reconstruct a valid signature")
+		return err
 	}
 
 	logger.Info("signatures successfully reconstructed", zap.String("signature", base64.StdEncoding.EncodeToString(signature.Serialize())), zap.Int("signature count", len(signatures)))
@@ -123,16 +166,44 @@ func (v *Validator) reconstructAndBroadcastSignature(logger *zap.Logger, signatu
 		if err := v.beacon.SubmitAttestation(inputValue.GetAttestation()); err != nil {
 			return errors.Wrap(err, "failed to broadcast attestation")
 		}
-	//case beacon.RoleTypeAggregator:
-	//	inputValue.GetAggregation().Signature = signature.Serialize()
-	//	if err := v.beacon.SubmitAggregation(ctx, inputValue.GetAggregation()); err != nil {
-	//		return errors.Wrap(err, "failed to broadcast aggregation")
-	//	}
-	//case beacon.RoleTypeProposer:
-	//	inputValue.GetBlock().Signature = signature.Serialize()
-	//	if err := v.beacon.SubmitProposal(ctx, inputValue.GetBlock()); err != nil {
-	//		return errors.Wrap(err, "failed to broadcast proposal")
-	//	}
+	case beacon.RoleTypeAggregator:
+		logger.Debug("submitting aggregate and proof")
+		blsSig := spec.BLSSignature{}
+		copy(blsSig[:], signature.Serialize()[:])
+		inputValue.GetSignedAggregateAndProof().Signature = blsSig
+		if err := v.beacon.SubmitSignedAggregateSelectionProof(inputValue.GetSignedAggregateAndProof()); err != nil {
+			return errors.Wrap(err, "failed to broadcast aggregate and proof")
+		}
+	case beacon.RoleTypeProposer:
+		logger.Debug("submitting beacon block")
+		blsSig := spec.BLSSignature{}
+		copy(blsSig[:], signature.Serialize()[:])
+		signedBlock := inputValue.GetSignedBeaconBlock()
+		switch signedBlock.Version {
+		case eth2spec.DataVersionPhase0:
+			signedBlock.Phase0.Signature = blsSig
+		case eth2spec.DataVersionAltair:
+			signedBlock.Altair.Signature = blsSig
+		}
+		if err := v.beacon.SubmitBeaconBlock(signedBlock); err != nil {
+			return errors.Wrap(err, "failed to broadcast beacon block")
+		}
+	case beacon.RoleTypeSyncCommittee:
+		logger.Debug("submitting sync committee message")
+		blsSig := spec.BLSSignature{}
+		copy(blsSig[:], signature.Serialize()[:])
+		inputValue.GetSyncCommitteeMessage().Signature = blsSig
+		if err := v.beacon.SubmitSyncCommitteeMessage(inputValue.GetSyncCommitteeMessage()); err != nil {
+			return errors.Wrap(err, "failed to broadcast sync committee message")
+		}
+	case beacon.RoleTypeSyncCommitteeContribution:
+		logger.Debug("submitting contribution and proof")
+		blsSig := spec.BLSSignature{}
+		copy(blsSig[:], signature.Serialize()[:])
+		inputValue.GetSignedContributionAndProof().Signature = blsSig
+		if err := v.beacon.SubmitSignedContributionAndProof(inputValue.GetSignedContributionAndProof()); err != nil {
+			return errors.Wrap(err, "failed to broadcast contribution and proof")
+		}
 	default:
 		return errors.New("role is undefined, can't reconstruct signature")
 	}
@@ -14,6 +14,7 @@ type ICollection interface {
 	SaveValidatorShare(share *Share) error
 	GetValidatorShare(key []byte) (*Share, bool, error)
 	GetAllValidatorsShare() ([]*Share, error)
+	DeleteValidatorShare(key []byte) error
 	CleanAllShares() error
 }
 
@@ -83,6 +84,14 @@ func (s *Collection) getUnsafe(key []byte) (*Share, bool, error) {
 	return share, found, err
 }
 
+// DeleteValidatorShare removes the share of the given validator public key
+func (s *Collection) DeleteValidatorShare(key []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete(collectionPrefix(), key)
+}
+
 // CleanAllShares cleans all existing shares from DB
 func (s *Collection) CleanAllShares() error {
 	return s.db.RemoveAllByCollection(collectionPrefix())
@@ -60,6 +60,14 @@ func TestSaveAndGetValidatorStorage(t *testing.T) {
 	validators, err := collection.GetAllValidatorsShare()
 	require.NoError(t, err)
 	require.EqualValues(t, len(validators), 2)
+
+	require.NoError(t, collection.DeleteValidatorShare(validatorShare.PublicKey.Serialize()))
+	_, found, err = collection.GetValidatorShare(validatorShare.PublicKey.Serialize())
+	require.NoError(t, err)
+	require.False(t, found)
+	validators, err = collection.GetAllValidatorsShare()
+	require.NoError(t, err)
+	require.EqualValues(t, len(validators), 1)
 }
 
 func generateRandomValidatorShare() (*Share, *bls.SecretKey) {
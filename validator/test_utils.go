@@ -3,6 +3,8 @@ package validator
 import (
 	"encoding/hex"
 	api "github.com/attestantio/go-eth2-client/api/v1"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/beacon"
 	"github.com/bloxapp/ssv/beacon/valcheck"
@@ -52,6 +54,7 @@ type testIBFT struct {
 	decided         bool
 	signaturesCount int
 	identifier      []byte
+	nextSeqNumber   uint64
 }
 
 func (t *testIBFT) Init() error {
@@ -99,15 +102,31 @@ func (t *testIBFT) GetIdentifier() []byte {
 }
 
 func (t *testIBFT) NextSeqNumber() (uint64, error) {
-	return 0, nil
+	return t.nextSeqNumber, nil
 }
 
+func (t *testIBFT) Stop() {}
+
 /**
 testBeacon
 */
 type testBeacon struct {
 	refAttestationData       *spec.AttestationData
+	refHighestAttestation    *spec.AttestationData
 	LastSubmittedAttestation *spec.Attestation
+	refAggregateAttestation  *spec.Attestation
+	LastSubmittedAggregate   *spec.SignedAggregateAndProof
+	refBeaconBlock           *eth2spec.VersionedBeaconBlock
+	LastSubmittedBeaconBlock *eth2spec.VersionedSignedBeaconBlock
+	// LastAggregateAttestationDataRoot is the attestation data root of the last requested aggregate
+	LastAggregateAttestationDataRoot *spec.Root
+
+	refSyncCommitteeBlockRoot         spec.Root
+	LastSubmittedSyncCommitteeMessage *altair.SyncCommitteeMessage
+	refSyncCommitteeContribution      *altair.SyncCommitteeContribution
+	refSyncSelectionProofRoot         []byte
+	LastSubmittedContribution         *altair.SignedContributionAndProof
+	LastSubmittedVoluntaryExit        *spec.SignedVoluntaryExit
 }
 
 func newTestBeacon(t *testing.T) *testBeacon {
@@ -115,6 +134,10 @@ func newTestBeacon(t *testing.T) *testBeacon {
 	ret.refAttestationData = &spec.AttestationData{}
 	err := ret.refAttestationData.UnmarshalSSZ(refAttestationDataByts) // ignore error
 	require.NoError(t, err)
+	ret.refHighestAttestation = &spec.AttestationData{
+		Source: &spec.Checkpoint{},
+		Target: &spec.Checkpoint{},
+	}
 	return ret
 }
 
@@ -129,6 +152,14 @@ func (b *testBeacon) GetDuties(epoch spec.Epoch, validatorIndices []spec.Validat
 	return nil, nil
 }
 
+func (b *testBeacon) GetLookaheadDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	return nil, nil
+}
+
+func (b *testBeacon) GetProposerDuties(epoch spec.Epoch, validatorIndices []spec.ValidatorIndex) ([]*beacon.Duty, error) {
+	return nil, nil
+}
+
 func (b *testBeacon) GetValidatorData(validatorPubKeys []spec.BLSPubKey) (map[spec.ValidatorIndex]*api.Validator, error) {
 	return nil, nil
 }
@@ -156,10 +187,117 @@ func (b *testBeacon) SubscribeToCommitteeSubnet(subscription []*api.BeaconCommit
 	panic("implement me")
 }
 
-func (b *testBeacon) AddShare(shareKey *bls.SecretKey) error {
+func (b *testBeacon) GetBeaconBlock(slot spec.Slot, randaoReveal spec.BLSSignature) (*eth2spec.VersionedBeaconBlock, error) {
+	return b.refBeaconBlock, nil
+}
+
+func (b *testBeacon) SubmitBeaconBlock(block *eth2spec.VersionedSignedBeaconBlock) error {
+	b.LastSubmittedBeaconBlock = block
+	return nil
+}
+
+func (b *testBeacon) SignRandaoReveal(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(refSplitShares[0]); err != nil {
+		return nil, nil, err
+	}
+	return sk.SignByte(refSigRoot).Serialize(), refSigRoot, nil
+}
+
+func (b *testBeacon) SignBeaconBlock(block *eth2spec.VersionedBeaconBlock, duty *beacon.Duty, pk []byte) (*eth2spec.VersionedSignedBeaconBlock, []byte, error) {
+	panic("implement me")
+}
+
+func (b *testBeacon) GetAggregateAttestation(slot spec.Slot, committeeIndex spec.CommitteeIndex, attestationDataRoot *spec.Root) (*spec.Attestation, error) {
+	b.LastAggregateAttestationDataRoot = attestationDataRoot
+	return b.refAggregateAttestation, nil
+}
+
+func (b *testBeacon) SubmitSignedAggregateSelectionProof(msg *spec.SignedAggregateAndProof) error {
+	b.LastSubmittedAggregate = msg
+	return nil
+}
+
+func (b *testBeacon) SignSelectionProof(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return b.SignRandaoReveal(duty, pk)
+}
+
+func (b *testBeacon) SignAggregateAndProof(msg *spec.AggregateAndProof, duty *beacon.Duty, pk []byte) (*spec.SignedAggregateAndProof, []byte, error) {
 	panic("implement me")
 }
 
+func (b *testBeacon) SubscribeToHeadEvents(handler func(event *api.HeadEvent)) {
+	panic("implement me")
+}
+
+func (b *testBeacon) SubscribeToSyncCommitteeSubnet(subscriptions []*api.SyncCommitteeSubscription) error {
+	panic("implement me")
+}
+
+func (b *testBeacon) GetSyncCommitteeBlockRoot(slot spec.Slot) (*spec.Root, error) {
+	return &b.refSyncCommitteeBlockRoot, nil
+}
+
+func (b *testBeacon) SubmitSyncCommitteeMessage(msg *altair.SyncCommitteeMessage) error {
+	b.LastSubmittedSyncCommitteeMessage = msg
+	return nil
+}
+
+func (b *testBeacon) GetSyncCommitteeContribution(slot spec.Slot, subcommitteeIndex uint64, blockRoot spec.Root) (*altair.SyncCommitteeContribution, error) {
+	contribution := *b.refSyncCommitteeContribution
+	contribution.SubcommitteeIndex = subcommitteeIndex
+	return &contribution, nil
+}
+
+func (b *testBeacon) SubmitSignedContributionAndProof(msg *altair.SignedContributionAndProof) error {
+	b.LastSubmittedContribution = msg
+	return nil
+}
+
+func (b *testBeacon) SignSyncCommitteeMessage(blockRoot spec.Root, duty *beacon.Duty, pk []byte) (*altair.SyncCommitteeMessage, []byte, error) {
+	sig := spec.BLSSignature{}
+	copy(sig[:], refAttestationSplitSigs[0])
+	return &altair.SyncCommitteeMessage{
+		Slot:            duty.Slot,
+		BeaconBlockRoot: blockRoot,
+		ValidatorIndex:  duty.ValidatorIndex,
+		Signature:       sig,
+	}, refSigRoot, nil
+}
+
+func (b *testBeacon) SignSyncCommitteeSelectionProof(subcommitteeIndex uint64, duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(refSplitShares[0]); err != nil {
+		return nil, nil, err
+	}
+	return sk.SignByte(b.refSyncSelectionProofRoot).Serialize(), b.refSyncSelectionProofRoot, nil
+}
+
+func (b *testBeacon) SignContributionAndProof(msg *altair.ContributionAndProof, duty *beacon.Duty, pk []byte) (*altair.SignedContributionAndProof, []byte, error) {
+	panic("implement me")
+}
+
+func (b *testBeacon) SubmitVoluntaryExit(exit *spec.SignedVoluntaryExit) error {
+	b.LastSubmittedVoluntaryExit = exit
+	return nil
+}
+
+func (b *testBeacon) SignVoluntaryExit(duty *beacon.Duty, pk []byte) ([]byte, []byte, error) {
+	return b.SignRandaoReveal(duty, pk)
+}
+
+func (b *testBeacon) AddShare(shareKey *bls.SecretKey) error {
+	return nil
+}
+
+func (b *testBeacon) RemoveShare(pubKey string) error {
+	return nil
+}
+
+func (b *testBeacon) RetrieveHighestAttestation(pubKey []byte) (*spec.AttestationData, error) {
+	return b.refHighestAttestation, nil
+}
+
 func (b *testBeacon) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte, error) {
 	panic("implement me")
 }
@@ -167,8 +305,13 @@ func (b *testBeacon) SignIBFTMessage(message *proto.Message, pk []byte) ([]byte,
 func (b *testBeacon) GetDomain(data *spec.AttestationData) ([]byte, error) {
 	panic("implement")
 }
+func (b *testBeacon) GetEpochDomain(domainType beacon.DomainType, epoch spec.Epoch) ([]byte, error) {
+	return []byte(domainType), nil
+}
 func (b *testBeacon) ComputeSigningRoot(object interface{}, domain []byte) ([32]byte, error) {
-	panic("implement")
+	root := [32]byte{}
+	copy(root[:], refSigRoot)
+	return root, nil
 }
 
 func testingValidator(t *testing.T, decided bool, signaturesCount int, identifier []byte) *Validator {
@@ -181,18 +324,19 @@ func testingValidator(t *testing.T, decided bool, signaturesCount int, identifie
 	ret.ibfts[beacon.RoleTypeAttester] = &testIBFT{decided: decided, signaturesCount: signaturesCount}
 	ret.ibfts[beacon.RoleTypeAttester].(*testIBFT).identifier = identifier
 	require.NoError(t, ret.ibfts[beacon.RoleTypeAttester].Init())
-	ret.valueCheck = valcheck.New()
+	ret.valueCheck = valcheck.New(ret.beacon)
 	ret.signer = ret.beacon
 
 	// nodes
 	ret.network = local.NewLocalNetwork()
 	ret.msgQueue = msgqueue.New()
+	ret.stopCh = make(chan struct{})
 
 	// validatorStorage pk
 	pk := &bls.PublicKey{}
 	err := pk.Deserialize(refPk)
 
-	ret.Share = &storage.Share{
+	ret.share = &storage.Share{
 		NodeID:    1,
 		PublicKey: pk,
 		Committee: map[uint64]*proto.Node{
@@ -219,8 +363,10 @@ func testingValidator(t *testing.T, decided bool, signaturesCount int, identifie
 
 	// timeout
 	ret.signatureCollectionTimeout = time.Second * 2
+	ret.preConsensusSigTimeout = time.Second * 2
 
 	go ret.listenToSignatureMessages()
+	go ret.listenToPreConsensusSignatureMessages()
 	return ret
 }
 
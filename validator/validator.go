@@ -31,29 +31,53 @@ type Options struct {
 	Logger                     *zap.Logger
 	Share                      *storage.Share
 	SignatureCollectionTimeout time.Duration
+	PreConsensusSigTimeout     time.Duration
 	Network                    network.Network
 	Beacon                     beacon.Beacon
 	ETHNetwork                 *core.Network
 	DB                         basedb.IDb
 	Fork                       forks.Fork
-	Signer                     beacon.Signer
+	Signer                     beacon.KeyManager
 }
 
 // Validator struct that manages all ibft wrappers
 type Validator struct {
 	ctx                        context.Context
 	logger                     *zap.Logger
-	Share                      *storage.Share
 	ethNetwork                 *core.Network
 	beacon                     beacon.Beacon
 	ibfts                      map[beacon.RoleType]ibft.Controller
+	ibftsLock                  sync.RWMutex
 	msgQueue                   *msgqueue.MessageQueue
 	network                    network.Network
 	signatureCollectionTimeout time.Duration
+	preConsensusSigTimeout     time.Duration
 	valueCheck                 *valcheck.SlashingProtection
 	startOnce                  sync.Once
+	started                    bool
+	stopOnce                   sync.Once
+	stopCh                     chan struct{}
 	fork                       forks.Fork
 	signer                     beacon.Signer
+	db                         basedb.IDb
+
+	// dutyLock is held for reading by running duties and for writing while the share is updated
+	dutyLock sync.RWMutex
+
+	shareLock sync.RWMutex
+	share     *storage.Share
+
+	exitsLock sync.Mutex
+	exits     map[spec.Epoch]*voluntaryExitState
+
+	// decidedAttestation is the attestation data that was decided last, the aggregate is fetched by its root
+	decidedAttestationLock sync.Mutex
+	decidedAttestation     *spec.AttestationData
+
+	// postConsensusRoots are the signing roots of the running post consensus signature rounds by their index key,
+	// the partial signatures of other operators are verified against them before they are propagated
+	postConsensusRootsLock sync.RWMutex
+	postConsensusRoots     map[string][]byte
 }
 
 // New Validator creation
@@ -62,10 +86,7 @@ func New(opt Options) *Validator {
 		With(zap.Uint64("node_id", opt.Share.NodeID))
 
 	msgQueue := msgqueue.New()
-	ibfts := make(map[beacon.RoleType]ibft.Controller)
-	ibfts[beacon.RoleTypeAttester] = setupIbftController(beacon.RoleTypeAttester, logger, opt.DB, opt.Network, msgQueue, opt.Share, opt.Fork, opt.Signer)
-	//ibfts[beacon.RoleAggregator] = setupIbftController(beacon.RoleAggregator, logger, db, opt.Network, msgQueue, opt.Share) TODO not supported for now
-	//ibfts[beacon.RoleProposer] = setupIbftController(beacon.RoleProposer, logger, db, opt.Network, msgQueue, opt.Share) TODO not supported for now
+	ibfts := setupIbfts(logger, opt.DB, opt.Network, msgQueue, opt.Share, opt.Fork, opt.Signer)
 
 	// updating goclient map
 	if opt.Share.HasMetadata() && opt.Share.Metadata.Index > 0 {
@@ -78,35 +99,36 @@ func New(opt Options) *Validator {
 		ctx:                        opt.Context,
 		logger:                     logger,
 		msgQueue:                   msgQueue,
-		Share:                      opt.Share,
+		share:                      opt.Share,
 		signatureCollectionTimeout: opt.SignatureCollectionTimeout,
+		preConsensusSigTimeout:     opt.PreConsensusSigTimeout,
 		network:                    opt.Network,
 		ibfts:                      ibfts,
 		ethNetwork:                 opt.ETHNetwork,
 		beacon:                     opt.Beacon,
-		valueCheck:                 valcheck.New(),
+		valueCheck:                 valcheck.New(opt.Signer),
 		startOnce:                  sync.Once{},
+		stopCh:                     make(chan struct{}),
 		fork:                       opt.Fork,
 		signer:                     opt.Signer,
+		db:                         opt.DB,
 	}
 }
 
 // Start validator
 func (v *Validator) Start() error {
-	if err := v.network.SubscribeToValidatorNetwork(v.Share.PublicKey); err != nil {
+	if err := v.network.SubscribeToValidatorNetwork(v.getShare().PublicKey); err != nil {
 		return errors.Wrap(err, "failed to subscribe topic")
 	}
 
 	v.startOnce.Do(func() {
 		go v.listenToSignatureMessages()
+		go v.listenToPreConsensusSignatureMessages()
 
-		for _, ib := range v.ibfts { // init all ibfts
-			go func(ib ibft.Controller) {
-				if err := ib.Init(); err != nil {
-					v.logger.Error("could not initialize ibft instance", zap.Error(err))
-				}
-			}(ib)
-		}
+		v.ibftsLock.Lock()
+		v.initIbfts()
+		v.started = true
+		v.ibftsLock.Unlock()
 
 		v.logger.Debug("validator started")
 	})
@@ -114,20 +136,109 @@ func (v *Validator) Start() error {
 	return nil
 }
 
+// Stop stops all ibfts and the listeners of the validator and unsubscribes from its topic,
+// a stopped validator can't be started again
+func (v *Validator) Stop() error {
+	var err error
+	v.stopOnce.Do(func() {
+		close(v.stopCh)
+		v.ibftsLock.RLock()
+		for _, ib := range v.ibfts {
+			ib.Stop()
+		}
+		v.ibftsLock.RUnlock()
+		if e := v.network.UnsubscribeFromValidatorNetwork(v.getShare().PublicKey); e != nil {
+			err = errors.Wrap(e, "failed to unsubscribe topic")
+		}
+		v.logger.Debug("validator stopped")
+	})
+	return err
+}
+
+// UpdateShare replaces the share of the validator once it was reshared to a new committee.
+// it waits for running duties to finish, stops the iBFT controllers of the old committee and starts new ones,
+// the identifiers are kept so the new controllers continue from the highest decided sequence of the old ones.
+func (v *Validator) UpdateShare(share *storage.Share) error {
+	oldShare := v.getShare()
+	if !bytes.Equal(share.PublicKey.Serialize(), oldShare.PublicKey.Serialize()) {
+		return errors.New("could not update share of a different validator")
+	}
+
+	v.dutyLock.Lock()
+	defer v.dutyLock.Unlock()
+
+	if share.Metadata == nil {
+		share.Metadata = oldShare.Metadata
+	}
+	ibfts := setupIbfts(v.logger, v.db, v.network, v.msgQueue, share, v.fork, v.signer)
+
+	v.ibftsLock.Lock()
+	defer v.ibftsLock.Unlock()
+
+	for _, ib := range v.ibfts {
+		ib.Stop()
+	}
+	v.shareLock.Lock()
+	v.share = share
+	v.shareLock.Unlock()
+	v.ibfts = ibfts
+	if v.started {
+		v.initIbfts()
+	}
+	v.logger.Info("validator share was updated", zap.Uint64("node_id", share.NodeID),
+		zap.Int("committee size", share.CommitteeSize()))
+	return nil
+}
+
+// getShare returns the current share of the validator, the share is replaced once the validator was reshared
+func (v *Validator) getShare() *storage.Share {
+	v.shareLock.RLock()
+	defer v.shareLock.RUnlock()
+
+	return v.share
+}
+
+// isStarted returns whether the validator was started
+func (v *Validator) isStarted() bool {
+	v.ibftsLock.RLock()
+	defer v.ibftsLock.RUnlock()
+
+	return v.started
+}
+
+// initIbfts inits all ibfts, this method is not thread-safe - should be called after ibftsLock was acquired
+func (v *Validator) initIbfts() {
+	for _, ib := range v.ibfts {
+		go func(ib ibft.Controller) {
+			if err := ib.Init(); err != nil {
+				v.logger.Error("could not initialize ibft instance", zap.Error(err))
+			}
+		}(ib)
+	}
+}
+
 func (v *Validator) listenToSignatureMessages() {
 	sigChan, done := v.network.ReceivedSignatureChan()
 	defer done()
-	for sigMsg := range sigChan {
-		if sigMsg == nil {
-			v.logger.Debug("got nil message")
-			continue
-		}
+	for {
+		select {
+		case <-v.stopCh:
+			return
+		case sigMsg, ok := <-sigChan:
+			if !ok {
+				return
+			}
+			if sigMsg == nil {
+				v.logger.Debug("got nil message")
+				continue
+			}
 
-		if sigMsg.Message != nil && v.oneOfIBFTIdentifiers(sigMsg.Message.Lambda) {
-			v.msgQueue.AddMessage(&network.Message{
-				SignedMessage: sigMsg,
-				Type:          network.NetworkMsg_SignatureType,
-			})
+			if sigMsg.Message != nil && v.oneOfIBFTIdentifiers(sigMsg.Message.Lambda) {
+				v.msgQueue.AddMessage(&network.Message{
+					SignedMessage: sigMsg,
+					Type:          network.NetworkMsg_SignatureType,
+				})
+			}
 		}
 	}
 }
@@ -139,6 +250,24 @@ func (v *Validator) getSlotStartTime(slot uint64) time.Time {
 	return start
 }
 
+func setupIbfts(
+	logger *zap.Logger,
+	db basedb.IDb,
+	network network.Network,
+	msgQueue *msgqueue.MessageQueue,
+	share *storage.Share,
+	fork forks.Fork,
+	signer beacon.Signer,
+) map[beacon.RoleType]ibft.Controller {
+	ibfts := make(map[beacon.RoleType]ibft.Controller)
+	ibfts[beacon.RoleTypeAttester] = setupIbftController(beacon.RoleTypeAttester, logger, db, network, msgQueue, share, fork, signer)
+	ibfts[beacon.RoleTypeProposer] = setupIbftController(beacon.RoleTypeProposer, logger, db, network, msgQueue, share, fork, signer)
+	ibfts[beacon.RoleTypeAggregator] = setupIbftController(beacon.RoleTypeAggregator, logger, db, network, msgQueue, share, fork, signer)
+	ibfts[beacon.RoleTypeSyncCommittee] = setupIbftController(beacon.RoleTypeSyncCommittee, logger, db, network, msgQueue, share, fork, signer)
+	ibfts[beacon.RoleTypeSyncCommitteeContribution] = setupIbftController(beacon.RoleTypeSyncCommitteeContribution, logger, db, network, msgQueue, share, fork, signer)
+	return ibfts
+}
+
 func setupIbftController(
 	role beacon.RoleType,
 	logger *zap.Logger,
@@ -167,10 +296,8 @@ func setupIbftController(
 
 // oneOfIBFTIdentifiers will return true if provided identifier matches one of the iBFT instances.
 func (v *Validator) oneOfIBFTIdentifiers(toMatch []byte) bool {
-	for _, i := range v.ibfts {
-		if bytes.Equal(i.GetIdentifier(), toMatch) {
-			return true
-		}
-	}
-	return false
+	v.ibftsLock.RLock()
+	defer v.ibftsLock.RUnlock()
+
+	return v.ibftByIdentifier(toMatch) != nil
 }
@@ -1,6 +1,12 @@
 package validator
 
 import (
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	v0 "github.com/bloxapp/ssv/operator/forks/v0"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/bloxapp/ssv/validator/storage"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -10,3 +16,53 @@ func TestIdentifierTest(t *testing.T) {
 	require.True(t, node.oneOfIBFTIdentifiers([]byte{1, 2, 3, 4}))
 	require.False(t, node.oneOfIBFTIdentifiers([]byte{1, 2, 3, 3}))
 }
+
+func TestUpdateShare(t *testing.T) {
+	node := testingValidator(t, true, 4, []byte{1, 2, 3, 4})
+	node.fork = v0.New()
+
+	t.Run("different validator", func(t *testing.T) {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		err := node.UpdateShare(&storage.Share{NodeID: 2, PublicKey: sk.GetPublicKey()})
+		require.EqualError(t, err, "could not update share of a different validator")
+		require.EqualValues(t, 1, node.share.NodeID)
+	})
+
+	t.Run("new committee", func(t *testing.T) {
+		newShare := &storage.Share{
+			NodeID:    2,
+			PublicKey: node.share.PublicKey,
+			Committee: map[uint64]*proto.Node{
+				1: {IbftId: 1, Pk: refSplitSharesPubKeys[1]},
+				2: {IbftId: 2, Pk: refSplitSharesPubKeys[0]},
+				3: {IbftId: 3, Pk: refSplitSharesPubKeys[3]},
+				4: {IbftId: 4, Pk: refSplitSharesPubKeys[2]},
+			},
+		}
+		require.NoError(t, node.UpdateShare(newShare))
+		require.EqualValues(t, 2, node.share.NodeID)
+		require.Len(t, node.ibfts, 5)
+		require.EqualValues(t, newShare.Committee, node.ibfts[beacon.RoleTypeAttester].GetIBFTCommittee())
+
+		identifier := []byte(format.IdentifierFormat(node.share.PublicKey.Serialize(), beacon.RoleTypeAttester.String()))
+		require.True(t, node.oneOfIBFTIdentifiers(identifier))
+		require.False(t, node.oneOfIBFTIdentifiers([]byte{1, 2, 3, 4}))
+	})
+
+	t.Run("concurrent readers", func(t *testing.T) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				require.True(t, node.oneOfPreConsensusIdentifiers(node.randaoIdentifier()))
+				node.VoluntaryExits()
+			}
+		}()
+		for i := 0; i < 10; i++ {
+			share := *node.getShare()
+			require.NoError(t, node.UpdateShare(&share))
+		}
+		<-done
+	})
+}
@@ -72,12 +72,25 @@ func (vm *validatorsMap) GetOrCreateValidator(share *storage.Share) *Validator {
 		printShare(share, vm.logger, "setup validator done")
 		opts.Share = nil
 	} else {
-		printShare(v.Share, vm.logger, "get validator")
+		printShare(v.getShare(), vm.logger, "get validator")
 	}
 
 	return vm.validatorsMap[pubKey]
 }
 
+// RemoveValidator removes a validator from the map and returns it, nil is returned if the validator doesn't exist
+func (vm *validatorsMap) RemoveValidator(pubKey string) *Validator {
+	vm.lock.Lock()
+	defer vm.lock.Unlock()
+
+	v, ok := vm.validatorsMap[pubKey]
+	if !ok {
+		return nil
+	}
+	delete(vm.validatorsMap, pubKey)
+	return v
+}
+
 // Size returns the number of validators in the map
 func (vm *validatorsMap) Size() int {
 	vm.lock.RLock()
@@ -0,0 +1,241 @@
+package validator
+
+import (
+	"sort"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// voluntaryExitRole is the identifier suffix of voluntary exit signatures, the seq number of these messages is the exit epoch
+	voluntaryExitRole = "VOLUNTARY_EXIT"
+)
+
+// VoluntaryExit represents a request to exit a validator at a given epoch,
+// the exit is submitted to the beacon chain once this operator approved it and enough members of the committee signed it
+type VoluntaryExit struct {
+	PubKey string `json:"public_key"`
+	Index  uint64 `json:"index"`
+	Epoch  uint64 `json:"epoch"`
+	// Signers are the ids of the committee members that signed the exit
+	Signers   []uint64 `json:"signers"`
+	Threshold int      `json:"threshold"`
+	// Approved is true once this operator signed the exit
+	Approved  bool `json:"approved"`
+	Submitted bool `json:"submitted"`
+}
+
+// voluntaryExitState holds the collected partial signatures of a voluntary exit
+type voluntaryExitState struct {
+	signatures map[uint64][]byte
+	// root is the signing root of the exit, known once this operator signed the exit
+	root      []byte
+	submitted bool
+}
+
+// voluntaryExitIdentifier returns the identifier used for collecting voluntary exit partial signatures
+func (v *Validator) voluntaryExitIdentifier() []byte {
+	return []byte(format.IdentifierFormat(v.getShare().PublicKey.Serialize(), voluntaryExitRole))
+}
+
+// voluntaryExitState returns the state of the exit at the given epoch, creating it if needed,
+// this method is not thread-safe - should be called after exitsLock was acquired
+func (v *Validator) voluntaryExitState(epoch spec.Epoch) (*voluntaryExitState, bool) {
+	if v.exits == nil {
+		v.exits = make(map[spec.Epoch]*voluntaryExitState)
+	}
+	exit, found := v.exits[epoch]
+	if !found {
+		exit = &voluntaryExitState{signatures: make(map[uint64][]byte)}
+		v.exits[epoch] = exit
+	}
+	return exit, found
+}
+
+// voluntaryExitRoot computes the signing root of a voluntary exit of the validator at the given epoch,
+// returns nil if the index of the validator is not known
+func (v *Validator) voluntaryExitRoot(epoch spec.Epoch) ([]byte, error) {
+	share := v.getShare()
+	if !share.HasMetadata() || share.Metadata.Index == 0 {
+		return nil, nil
+	}
+	domain, err := v.beacon.GetEpochDomain(beacon.DomainVoluntaryExit, epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get domain")
+	}
+	root, err := v.beacon.ComputeSigningRoot(&spec.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: share.Metadata.Index,
+	}, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute signing root")
+	}
+	return root[:], nil
+}
+
+// ApproveVoluntaryExit signs a voluntary exit of the validator at the given epoch and broadcasts the partial signature to the committee,
+// approving an exit again broadcasts the signature again (e.g. for operators that restarted since)
+func (v *Validator) ApproveVoluntaryExit(epoch spec.Epoch) error {
+	share := v.getShare()
+	if !share.HasMetadata() || share.Metadata.Index == 0 {
+		return errors.New("could not exit validator: index not found")
+	}
+	pk, err := share.OperatorPubKey()
+	if err != nil {
+		return errors.Wrap(err, "could not find operator pk for signing voluntary exit")
+	}
+	duty := &beacon.Duty{
+		Type:           beacon.RoleTypeVoluntaryExit,
+		Slot:           spec.Slot(uint64(epoch) * v.ethNetwork.SlotsPerEpoch()),
+		ValidatorIndex: share.Metadata.Index,
+	}
+	copy(duty.PubKey[:], share.PublicKey.Serialize())
+	sig, root, err := v.signer.SignVoluntaryExit(duty, pk.Serialize())
+	if err != nil {
+		return errors.Wrap(err, "failed to sign voluntary exit")
+	}
+
+	v.exitsLock.Lock()
+	exit, _ := v.voluntaryExitState(epoch)
+	exit.root = ensureRoot(root)
+	exit.signatures[share.NodeID] = sig
+	v.exitsLock.Unlock()
+
+	if err := v.network.BroadcastPreConsensusSignature(share.PublicKey.Serialize(), &proto.SignedMessage{
+		Message: &proto.Message{
+			Lambda:    v.voluntaryExitIdentifier(),
+			SeqNumber: uint64(epoch),
+		},
+		Signature: sig,
+		SignerIds: []uint64{share.NodeID},
+	}); err != nil {
+		return errors.Wrap(err, "failed to broadcast voluntary exit signature")
+	}
+	v.logger.Info("voluntary exit was approved", zap.Uint64("epoch", uint64(epoch)))
+
+	return v.submitVoluntaryExit(epoch)
+}
+
+// onVoluntaryExitSignature is called when a partial signature of a voluntary exit was received,
+// signatures are verified once the signing root is known (i.e. after this operator approved the exit)
+func (v *Validator) onVoluntaryExitSignature(msg *proto.SignedMessage) {
+	if len(msg.SignerIds) != 1 {
+		return
+	}
+	epoch := spec.Epoch(msg.Message.SeqNumber)
+	signer := msg.SignerIds[0]
+	logger := v.logger.With(zap.Uint64("epoch", uint64(epoch)), zap.Uint64("signer", signer))
+
+	v.exitsLock.Lock()
+	exit, found := v.voluntaryExitState(epoch)
+	if !found {
+		logger.Info("received a new voluntary exit request")
+	}
+	approved := exit.root != nil
+	if approved {
+		if err := v.verifyPartialSignature(msg.Signature, exit.root, signer, v.getShare().Committee); err != nil {
+			v.exitsLock.Unlock()
+			logger.Warn("received invalid voluntary exit signature", zap.Error(err))
+			return
+		}
+	}
+	exit.signatures[signer] = msg.Signature
+	v.exitsLock.Unlock()
+
+	if approved {
+		if err := v.submitVoluntaryExit(epoch); err != nil {
+			logger.Error("could not submit voluntary exit", zap.Error(err))
+		}
+	}
+}
+
+// submitVoluntaryExit reconstructs the signature of an approved voluntary exit and submits it to the beacon chain,
+// does nothing if not enough signatures were collected yet
+func (v *Validator) submitVoluntaryExit(epoch spec.Epoch) error {
+	share := v.getShare()
+
+	v.exitsLock.Lock()
+	exit, found := v.exits[epoch]
+	if !found || exit.root == nil || exit.submitted {
+		v.exitsLock.Unlock()
+		return nil
+	}
+	signatures := make(map[uint64][]byte, len(exit.signatures))
+	for signer, sig := range exit.signatures {
+		if err := v.verifyPartialSignature(sig, exit.root, signer, share.Committee); err != nil {
+			v.logger.Warn("dropping invalid voluntary exit signature", zap.Uint64("signer", signer), zap.Error(err))
+			delete(exit.signatures, signer)
+			continue
+		}
+		signatures[signer] = sig
+	}
+	root := exit.root
+	v.exitsLock.Unlock()
+
+	if len(signatures) < share.ThresholdSize() {
+		v.logger.Debug("waiting for voluntary exit signatures", zap.Uint64("epoch", uint64(epoch)),
+			zap.Int("signatures", len(signatures)), zap.Int("threshold", share.ThresholdSize()))
+		return nil
+	}
+	signature, err := v.reconstructSignature(signatures, root)
+	if err != nil {
+		return err
+	}
+	signedExit := &spec.SignedVoluntaryExit{
+		Message: &spec.VoluntaryExit{
+			Epoch:          epoch,
+			ValidatorIndex: share.Metadata.Index,
+		},
+	}
+	copy(signedExit.Signature[:], signature.Serialize())
+	if err := v.beacon.SubmitVoluntaryExit(signedExit); err != nil {
+		return errors.Wrap(err, "failed to submit voluntary exit")
+	}
+
+	v.exitsLock.Lock()
+	exit.submitted = true
+	v.exitsLock.Unlock()
+	v.logger.Info("voluntary exit was submitted", zap.Uint64("epoch", uint64(epoch)),
+		zap.Int("signature count", len(signatures)))
+	return nil
+}
+
+// VoluntaryExits returns the voluntary exit requests of the validator, sorted by epoch
+func (v *Validator) VoluntaryExits() []*VoluntaryExit {
+	v.exitsLock.Lock()
+	defer v.exitsLock.Unlock()
+
+	share := v.getShare()
+	var index uint64
+	if share.HasMetadata() {
+		index = uint64(share.Metadata.Index)
+	}
+	exits := make([]*VoluntaryExit, 0, len(v.exits))
+	for epoch, exit := range v.exits {
+		signers := make([]uint64, 0, len(exit.signatures))
+		for signer := range exit.signatures {
+			signers = append(signers, signer)
+		}
+		sort.Slice(signers, func(i, j int) bool {
+			return signers[i] < signers[j]
+		})
+		exits = append(exits, &VoluntaryExit{
+			PubKey:    share.PublicKey.SerializeToHexStr(),
+			Index:     index,
+			Epoch:     uint64(epoch),
+			Signers:   signers,
+			Threshold: share.ThresholdSize(),
+			Approved:  exit.root != nil,
+			Submitted: exit.submitted,
+		})
+	}
+	sort.Slice(exits, func(i, j int) bool {
+		return exits[i].Epoch < exits[j].Epoch
+	})
+	return exits
+}
@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/bloxapp/ssv/beacon"
+	"github.com/bloxapp/ssv/ibft/proto"
+	"github.com/bloxapp/ssv/network/msgqueue"
+	"github.com/bloxapp/ssv/utils/format"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func testingExitValidator(t *testing.T) *Validator {
+	identifier := []byte(format.IdentifierFormat(refPk, beacon.RoleTypeAttester.String()))
+	v := testingValidator(t, true, 3, identifier)
+	ethNetwork := core.PraterNetwork
+	v.ethNetwork = &ethNetwork
+	v.share.Metadata = &beacon.ValidatorMetadata{Index: 12}
+	return v
+}
+
+func exitSignatureMsg(v *Validator, epoch uint64, signer uint64, sig []byte) *proto.SignedMessage {
+	return &proto.SignedMessage{
+		Message: &proto.Message{
+			Lambda:    v.voluntaryExitIdentifier(),
+			SeqNumber: epoch,
+		},
+		Signature: sig,
+		SignerIds: []uint64{signer},
+	}
+}
+
+func requireSubmittedExit(t *testing.T, v *Validator, epoch spec.Epoch) {
+	exit := v.beacon.(*testBeacon).LastSubmittedVoluntaryExit
+	require.NotNil(t, exit)
+	require.Equal(t, epoch, exit.Message.Epoch)
+	require.Equal(t, spec.ValidatorIndex(12), exit.Message.ValidatorIndex)
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(append([]byte{}, exit.Signature[:]...)))
+	require.True(t, sig.VerifyByte(v.share.PublicKey, refSigRoot))
+}
+
+func TestVoluntaryExit(t *testing.T) {
+	t.Run("approved after signatures were received", func(t *testing.T) {
+		v := testingExitValidator(t)
+
+		v.onVoluntaryExitSignature(exitSignatureMsg(v, 100, 2, signByShare(t, 1, refSigRoot)))
+		v.onVoluntaryExitSignature(exitSignatureMsg(v, 100, 3, signByShare(t, 2, refSigRoot)))
+		require.Equal(t, &VoluntaryExit{
+			PubKey:    v.share.PublicKey.SerializeToHexStr(),
+			Index:     12,
+			Epoch:     100,
+			Signers:   []uint64{2, 3},
+			Threshold: 3,
+		}, v.VoluntaryExits()[0])
+
+		require.NoError(t, v.ApproveVoluntaryExit(100))
+		requireSubmittedExit(t, v, 100)
+		exits := v.VoluntaryExits()
+		require.Len(t, exits, 1)
+		require.Equal(t, []uint64{1, 2, 3}, exits[0].Signers)
+		require.True(t, exits[0].Approved)
+		require.True(t, exits[0].Submitted)
+	})
+
+	t.Run("approved before signatures were received", func(t *testing.T) {
+		v := testingExitValidator(t)
+
+		require.NoError(t, v.ApproveVoluntaryExit(100))
+		exits := v.VoluntaryExits()
+		require.Len(t, exits, 1)
+		require.True(t, exits[0].Approved)
+		require.False(t, exits[0].Submitted)
+
+		// invalid signatures are not collected once the exit was approved
+		v.onVoluntaryExitSignature(exitSignatureMsg(v, 100, 2, signByShare(t, 2, refSigRoot)))
+		v.onVoluntaryExitSignature(exitSignatureMsg(v, 100, 3, signByShare(t, 2, refSigRoot)))
+		require.Equal(t, []uint64{1, 3}, v.VoluntaryExits()[0].Signers)
+		require.False(t, v.VoluntaryExits()[0].Submitted)
+
+		v.onVoluntaryExitSignature(exitSignatureMsg(v, 100, 4, signByShare(t, 3, refSigRoot)))
+		require.True(t, v.VoluntaryExits()[0].Submitted)
+		requireSubmittedExit(t, v, 100)
+	})
+
+	t.Run("invalid signatures received before approval", func(t *testing.T) {
+		v := testingExitValidator(t)
+
+		v.onVoluntaryExitSignature(exitSignatureMsg(v, 100, 2, signByShare(t, 2, refSigRoot)))
+		v.onVoluntaryExitSignature(exitSignatureMsg(v, 100, 3, signByShare(t, 2, refSigRoot)))
+		require.Len(t, v.VoluntaryExits()[0].Signers, 2)
+
+		require.NoError(t, v.ApproveVoluntaryExit(100))
+		exits := v.VoluntaryExits()
+		require.Equal(t, []uint64{1, 3}, exits[0].Signers)
+		require.False(t, exits[0].Submitted)
+		require.Nil(t, v.beacon.(*testBeacon).LastSubmittedVoluntaryExit)
+	})
+
+	t.Run("signatures received from the network", func(t *testing.T) {
+		v := testingExitValidator(t)
+
+		msg := exitSignatureMsg(v, 100, 2, signByShare(t, 1, refSigRoot))
+		// broadcasting until received as the listener might not be subscribed yet
+		require.Eventually(t, func() bool {
+			require.NoError(t, v.network.BroadcastPreConsensusSignature(nil, msg))
+			exits := v.VoluntaryExits()
+			return len(exits) == 1 && len(exits[0].Signers) == 1
+		}, 5*time.Second, 50*time.Millisecond)
+		require.Equal(t, uint64(100), v.VoluntaryExits()[0].Epoch)
+		require.Equal(t, 0, v.msgQueue.MsgCount(msgqueue.PreConsensusSigIndexKey(v.voluntaryExitIdentifier(), 100)))
+	})
+
+	t.Run("missing index", func(t *testing.T) {
+		v := testingExitValidator(t)
+		v.share.Metadata = nil
+
+		require.EqualError(t, v.ApproveVoluntaryExit(100), "could not exit validator: index not found")
+		require.Len(t, v.VoluntaryExits(), 0)
+	})
+}